@@ -41,6 +41,7 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrInvalidHeader        = errors.New("invalid block header")
 )
 
 // Blockchain is a blockchain reference
@@ -663,7 +664,7 @@ func (b *Blockchain) VerifyPotentialBlock(block *types.Block) error {
 func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
 	// Make sure the consensus layer verifies this block header
 	if err := b.consensus.VerifyHeader(block.Header); err != nil {
-		return nil, fmt.Errorf("failed to verify the header: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHeader, err)
 	}
 
 	// Do the initial block verification
@@ -675,6 +676,52 @@ func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock,
 	return &types.FullBlock{Block: block, Receipts: receipts}, nil
 }
 
+// VerifyFinalizedBlockWithoutExecution verifies the finalized block like VerifyFinalizedBlock,
+// except that its transactions are not executed, so its state root and receipts are not verified.
+// It is used to sync the blocks whose parent state is not available locally, as when
+// bootstrapping the node from the state of a recent block. The returned block has no receipts
+func (b *Blockchain) VerifyFinalizedBlockWithoutExecution(block *types.Block) (*types.FullBlock, error) {
+	// Make sure the block is present
+	if block == nil {
+		return nil, ErrNoBlock
+	}
+
+	// Make sure the consensus layer verifies this block header
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+	}
+
+	// Make sure the block is in line with the parent block
+	if err := b.verifyBlockParent(block); err != nil {
+		return nil, err
+	}
+
+	// Make sure the block body matches the header
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	return &types.FullBlock{Block: block}, nil
+}
+
+// VerifyCheckpointBlock verifies the block trusted as the checkpoint of a checkpoint sync.
+// The consensus layer verifies its header against the headers of its ancestors,
+// but the block is not executed, as the state of its parent is not present
+func (b *Blockchain) VerifyCheckpointBlock(block *types.Block) error {
+	// Make sure the block is present
+	if block == nil {
+		return ErrNoBlock
+	}
+
+	// Make sure the consensus layer verifies this block header
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+	}
+
+	// Make sure the block body matches the header
+	return b.verifyBlockRoots(block)
+}
+
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
 func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
@@ -753,6 +800,28 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+	// Make sure the uncles and transactions roots match up
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return blockResult.Receipts, nil
+}
+
+// verifyBlockRoots verifies that the uncles and transactions of the block
+// match up to the roots of its header
+func (b *Blockchain) verifyBlockRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -761,7 +830,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.Sha3Uncles,
 		))
 
-		return nil, ErrInvalidSha3Uncles
+		return ErrInvalidSha3Uncles
 	}
 
 	// Make sure the transactions root matches up
@@ -772,21 +841,10 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.TxRoot,
 		))
 
-		return nil, ErrInvalidTxRoot
-	}
-
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
-	}
-
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+		return ErrInvalidTxRoot
 	}
 
-	return blockResult.Receipts, nil
+	return nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
@@ -915,6 +973,86 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 	return nil
 }
 
+// WriteCheckpointAncestors writes the headers of the ancestors of a checkpoint block as canonical,
+// without their bodies, receipts and total difficulty, so that the consensus layer can look them up.
+// They are trusted as ancestors of the checkpoint, so they are not verified
+func (b *Blockchain) WriteCheckpointAncestors(headers []*types.Header) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	for _, header := range headers {
+		// the canonical chain up to the head is never overwritten
+		if header.Number <= b.Header().Number {
+			continue
+		}
+
+		if err := b.db.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if err := b.db.WriteCanonicalHash(header.Number, header.Hash); err != nil {
+			return err
+		}
+
+		b.headersCache.Add(header.Hash, header)
+	}
+
+	return nil
+}
+
+// WriteCheckpointBlock writes the block trusted as the checkpoint of a checkpoint sync as the head,
+// without its parent block. Its receipts are not available
+func (b *Blockchain) WriteCheckpointBlock(block *types.Block, source string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	currentHeader := b.Header()
+
+	if block.Number() <= currentHeader.Number {
+		b.logger.Info("block already inserted", "block", block.Number(), "source", source)
+
+		return nil
+	}
+
+	header := block.Header
+
+	if err := b.writeBody(block); err != nil {
+		return err
+	}
+
+	// the total difficulty of the ancestors is unknown,
+	// so the one of the current head is extended by the checkpoint
+	currentTD, ok := b.readTotalDifficulty(currentHeader.Hash)
+	if !ok {
+		return errors.New("failed to get header difficulty")
+	}
+
+	newTD := big.NewInt(0).Add(currentTD, new(big.Int).SetUint64(header.Difficulty))
+	if err := b.db.WriteCanonicalHeader(header, newTD); err != nil {
+		return err
+	}
+
+	b.setCurrentHeader(header, newTD)
+
+	// update snapshot
+	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+		return err
+	}
+
+	evnt := &Event{Source: source, Type: EventHead}
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(newTD)
+
+	b.dispatchEvent(evnt)
+
+	// Update the average gas price
+	b.updateGasPriceAvgWithBlock(block)
+
+	b.logger.Info("checkpoint block", "number", header.Number, "hash", header.Hash, "source", source)
+
+	return nil
+}
+
 // WriteBlock writes a single block to the local blockchain.
 // It doesn't do any kind of verification, only commits the block to the DB
 func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
@@ -1179,6 +1179,178 @@ func TestBlockchain_VerifyBlockParent(t *testing.T) {
 	})
 }
 
+// TestBlockchain_VerifyFinalizedBlockWithoutExecution makes sure that the block is verified
+// without executing its transactions
+func TestBlockchain_VerifyFinalizedBlockWithoutExecution(t *testing.T) {
+	t.Parallel()
+
+	parentHeader := &types.Header{
+		Hash:       types.ZeroHash,
+		ParentHash: types.ZeroHash,
+		GasLimit:   defaultBlockGasTarget,
+	}
+	parentHeader.ComputeHash()
+
+	// Set up the storage callback
+	storageCallback := func(storage *storage.MockStorage) {
+		storage.HookReadHeader(func(hash types.Hash) (*types.Header, error) {
+			return parentHeader, nil
+		})
+	}
+
+	executorCallback := func(executor *mockExecutor) {
+		executor.HookProcessBlock(func(
+			hash types.Hash,
+			block *types.Block,
+			address types.Address,
+		) (*state.Transition, error) {
+			t.Fatal("the block should not be executed")
+
+			return nil, nil
+		})
+	}
+
+	blockchain, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+		StorageCallback:  storageCallback,
+		ExecutorCallback: executorCallback,
+	})
+	if err != nil {
+		t.Fatalf("unable to instantiate new blockchain, %v", err)
+	}
+
+	newBlock := func() *types.Block {
+		return &types.Block{
+			Header: &types.Header{
+				Number:     1,
+				ParentHash: parentHeader.Hash,
+				GasLimit:   parentHeader.GasLimit,
+				Sha3Uncles: types.EmptyUncleHash,
+				TxRoot:     types.EmptyRootHash,
+				StateRoot:  types.StringToHash("1"),
+			},
+		}
+	}
+
+	t.Run("Valid block", func(t *testing.T) {
+		t.Parallel()
+
+		block := newBlock()
+
+		fullBlock, err := blockchain.VerifyFinalizedBlockWithoutExecution(block)
+		if err != nil {
+			t.Fatalf("unable to verify the block, %v", err)
+		}
+
+		assert.Equal(t, block, fullBlock.Block)
+		assert.Nil(t, fullBlock.Receipts)
+	})
+
+	t.Run("Invalid Transactions root", func(t *testing.T) {
+		t.Parallel()
+
+		block := newBlock()
+		block.Header.TxRoot = types.ZeroHash
+
+		_, err := blockchain.VerifyFinalizedBlockWithoutExecution(block)
+		assert.ErrorIs(t, err, ErrInvalidTxRoot)
+	})
+
+	t.Run("Invalid parent", func(t *testing.T) {
+		t.Parallel()
+
+		block := newBlock()
+		block.Header.Number = 2
+
+		_, err := blockchain.VerifyFinalizedBlockWithoutExecution(block)
+		assert.ErrorIs(t, err, ErrInvalidBlockSequence)
+	})
+}
+
+// TestBlockchain_VerifyFinalizedBlock_InvalidHeader makes sure that the headers
+// rejected by the consensus layer are reported as invalid
+func TestBlockchain_VerifyFinalizedBlock_InvalidHeader(t *testing.T) {
+	t.Parallel()
+
+	errInvalidSeal := errors.New("invalid seal")
+
+	verifierCallback := func(verifier *MockVerifier) {
+		verifier.HookVerifyHeader(func(header *types.Header) error {
+			return errInvalidSeal
+		})
+	}
+
+	blockchain, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+		VerifierCallback: verifierCallback,
+	})
+	if err != nil {
+		t.Fatalf("unable to instantiate new blockchain, %v", err)
+	}
+
+	block := &types.Block{
+		Header: &types.Header{
+			Number: 1,
+		},
+	}
+
+	_, err = blockchain.VerifyFinalizedBlock(block)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+	assert.ErrorContains(t, err, errInvalidSeal.Error())
+
+	_, err = blockchain.VerifyFinalizedBlockWithoutExecution(block)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+
+	assert.ErrorIs(t, blockchain.VerifyCheckpointBlock(block), ErrInvalidHeader)
+}
+
+// TestBlockchain_WriteCheckpointBlock makes sure that a checkpoint block is written as the head
+// without its ancestor blocks, and the chain continues from it
+func TestBlockchain_WriteCheckpointBlock(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	checkpoint := headers[8]
+
+	b := NewTestBlockchain(t, headers[:2])
+
+	if err := b.VerifyCheckpointBlock(&types.Block{Header: checkpoint}); err != nil {
+		t.Fatalf("unable to verify the checkpoint block, %v", err)
+	}
+
+	invalid := &types.Block{Header: checkpoint.Copy()}
+	invalid.Header.TxRoot = types.ZeroHash
+
+	assert.ErrorIs(t, b.VerifyCheckpointBlock(invalid), ErrInvalidTxRoot)
+
+	if err := b.WriteCheckpointAncestors(headers[:8]); err != nil {
+		t.Fatalf("unable to write the ancestors, %v", err)
+	}
+
+	// the ancestors don't move the head
+	assert.Equal(t, uint64(1), b.Header().Number)
+
+	if err := b.WriteCheckpointBlock(&types.Block{Header: checkpoint}, "test"); err != nil {
+		t.Fatalf("unable to write the checkpoint block, %v", err)
+	}
+
+	assert.Equal(t, checkpoint.Hash, b.Header().Hash)
+
+	for _, header := range headers[1:9] {
+		found, ok := b.GetHeaderByNumber(header.Number)
+		if !ok {
+			t.Fatalf("header %d not found", header.Number)
+		}
+
+		assert.Equal(t, header.Hash, found.Hash)
+	}
+
+	// the chain continues from the checkpoint
+	if err := b.WriteHeaders(headers[9:]); err != nil {
+		t.Fatalf("unable to write the block following the checkpoint, %v", err)
+	}
+
+	assert.Equal(t, headers[9].Hash, b.Header().Hash)
+}
+
 // TestBlockchain_VerifyBlockBody makes sure that the block body is verified correctly
 func TestBlockchain_VerifyBlockBody(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,535 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultBloomSectionSize is the number of blocks of a bloom bits section
+	defaultBloomSectionSize uint64 = 4096
+
+	// defaultBloomConfirmations is the number of blocks a section
+	// must be behind the head of the chain before being indexed
+	defaultBloomConfirmations uint64 = 64
+
+	// bloomBitLength is the number of bits of a logs bloom
+	bloomBitLength = types.BloomByteLength * 8
+)
+
+// Encodings of the stored bloom bits vectors
+const (
+	bloomBitsRaw    byte = 0x0
+	bloomBitsSparse byte = 0x1
+)
+
+var (
+	ErrInvalidBloomBits       = errors.New("invalid bloom bits vector")
+	ErrTooManyUnindexedBlocks = errors.New("too many blocks are not indexed yet")
+)
+
+// LogIndex is a persisted index of the logs blooms of the canonical chain.
+//
+// The chain is split into sections of blocks. For each section, the index stores
+// one bit vector per bloom bit, in which the n-th bit is set if the logs bloom
+// of the n-th block of the section has this bloom bit set. A query then only reads
+// the vectors of the bloom bits of the searched addresses and topics, instead of
+// the headers (or receipts) of every block of the range.
+//
+// A section is indexed once its last block is a number of confirmations behind the
+// head, and the sections are rolled back if a reorg reaches them. The blocks which
+// are not yet part of an indexed section are filtered using their headers.
+// The blocks whose headers are missing, such as the ones skipped by a checkpoint sync,
+// never match, as their logs are not available anyway.
+type LogIndex struct {
+	logger     hclog.Logger
+	blockchain *Blockchain
+
+	sectionSize   uint64
+	confirmations uint64
+
+	// sections is the number of indexed sections
+	sections uint64
+	// reorgs is the number of reorgs which touched the section being indexed
+	reorgs uint64
+	lock   sync.RWMutex
+
+	updateCh chan struct{}
+	closeCh  chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewLogIndex creates the log index of the given blockchain
+func NewLogIndex(logger hclog.Logger, blockchain *Blockchain) *LogIndex {
+	return &LogIndex{
+		logger:        logger.Named("log-index"),
+		blockchain:    blockchain,
+		sectionSize:   defaultBloomSectionSize,
+		confirmations: defaultBloomConfirmations,
+		updateCh:      make(chan struct{}, 1),
+		closeCh:       make(chan struct{}),
+	}
+}
+
+// Start loads the index state and starts indexing the new sections of the chain
+func (l *LogIndex) Start() {
+	sections, _ := l.blockchain.db.ReadBloomSections()
+
+	// the chain might have been rolled back since the last run
+	if indexable := l.indexableSections(); sections > indexable {
+		sections = indexable
+	}
+
+	l.sections = sections
+
+	subscription := l.blockchain.SubscribeEvents()
+
+	l.wg.Add(2)
+
+	go l.runEventLoop(subscription)
+	go l.runIndexer()
+
+	l.notify()
+}
+
+// Close stops the indexing
+func (l *LogIndex) Close() {
+	close(l.closeCh)
+	l.wg.Wait()
+}
+
+// Sections returns the number of indexed sections
+func (l *LogIndex) Sections() uint64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.sections
+}
+
+// runEventLoop handles the chain events. It must not block,
+// as the chain waits for the events to be delivered.
+func (l *LogIndex) runEventLoop(subscription Subscription) {
+	defer l.wg.Done()
+	defer subscription.Close()
+
+	for {
+		select {
+		case event := <-subscription.GetEventCh():
+			if event == nil {
+				continue
+			}
+
+			if event.Type == EventReorg && len(event.NewChain) > 0 {
+				forkPoint := event.NewChain[0].Number
+				for _, header := range event.NewChain {
+					if header.Number < forkPoint {
+						forkPoint = header.Number
+					}
+				}
+
+				l.rollback(forkPoint)
+			}
+
+			l.notify()
+		case <-l.closeCh:
+			return
+		}
+	}
+}
+
+// runIndexer indexes the sections which got enough confirmations
+func (l *LogIndex) runIndexer() {
+	defer l.wg.Done()
+
+	for {
+		select {
+		case <-l.updateCh:
+		case <-l.closeCh:
+			return
+		}
+
+		for {
+			select {
+			case <-l.closeCh:
+				return
+			default:
+			}
+
+			indexed, err := l.indexNextSection()
+			if err != nil {
+				l.logger.Error("failed to index section", "err", err)
+			}
+
+			if !indexed {
+				break
+			}
+		}
+	}
+}
+
+// notify wakes up the indexer, without blocking
+func (l *LogIndex) notify() {
+	select {
+	case l.updateCh <- struct{}{}:
+	default:
+	}
+}
+
+// indexableSections returns the number of sections with enough confirmations
+func (l *LogIndex) indexableSections() uint64 {
+	head := l.blockchain.Header()
+	if head == nil || head.Number+1 < l.confirmations {
+		return 0
+	}
+
+	return (head.Number + 1 - l.confirmations) / l.sectionSize
+}
+
+// rollback drops the sections including blocks from the given fork point
+func (l *LogIndex) rollback(forkPoint uint64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	section := forkPoint / l.sectionSize
+	if section > l.sections {
+		return
+	}
+
+	// the section being indexed (if any) is outdated
+	l.reorgs++
+
+	if section == l.sections {
+		return
+	}
+
+	l.logger.Info("reorg reached indexed sections, rolling back", "sections", l.sections, "to", section)
+
+	l.sections = section
+
+	if err := l.blockchain.db.WriteBloomSections(section); err != nil {
+		l.logger.Error("failed to write the number of indexed sections", "err", err)
+	}
+}
+
+// indexNextSection indexes the next section if it has enough confirmations,
+// and returns true if it did
+func (l *LogIndex) indexNextSection() (bool, error) {
+	l.lock.RLock()
+	section, reorgs := l.sections, l.reorgs
+	l.lock.RUnlock()
+
+	if section >= l.indexableSections() {
+		return false, nil
+	}
+
+	vectors := make([][]byte, bloomBitLength)
+	for bit := range vectors {
+		vectors[bit] = make([]byte, l.sectionSize/8)
+	}
+
+	first := section * l.sectionSize
+	missing := 0
+
+	for i := uint64(0); i < l.sectionSize; i++ {
+		header, ok := l.blockchain.GetHeaderByNumber(first + i)
+		if !ok {
+			missing++
+
+			continue
+		}
+
+		for j, b := range header.LogsBloom {
+			if b == 0 {
+				continue
+			}
+
+			for k := uint(0); k < 8; k++ {
+				if b&(1<<k) != 0 {
+					bit := uint(types.BloomByteLength-1-j)*8 + k
+					vectors[bit][i/8] |= 1 << (7 - i%8)
+				}
+			}
+		}
+	}
+
+	encoded := make([][]byte, len(vectors))
+	for bit, vector := range vectors {
+		encoded[bit] = encodeBloomBits(vector)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.reorgs != reorgs || l.sections != section {
+		// the section got (partially) reorged while being indexed
+		return true, nil
+	}
+
+	// the bloom bits and the number of indexed sections are written at once,
+	// so a crash can not leave a partially written section marked as indexed
+	if err := l.blockchain.db.WriteBloomSection(section, encoded); err != nil {
+		return false, err
+	}
+
+	l.sections = section + 1
+
+	l.logger.Debug("indexed section", "section", section,
+		"blocks", fmt.Sprintf("%d-%d", first, first+l.sectionSize-1), "missing", missing)
+
+	return true, nil
+}
+
+// FilterBlocks returns the numbers of the blocks in the [from, to] range whose logs
+// bloom possibly matches all the given criteria, each of them being a set of
+// alternative values (addresses or topics) of which at least one must match.
+// An empty set of alternatives matches every block. The blocks which are not
+// indexed yet are filtered by their headers, and it fails if there are more than
+// maxUnindexed of them in the range (no limit if 0), like when the index lags behind
+// a syncing chain.
+func (l *LogIndex) FilterBlocks(from, to uint64, criteria [][][]byte, maxUnindexed uint64) ([]uint64, error) {
+	matcher := newBloomMatcher(criteria)
+
+	if head := l.blockchain.Header(); head != nil && to > head.Number {
+		to = head.Number
+	}
+
+	blocks := make([]uint64, 0)
+
+	if from > to {
+		return blocks, nil
+	}
+
+	blocks, sections, err := l.filterIndexedBlocks(from, to, matcher, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	// the blocks which are not indexed yet
+	start := sections * l.sectionSize
+	if start < from {
+		start = from
+	}
+
+	if maxUnindexed != 0 && start <= to && to-start >= maxUnindexed {
+		return nil, ErrTooManyUnindexedBlocks
+	}
+
+	for number := start; number <= to; number++ {
+		header, ok := l.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			continue
+		}
+
+		if matcher.matchBloom(&header.LogsBloom) {
+			blocks = append(blocks, number)
+		}
+	}
+
+	return blocks, nil
+}
+
+// filterIndexedBlocks appends the numbers of the matching blocks of the indexed
+// sections to the given list, and returns it with the number of indexed sections
+func (l *LogIndex) filterIndexedBlocks(
+	from, to uint64,
+	matcher *bloomMatcher,
+	blocks []uint64,
+) ([]uint64, uint64, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	for section := from / l.sectionSize; section < l.sections && section*l.sectionSize <= to; section++ {
+		vector, err := matcher.matchSection(l.blockchain.db, section, l.sectionSize)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		first := section * l.sectionSize
+
+		for i := uint64(0); i < l.sectionSize; i++ {
+			if number := first + i; number >= from && number <= to && vector[i/8]&(1<<(7-i%8)) != 0 {
+				blocks = append(blocks, number)
+			}
+		}
+	}
+
+	return blocks, l.sections, nil
+}
+
+// bloomMatcher matches logs blooms against sets of alternative values
+type bloomMatcher struct {
+	// criteria holds the bloom bits of every alternative of every criterion
+	criteria [][][3]uint
+}
+
+func newBloomMatcher(criteria [][][]byte) *bloomMatcher {
+	m := &bloomMatcher{
+		criteria: make([][][3]uint, 0, len(criteria)),
+	}
+
+	for _, alternatives := range criteria {
+		if len(alternatives) == 0 {
+			// wildcard
+			continue
+		}
+
+		bits := make([][3]uint, len(alternatives))
+		for i, value := range alternatives {
+			bits[i] = types.BloomBits(value)
+		}
+
+		m.criteria = append(m.criteria, bits)
+	}
+
+	return m
+}
+
+// matchBloom checks if the bloom possibly matches all the criteria
+func (m *bloomMatcher) matchBloom(bloom *types.Bloom) bool {
+	for _, alternatives := range m.criteria {
+		matched := false
+
+		for _, bits := range alternatives {
+			if bloom.IsBitSet(bits[0]) && bloom.IsBitSet(bits[1]) && bloom.IsBitSet(bits[2]) {
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bloomBitsReader reads the bloom bits vectors of the indexed sections
+type bloomBitsReader interface {
+	ReadBloomBits(bit uint, section uint64) ([]byte, bool)
+}
+
+// matchSection returns the bit vector of the blocks of the section
+// whose logs bloom possibly matches all the criteria
+func (m *bloomMatcher) matchSection(db bloomBitsReader, section, sectionSize uint64) ([]byte, error) {
+	vectors := make(map[uint][]byte)
+
+	readVector := func(bit uint) ([]byte, error) {
+		if vector, ok := vectors[bit]; ok {
+			return vector, nil
+		}
+
+		encoded, ok := db.ReadBloomBits(bit, section)
+		if !ok {
+			return nil, fmt.Errorf("bloom bits %d of section %d not found", bit, section)
+		}
+
+		vector, err := decodeBloomBits(encoded, sectionSize/8)
+		if err != nil {
+			return nil, err
+		}
+
+		vectors[bit] = vector
+
+		return vector, nil
+	}
+
+	result := make([]byte, sectionSize/8)
+	for i := range result {
+		result[i] = 0xff
+	}
+
+	for _, alternatives := range m.criteria {
+		matches := make([]byte, sectionSize/8)
+
+		for _, bits := range alternatives {
+			match := make([]byte, sectionSize/8)
+			for i := range match {
+				match[i] = 0xff
+			}
+
+			for _, bit := range bits {
+				vector, err := readVector(bit)
+				if err != nil {
+					return nil, err
+				}
+
+				for i := range match {
+					match[i] &= vector[i]
+				}
+			}
+
+			for i := range matches {
+				matches[i] |= match[i]
+			}
+		}
+
+		for i := range result {
+			result[i] &= matches[i]
+		}
+	}
+
+	return result, nil
+}
+
+// encodeBloomBits encodes the bit vector, as the list of the positions
+// of the set bits if sparse (as it usually is), or as is otherwise
+func encodeBloomBits(vector []byte) []byte {
+	positions := make([]byte, 1, 1+len(vector))
+	positions[0] = bloomBitsSparse
+
+	for i := 0; i < len(vector)*8; i++ {
+		if vector[i/8]&(1<<(7-i%8)) == 0 {
+			continue
+		}
+
+		if len(positions)+2 > len(vector) {
+			return append([]byte{bloomBitsRaw}, vector...)
+		}
+
+		positions = binary.BigEndian.AppendUint16(positions, uint16(i))
+	}
+
+	return positions
+}
+
+// decodeBloomBits decodes a bit vector of the given length
+func decodeBloomBits(encoded []byte, length uint64) ([]byte, error) {
+	if len(encoded) == 0 {
+		return nil, ErrInvalidBloomBits
+	}
+
+	switch encoded[0] {
+	case bloomBitsRaw:
+		if uint64(len(encoded)-1) != length {
+			return nil, ErrInvalidBloomBits
+		}
+
+		return encoded[1:], nil
+	case bloomBitsSparse:
+		if (len(encoded)-1)%2 != 0 {
+			return nil, ErrInvalidBloomBits
+		}
+
+		vector := make([]byte, length)
+
+		for i := 1; i < len(encoded); i += 2 {
+			position := uint64(binary.BigEndian.Uint16(encoded[i:]))
+			if position >= length*8 {
+				return nil, ErrInvalidBloomBits
+			}
+
+			vector[position/8] |= 1 << (7 - position%8)
+		}
+
+		return vector, nil
+	default:
+		return nil, ErrInvalidBloomBits
+	}
+}
@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogIndex(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1  = types.StringToAddress("1")
+		addr2  = types.StringToAddress("2")
+		topic1 = types.StringToHash("100")
+	)
+
+	blooms := map[uint64]types.Bloom{
+		3:  types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: addr1}}}}),
+		17: types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: addr1, Topics: []types.Hash{topic1}}}}}),
+		20: types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: addr2, Topics: []types.Hash{topic1}}}}}),
+		38: types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: addr1}}}}),
+	}
+
+	headers := NewTestHeaders(1)
+	for i := uint64(1); i < 40; i++ {
+		header := &types.Header{
+			Number:       i,
+			ParentHash:   headers[i-1].Hash,
+			TxRoot:       types.EmptyRootHash,
+			Sha3Uncles:   types.EmptyUncleHash,
+			ReceiptsRoot: types.EmptyRootHash,
+			Difficulty:   i,
+			LogsBloom:    blooms[i],
+		}
+		header.ComputeHash()
+
+		headers = append(headers, header)
+	}
+
+	b := NewTestBlockchain(t, headers)
+
+	// the test chain doesn't store its genesis header
+	require.NoError(t, b.db.WriteHeader(headers[0]))
+
+	index := NewLogIndex(hclog.NewNullLogger(), b)
+	index.sectionSize = 8
+	index.confirmations = 2
+
+	index.Start()
+	defer index.Close()
+
+	// the blocks 0-31 are indexed, while the 32-39 ones are not confirmed enough
+	require.Eventually(t, func() bool {
+		return index.Sections() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	filter := func(from, to uint64, criteria ...[][]byte) []uint64 {
+		t.Helper()
+
+		blocks, err := index.FilterBlocks(from, to, criteria, 0)
+		require.NoError(t, err)
+
+		return blocks
+	}
+
+	assert.Equal(t, []uint64{3, 17, 38}, filter(1, 39, [][]byte{addr1.Bytes()}))
+	assert.Equal(t, []uint64{17, 38}, filter(4, 100, [][]byte{addr1.Bytes()}))
+	assert.Equal(t, []uint64{17}, filter(1, 39, [][]byte{addr1.Bytes()}, [][]byte{topic1.Bytes()}))
+	assert.Equal(t, []uint64{17, 20}, filter(1, 39, [][]byte{addr1.Bytes(), addr2.Bytes()}, [][]byte{topic1.Bytes()}))
+	assert.Equal(t, []uint64{20}, filter(1, 39, nil, [][]byte{addr2.Bytes()}))
+	assert.Len(t, filter(1, 39), 39)
+	assert.Empty(t, filter(21, 37, [][]byte{addr1.Bytes(), addr2.Bytes()}))
+
+	// a reorg reaching the indexed sections rolls them back, before they get indexed again
+	index.rollback(10)
+	assert.Equal(t, uint64(1), index.Sections())
+	assert.Equal(t, []uint64{3, 17, 38}, filter(1, 39, [][]byte{addr1.Bytes()}))
+
+	index.notify()
+
+	require.Eventually(t, func() bool {
+		return index.Sections() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	sections, ok := b.db.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), sections)
+}
+
+func TestLogIndex_MissingHeaders(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	headers := NewTestHeaders(20)
+	headers[10].LogsBloom = types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: addr}}}})
+
+	// the genesis header is missing, as the headers before a checkpoint might be
+	b := NewTestBlockchain(t, headers)
+
+	index := NewLogIndex(hclog.NewNullLogger(), b)
+	index.sectionSize = 8
+	index.confirmations = 2
+
+	index.Start()
+	defer index.Close()
+
+	require.Eventually(t, func() bool {
+		return index.Sections() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	blocks, err := index.FilterBlocks(0, 19, [][][]byte{{addr.Bytes()}}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10}, blocks)
+}
+
+func TestLogIndex_UnindexedHead(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	// with the default section size, none of the blocks are indexed yet
+	headers := NewTestHeaders(1500)
+	for _, number := range []int{10, 700, 1499} {
+		headers[number].LogsBloom = types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Address: addr}}}})
+	}
+
+	b := NewTestBlockchain(t, headers)
+
+	index := NewLogIndex(hclog.NewNullLogger(), b)
+
+	index.Start()
+	defer index.Close()
+
+	assert.Equal(t, uint64(0), index.Sections())
+
+	// the blocks which are not indexed yet are filtered within the limit
+	blocks, err := index.FilterBlocks(0, 1499, [][][]byte{{addr.Bytes()}}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 700, 1499}, blocks)
+
+	blocks, err = index.FilterBlocks(0, 1499, [][][]byte{{addr.Bytes()}}, 1500)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 700, 1499}, blocks)
+
+	blocks, err = index.FilterBlocks(600, 1000, [][][]byte{{addr.Bytes()}}, 401)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{700}, blocks)
+
+	_, err = index.FilterBlocks(0, 1499, [][][]byte{{addr.Bytes()}}, 1499)
+	assert.ErrorIs(t, err, ErrTooManyUnindexedBlocks)
+}
+
+func TestBloomBitsEncoding(t *testing.T) {
+	t.Parallel()
+
+	sparse := make([]byte, 512)
+	sparse[0] = 0x80
+	sparse[511] = 0x01
+
+	dense := make([]byte, 512)
+	for i := range dense {
+		dense[i] = 0x55
+	}
+
+	for _, vector := range [][]byte{make([]byte, 512), sparse, dense} {
+		encoded := encodeBloomBits(vector)
+		assert.LessOrEqual(t, len(encoded), len(vector)+1)
+
+		decoded, err := decodeBloomBits(encoded, uint64(len(vector)))
+		require.NoError(t, err)
+		assert.Equal(t, vector, decoded)
+	}
+
+	assert.Len(t, encodeBloomBits(sparse), 5)
+
+	_, err := decodeBloomBits([]byte{bloomBitsSparse, 0xff, 0xff}, 512)
+	assert.ErrorIs(t, err, ErrInvalidBloomBits)
+
+	_, err = decodeBloomBits([]byte{bloomBitsRaw, 0x1}, 512)
+	assert.ErrorIs(t, err, ErrInvalidBloomBits)
+}
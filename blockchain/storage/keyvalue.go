@@ -39,6 +39,9 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// BLOOM_BITS is the prefix for the bloom bits sections of the log index
+	BLOOM_BITS = []byte("m")
 )
 
 // Sub-prefixes
@@ -46,6 +49,8 @@ var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
 	EMPTY  = []byte("empty")
+
+	SECTIONS = []byte("sections")
 )
 
 // KV is a key value storage interface.
@@ -55,6 +60,13 @@ type KV interface {
 	Close() error
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+	NewBatch() Batch
+}
+
+// Batch is a set of key-value pairs written at once
+type Batch interface {
+	Set(p []byte, v []byte)
+	Write() error
 }
 
 // KeyValueStorage is a generic storage for kv databases
@@ -267,6 +279,51 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// BLOOM BITS //
+
+// WriteBloomSection writes the bit vectors of all the bloom bits (indexed by bit)
+// for a section of blocks, and marks the sections up to this one as indexed.
+// Both are written in a single batch, so a section is never partially indexed
+func (s *KeyValueStorage) WriteBloomSection(section uint64, bits [][]byte) error {
+	batch := s.db.NewBatch()
+
+	for bit, vector := range bits {
+		batch.Set(append(append([]byte{}, BLOOM_BITS...), s.bloomBitsKey(uint(bit), section)...), vector)
+	}
+
+	batch.Set(append(append([]byte{}, BLOOM_BITS...), SECTIONS...), s.encodeUint(section+1))
+
+	return batch.Write()
+}
+
+// ReadBloomBits reads the bit vector of the given bloom bit for a section of blocks
+func (s *KeyValueStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	return s.get(BLOOM_BITS, s.bloomBitsKey(bit, section))
+}
+
+// WriteBloomSections writes the number of the indexed bloom bits sections
+func (s *KeyValueStorage) WriteBloomSections(n uint64) error {
+	return s.set(BLOOM_BITS, SECTIONS, s.encodeUint(n))
+}
+
+// ReadBloomSections reads the number of the indexed bloom bits sections
+func (s *KeyValueStorage) ReadBloomSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_BITS, SECTIONS)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+func (s *KeyValueStorage) bloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 10)
+	binary.BigEndian.PutUint16(key[:2], uint16(bit))
+	binary.BigEndian.PutUint64(key[2:], section)
+
+	return key
+}
+
 // WRITE OPERATIONS //
 
 func (s *KeyValueStorage) writeRLP(p, k []byte, raw types.RLPMarshaler) error {
@@ -59,6 +59,27 @@ func (l *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// NewBatch creates a batch of writes to the leveldb storage
+func (l *levelDBKV) NewBatch() storage.Batch {
+	return &levelDBBatch{db: l.db, batch: &leveldb.Batch{}}
+}
+
+// levelDBBatch is a batch of writes to the leveldb storage, applied atomically
+type levelDBBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+// Set adds the key-value pair to the batch
+func (b *levelDBBatch) Set(p []byte, v []byte) {
+	b.batch.Put(p, v)
+}
+
+// Write writes all the key-value pairs of the batch
+func (b *levelDBBatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDBKV) Close() error {
 	return l.db.Close()
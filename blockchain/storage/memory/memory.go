@@ -33,6 +33,30 @@ func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryKV) NewBatch() storage.Batch {
+	return &memoryBatch{kv: m}
+}
+
 func (m *memoryKV) Close() error {
 	return nil
 }
+
+// memoryBatch is a batch of writes to the in memory kv storage
+type memoryBatch struct {
+	kv    *memoryKV
+	pairs [][2][]byte
+}
+
+func (b *memoryBatch) Set(p []byte, v []byte) {
+	b.pairs = append(b.pairs, [2][]byte{p, v})
+}
+
+func (b *memoryBatch) Write() error {
+	for _, pair := range b.pairs {
+		if err := b.kv.Set(pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -37,6 +37,12 @@ type Storage interface {
 	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	WriteBloomSection(section uint64, bits [][]byte) error
+	ReadBloomBits(bit uint, section uint64) ([]byte, bool)
+
+	WriteBloomSections(n uint64) error
+	ReadBloomSections() (uint64, bool)
+
 	Close() error
 }
 
@@ -48,6 +48,9 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testBloomBits", func(t *testing.T) {
+		testBloomBits(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -442,6 +445,59 @@ func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testBloomBits(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadBloomSections()
+	assert.False(t, ok)
+
+	_, ok = s.ReadBloomBits(1, 0)
+	assert.False(t, ok)
+
+	section0 := make([][]byte, 2048)
+	section0[1] = []byte{0x1}
+	section0[2047] = []byte{0x3}
+
+	assert.NoError(t, s.WriteBloomSection(0, section0))
+
+	sections, ok := s.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), sections)
+
+	section1 := make([][]byte, 2048)
+	section1[1] = []byte{0x2}
+
+	assert.NoError(t, s.WriteBloomSection(1, section1))
+
+	for _, c := range []struct {
+		bit      uint
+		section  uint64
+		expected []byte
+	}{
+		{1, 0, []byte{0x1}},
+		{1, 1, []byte{0x2}},
+		{2047, 0, []byte{0x3}},
+	} {
+		bits, ok := s.ReadBloomBits(c.bit, c.section)
+		assert.True(t, ok)
+		assert.Equal(t, c.expected, bits)
+	}
+
+	sections, ok = s.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), sections)
+
+	// rolled back sections
+	assert.NoError(t, s.WriteBloomSections(1))
+
+	sections, ok = s.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), sections)
+}
+
 // Storage delegators
 
 type readCanonicalHashDelegate func(uint64) (types.Hash, bool)
@@ -465,6 +521,10 @@ type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type writeTxLookupDelegate func(types.Hash, types.Hash) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type writeBloomSectionDelegate func(uint64, [][]byte) error
+type readBloomBitsDelegate func(uint, uint64) ([]byte, bool)
+type writeBloomSectionsDelegate func(uint64) error
+type readBloomSectionsDelegate func() (uint64, bool)
 type closeDelegate func() error
 
 type MockStorage struct {
@@ -487,6 +547,10 @@ type MockStorage struct {
 	readReceiptsFn         readReceiptsDelegate
 	writeTxLookupFn        writeTxLookupDelegate
 	readTxLookupFn         readTxLookupDelegate
+	writeBloomSectionFn    writeBloomSectionDelegate
+	readBloomBitsFn        readBloomBitsDelegate
+	writeBloomSectionsFn   writeBloomSectionsDelegate
+	readBloomSectionsFn    readBloomSectionsDelegate
 	closeFn                closeDelegate
 }
 
@@ -722,6 +786,54 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) WriteBloomSection(section uint64, bits [][]byte) error {
+	if m.writeBloomSectionFn != nil {
+		return m.writeBloomSectionFn(section, bits)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBloomSection(fn writeBloomSectionDelegate) {
+	m.writeBloomSectionFn = fn
+}
+
+func (m *MockStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	if m.readBloomBitsFn != nil {
+		return m.readBloomBitsFn(bit, section)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadBloomBits(fn readBloomBitsDelegate) {
+	m.readBloomBitsFn = fn
+}
+
+func (m *MockStorage) WriteBloomSections(n uint64) error {
+	if m.writeBloomSectionsFn != nil {
+		return m.writeBloomSectionsFn(n)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBloomSections(fn writeBloomSectionsDelegate) {
+	m.writeBloomSectionsFn = fn
+}
+
+func (m *MockStorage) ReadBloomSections() (uint64, bool) {
+	if m.readBloomSectionsFn != nil {
+		return m.readBloomSectionsFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadBloomSections(fn readBloomSectionsDelegate) {
+	m.readBloomSectionsFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
@@ -0,0 +1,24 @@
+package dnsdisc
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/dnsdisc/tree"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dnsdiscCmd := &cobra.Command{
+		Use:   "dnsdisc",
+		Short: "Top level command for the DNS discovery of the peers. Only accepts subcommands.",
+	}
+
+	registerSubcommands(dnsdiscCmd)
+
+	return dnsdiscCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// dnsdisc tree
+		tree.GetCommand(),
+	)
+}
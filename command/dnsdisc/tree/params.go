@@ -0,0 +1,120 @@
+package tree
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
+)
+
+const (
+	domainFlag    = "domain"
+	keyFlag       = "key"
+	seqFlag       = "seq"
+	peerFlag      = "peer"
+	peersFileFlag = "peers-file"
+)
+
+var (
+	params = &treeParams{}
+)
+
+var (
+	errNoPeers = errors.New("at least 1 peer is required")
+)
+
+type treeParams struct {
+	domain    string
+	keyPath   string
+	seq       uint64
+	peers     []string
+	peersFile string
+
+	tree *dnsdisc.Tree
+	url  string
+}
+
+func (p *treeParams) getRequiredFlags() []string {
+	return []string{
+		domainFlag,
+		keyFlag,
+	}
+}
+
+func (p *treeParams) initPeers() error {
+	if p.peersFile != "" {
+		raw, err := os.ReadFile(p.peersFile)
+		if err != nil {
+			return fmt.Errorf("unable to read peers file: %w", err)
+		}
+
+		// the file lists one multiaddr per line, ignoring empty lines and comments
+		scanner := bufio.NewScanner(bytes.NewReader(raw))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			p.peers = append(p.peers, line)
+		}
+
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("unable to read peers file: %w", err)
+		}
+	}
+
+	if len(p.peers) == 0 {
+		return errNoPeers
+	}
+
+	return nil
+}
+
+func (p *treeParams) generateTree() error {
+	// the signing key is generated on first use
+	key, err := crypto.GenerateOrReadPrivateKey(p.keyPath)
+	if err != nil {
+		return fmt.Errorf("unable to read tree key: %w", err)
+	}
+
+	if p.tree, err = dnsdisc.MakeTree(p.seq, p.peers, key); err != nil {
+		return err
+	}
+
+	p.url = dnsdisc.MakeURL(p.domain, &key.PublicKey)
+
+	return nil
+}
+
+func (p *treeParams) getResult() command.CommandResult {
+	records := p.tree.Records(p.domain)
+
+	result := &TreeResult{
+		URL:     p.url,
+		Seq:     p.tree.Seq(),
+		Records: make([]*TXTRecord, 0, len(records)),
+	}
+
+	for name, value := range records {
+		result.Records = append(result.Records, &TXTRecord{Name: name, Value: value})
+	}
+
+	// the root is listed first, then the entries by name
+	sort.Slice(result.Records, func(i, j int) bool {
+		if result.Records[i].Name == p.domain || result.Records[j].Name == p.domain {
+			return result.Records[i].Name == p.domain
+		}
+
+		return result.Records[i].Name < result.Records[j].Name
+	})
+
+	return result
+}
@@ -0,0 +1,41 @@
+package tree
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+// TXTRecord is a DNS TXT record publishing an entry of the tree
+type TXTRecord struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type TreeResult struct {
+	URL     string       `json:"url"`
+	Seq     uint64       `json:"seq"`
+	Records []*TXTRecord `json:"records"`
+}
+
+func (r *TreeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DNS TREE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("URL|%s", r.URL),
+		fmt.Sprintf("Sequence|%d", r.Seq),
+	}))
+
+	records := make([]string, 0, len(r.Records))
+	for _, record := range r.Records {
+		records = append(records, fmt.Sprintf("%s|TXT|%q", record.Name, record.Value))
+	}
+
+	buffer.WriteString("\n\n[TXT RECORDS]\n")
+	buffer.WriteString(helper.FormatList(records))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,79 @@
+package tree
+
+import (
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	treeCmd := &cobra.Command{
+		Use: "tree",
+		Short: "Generates the signed DNS TXT records of a tree of peers, " +
+			"to be published under the domain for the DNS discovery",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(treeCmd)
+	helper.SetRequiredFlags(treeCmd, params.getRequiredFlags())
+
+	return treeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.domain,
+		domainFlag,
+		"",
+		"the domain the tree is published under",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keyPath,
+		keyFlag,
+		"",
+		"path to the hex-encoded private key signing the tree, generated if it doesn't exist",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.seq,
+		seqFlag,
+		uint64(time.Now().Unix()),
+		"the sequence number of the tree, which has to increase on every update. "+
+			"Defaults to the current unix time",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.peers,
+		peerFlag,
+		[]string{},
+		"the multiaddr of a peer of the tree",
+	)
+
+	cmd.Flags().StringVar(
+		&params.peersFile,
+		peersFileFlag,
+		"",
+		"path to the file of the peers of the tree (one multiaddr per line)",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initPeers()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.generateTree(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -50,9 +50,23 @@ func (p *statusParams) initPeerInfo(grpcAddress string) error {
 }
 
 func (p *statusParams) getResult() command.CommandResult {
+	traffic := make([]*PeerTrafficResult, 0, len(p.peerStatus.Traffic))
+	for _, t := range p.peerStatus.Traffic {
+		traffic = append(traffic, &PeerTrafficResult{
+			Protocol:    t.Protocol,
+			BytesIn:     t.BytesIn,
+			BytesOut:    t.BytesOut,
+			MessagesIn:  t.MessagesIn,
+			MessagesOut: t.MessagesOut,
+		})
+	}
+
 	return &PeersStatusResult{
-		ID:        p.peerStatus.Id,
-		Protocols: p.peerStatus.Protocols,
-		Addresses: p.peerStatus.Addrs,
+		ID:          p.peerStatus.Id,
+		Protocols:   p.peerStatus.Protocols,
+		Addresses:   p.peerStatus.Addrs,
+		Score:       p.peerStatus.Score,
+		BannedUntil: p.peerStatus.BannedUntil,
+		Traffic:     traffic,
 	}
 }
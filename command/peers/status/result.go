@@ -3,25 +3,58 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
 
 type PeersStatusResult struct {
-	ID        string   `json:"id"`
-	Protocols []string `json:"protocols"`
-	Addresses []string `json:"addresses"`
+	ID          string               `json:"id"`
+	Protocols   []string             `json:"protocols"`
+	Addresses   []string             `json:"addresses"`
+	Score       int64                `json:"score"`
+	BannedUntil int64                `json:"bannedUntil,omitempty"`
+	Traffic     []*PeerTrafficResult `json:"traffic,omitempty"`
+}
+
+// PeerTrafficResult is the traffic exchanged with the peer over a protocol or a gossip topic
+type PeerTrafficResult struct {
+	Protocol    string `json:"protocol"`
+	BytesIn     uint64 `json:"bytesIn"`
+	BytesOut    uint64 `json:"bytesOut"`
+	MessagesIn  uint64 `json:"messagesIn"`
+	MessagesOut uint64 `json:"messagesOut"`
 }
 
 func (r *PeersStatusResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("\n[PEER STATUS]\n")
-	buffer.WriteString(helper.FormatKV([]string{
+	rows := []string{
 		fmt.Sprintf("ID|%s", r.ID),
 		fmt.Sprintf("Protocols|%s", r.Protocols),
 		fmt.Sprintf("Addresses|%s", r.Addresses),
-	}))
+		fmt.Sprintf("Score|%d", r.Score),
+	}
+
+	if r.BannedUntil != 0 {
+		rows = append(rows, fmt.Sprintf("Banned Until|%s", time.Unix(r.BannedUntil, 0).UTC().Format(time.RFC3339)))
+	}
+
+	buffer.WriteString(helper.FormatKV(rows))
+
+	if len(r.Traffic) > 0 {
+		traffic := []string{"Protocol|Bytes In|Bytes Out|Messages In|Messages Out"}
+		for _, t := range r.Traffic {
+			traffic = append(traffic, fmt.Sprintf(
+				"%s|%d|%d|%d|%d", t.Protocol, t.BytesIn, t.BytesOut, t.MessagesIn, t.MessagesOut,
+			))
+		}
+
+		buffer.WriteString("\n\n[PEER TRAFFIC]\n")
+		buffer.WriteString(helper.FormatList(traffic))
+	}
+
 	buffer.WriteString("\n")
 
 	return buffer.String()
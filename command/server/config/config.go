@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/hashicorp/hcl"
 	"gopkg.in/yaml.v3"
 )
@@ -29,11 +30,27 @@ type Config struct {
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCJWTSecretPath     string     `json:"json_rpc_jwt_secret" yaml:"json_rpc_jwt_secret"`
+	JSONRPCAPIKeys           []string   `json:"json_rpc_api_keys" yaml:"json_rpc_api_keys"`
+	JSONRPCNamespaces        []string   `json:"json_rpc_namespaces" yaml:"json_rpc_namespaces"`
+	JSONRPCRateLimit         uint64     `json:"json_rpc_rate_limit" yaml:"json_rpc_rate_limit"`
+	JSONRPCRateBurst         uint64     `json:"json_rpc_rate_burst" yaml:"json_rpc_rate_burst"`
+	JSONRPCMaxExpensiveReqs  uint64     `json:"json_rpc_max_expensive_requests" yaml:"json_rpc_max_expensive_requests"`
+	JSONRPCSlowRequestMs     uint64     `json:"json_rpc_slow_request_threshold" yaml:"json_rpc_slow_request_threshold"`
+	JSONRPCCompressionSize   uint64     `json:"json_rpc_compression_threshold" yaml:"json_rpc_compression_threshold"`
+	JSONRPCMaxRequestSize    uint64     `json:"json_rpc_max_request_size" yaml:"json_rpc_max_request_size"`
+	JSONRPCMaxWSMessageSize  uint64     `json:"json_rpc_max_ws_message_size" yaml:"json_rpc_max_ws_message_size"`
 	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
 	CorsAllowedOrigins       []string   `json:"cors_allowed_origins" yaml:"cors_allowed_origins"`
 
+	// JSONRPCListeners are the json-rpc listeners served
+	// along with the one configured by the json-rpc flags
+	JSONRPCListeners []*JSONRPCListener `json:"json_rpc_listeners" yaml:"json_rpc_listeners"`
+
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+	SnapSync              bool   `json:"snap_sync" yaml:"snap_sync"`
+	CheckpointSync        string `json:"checkpoint_sync" yaml:"checkpoint_sync"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -50,13 +67,52 @@ type Network struct {
 	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
 	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+
+	StaticPeers   []string `json:"static_peers,omitempty" yaml:"static_peers,omitempty"`
+	TrustedPeers  []string `json:"trusted_peers,omitempty" yaml:"trusted_peers,omitempty"`
+	PeerAllowlist string   `json:"peer_allowlist,omitempty" yaml:"peer_allowlist,omitempty"`
+
+	MaxPeerBandwidth uint64 `json:"max_peer_bandwidth,omitempty" yaml:"max_peer_bandwidth,omitempty"`
+
+	DNSDiscovery []string `json:"dns_discovery,omitempty" yaml:"dns_discovery,omitempty"`
+
+	ListenAddrs []string `json:"listen_addrs,omitempty" yaml:"listen_addrs,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit         uint64   `json:"price_limit" yaml:"price_limit"`
+	MaxSlots           uint64   `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued uint64   `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	TxLifetime         uint64   `json:"tx_lifetime" yaml:"tx_lifetime"`
+	OrderingPolicy     string   `json:"ordering_policy" yaml:"ordering_policy"`
+	MaxTxsPerSender    uint64   `json:"max_txs_per_sender" yaml:"max_txs_per_sender"`
+	PriorityAddresses  []string `json:"priority_addresses" yaml:"priority_addresses"`
+	PrivateTxExpiry    uint64   `json:"private_tx_expiry" yaml:"private_tx_expiry"`
+	PrivateTxPeers     []string `json:"private_tx_peers" yaml:"private_tx_peers"`
+	RateLimit          uint64   `json:"rate_limit" yaml:"rate_limit"`
+	RateBurst          uint64   `json:"rate_burst" yaml:"rate_burst"`
+}
+
+// JSONRPCListener defines the configuration params of a json-rpc listener.
+// The CORS origins, batch request, block range, rate and size limits
+// default to the ones of the main listener if not set.
+type JSONRPCListener struct {
+	Transport          string   `json:"transport" yaml:"transport"`
+	Addr               string   `json:"addr" yaml:"addr"`
+	IPCPath            string   `json:"ipc_path" yaml:"ipc_path"`
+	Namespaces         []string `json:"namespaces" yaml:"namespaces"`
+	CorsAllowedOrigins []string `json:"cors_allowed_origins" yaml:"cors_allowed_origins"`
+	BatchRequestLimit  *uint64  `json:"batch_request_limit,omitempty" yaml:"batch_request_limit,omitempty"`
+	BlockRangeLimit    *uint64  `json:"block_range_limit,omitempty" yaml:"block_range_limit,omitempty"`
+	RateLimit          *uint64  `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	RateBurst          *uint64  `json:"rate_burst,omitempty" yaml:"rate_burst,omitempty"`
+	MaxExpensiveReqs   *uint64  `json:"max_expensive_requests,omitempty" yaml:"max_expensive_requests,omitempty"`
+	CompressionSize    *uint64  `json:"compression_threshold,omitempty" yaml:"compression_threshold,omitempty"`
+	MaxRequestSize     *uint64  `json:"max_request_size,omitempty" yaml:"max_request_size,omitempty"`
+	MaxWSMessageSize   *uint64  `json:"max_ws_message_size,omitempty" yaml:"max_ws_message_size,omitempty"`
+	JWTSecretPath      string   `json:"jwt_secret" yaml:"jwt_secret"`
+	APIKeys            []string `json:"api_keys" yaml:"api_keys"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -76,9 +132,34 @@ const (
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
 
+	// DefaultJSONRPCRateBurst maximum number of credits a single json_rpc client
+	// can spend at once (a trace costs 20 credits, a simple getter 1)
+	DefaultJSONRPCRateBurst uint64 = 100
+
+	// DefaultJSONRPCCompressionThreshold size in bytes from which the json_rpc responses are compressed
+	DefaultJSONRPCCompressionThreshold uint64 = 1024
+
+	// DefaultJSONRPCMaxRequestSize maximum size in bytes of a json_rpc HTTP request body or IPC message
+	DefaultJSONRPCMaxRequestSize uint64 = 5 * 1024 * 1024
+
+	// DefaultJSONRPCMaxWSMessageSize maximum size in bytes of a json_rpc WS message
+	DefaultJSONRPCMaxWSMessageSize uint64 = 32 * 1024 * 1024
+
 	// DefaultNumBlockConfirmations minimal number of child blocks required for the parent block to be considered final
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
+
+	// DefaultTxLifetime maximum time (in seconds) the transactions of an inactive account
+	// are kept in the transaction pool, disabled by default so that the operators opt in
+	DefaultTxLifetime uint64 = 0
+
+	// DefaultPrivateTxExpiry number of blocks after which a private transaction
+	// that was not included is dropped from the transaction pool
+	DefaultPrivateTxExpiry uint64 = 25
+
+	// DefaultTxRateBurst maximum number of transactions a single sender or RPC client
+	// can submit at once when the transaction rate limit is enabled
+	DefaultTxRateBurst uint64 = 16
 )
 
 // DefaultConfig returns the default server configuration
@@ -105,6 +186,10 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			TxLifetime:         DefaultTxLifetime,
+			OrderingPolicy:     string(txpool.PriceOrdering),
+			PrivateTxExpiry:    DefaultPrivateTxExpiry,
+			RateBurst:          DefaultTxRateBurst,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -114,8 +199,14 @@ func DefaultConfig() *Config {
 		LogFilePath:              "",
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
+		JSONRPCRateBurst:         DefaultJSONRPCRateBurst,
+		JSONRPCCompressionSize:   DefaultJSONRPCCompressionThreshold,
+		JSONRPCMaxRequestSize:    DefaultJSONRPCMaxRequestSize,
+		JSONRPCMaxWSMessageSize:  DefaultJSONRPCMaxWSMessageSize,
 		Relayer:                  false,
 		NumBlockConfirmations:    DefaultNumBlockConfirmations,
+		SnapSync:                 false,
+		CheckpointSync:           "",
 	}
 }
 
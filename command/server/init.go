@@ -12,10 +12,14 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
 )
 
 var (
@@ -53,6 +57,34 @@ func (p *serverParams) initRawParams() error {
 		p.initDevMode()
 	}
 
+	if err := p.initPriorityAddresses(); err != nil {
+		return err
+	}
+
+	if err := p.initPrivateTxPeers(); err != nil {
+		return err
+	}
+
+	if err := p.initStaticPeers(); err != nil {
+		return err
+	}
+
+	if err := p.initTrustedPeers(); err != nil {
+		return err
+	}
+
+	if err := p.initJSONRPCJWTSecret(); err != nil {
+		return err
+	}
+
+	if err := p.initJSONRPCListeners(); err != nil {
+		return err
+	}
+
+	if err := p.initCheckpointSync(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
@@ -75,6 +107,179 @@ func (p *serverParams) initLogFileLocation() {
 	}
 }
 
+func (p *serverParams) initPriorityAddresses() error {
+	p.priorityAddresses = make([]types.Address, 0, len(p.rawConfig.TxPool.PriorityAddresses))
+
+	for _, addr := range p.rawConfig.TxPool.PriorityAddresses {
+		if err := types.IsValidAddress(addr); err != nil {
+			return fmt.Errorf("invalid priority address: %w", err)
+		}
+
+		p.priorityAddresses = append(p.priorityAddresses, types.StringToAddress(addr))
+	}
+
+	return nil
+}
+
+func (p *serverParams) initPrivateTxPeers() error {
+	p.privateTxPeers = make([]peer.ID, 0, len(p.rawConfig.TxPool.PrivateTxPeers))
+
+	for _, rawID := range p.rawConfig.TxPool.PrivateTxPeers {
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid private tx peer: %w", err)
+		}
+
+		p.privateTxPeers = append(p.privateTxPeers, id)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initStaticPeers() error {
+	p.staticPeers = make([]*peer.AddrInfo, 0, len(p.rawConfig.Network.StaticPeers))
+
+	for _, rawAddr := range p.rawConfig.Network.StaticPeers {
+		addrInfo, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return fmt.Errorf("invalid static peer: %w", err)
+		}
+
+		p.staticPeers = append(p.staticPeers, addrInfo)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initTrustedPeers() error {
+	p.trustedPeers = make([]peer.ID, 0, len(p.rawConfig.Network.TrustedPeers))
+
+	for _, rawID := range p.rawConfig.Network.TrustedPeers {
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid trusted peer: %w", err)
+		}
+
+		p.trustedPeers = append(p.trustedPeers, id)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initJSONRPCJWTSecret() error {
+	if p.rawConfig.JSONRPCJWTSecretPath == "" {
+		return nil
+	}
+
+	secret, err := jsonrpc.ReadJWTSecret(p.rawConfig.JSONRPCJWTSecretPath)
+	if err != nil {
+		return err
+	}
+
+	p.jsonRPCJWTSecret = secret
+
+	return nil
+}
+
+func (p *serverParams) initJSONRPCListeners() error {
+	p.jsonRPCListeners = make([]*server.JSONRPC, 0, len(p.rawConfig.JSONRPCListeners))
+
+	for i, raw := range p.rawConfig.JSONRPCListeners {
+		listener := &server.JSONRPC{
+			Transport:                raw.Transport,
+			IPCPath:                  raw.IPCPath,
+			AccessControlAllowOrigin: raw.CorsAllowedOrigins,
+			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
+			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			RateLimit:                p.rawConfig.JSONRPCRateLimit,
+			RateBurst:                p.rawConfig.JSONRPCRateBurst,
+			MaxExpensiveRequests:     p.rawConfig.JSONRPCMaxExpensiveReqs,
+			SlowRequestThreshold:     p.jsonRPCSlowRequestThreshold(),
+			CompressionThreshold:     p.rawConfig.JSONRPCCompressionSize,
+			MaxRequestSize:           p.rawConfig.JSONRPCMaxRequestSize,
+			MaxWSMessageSize:         p.rawConfig.JSONRPCMaxWSMessageSize,
+			APIKeys:                  raw.APIKeys,
+			Namespaces:               raw.Namespaces,
+		}
+
+		if listener.AccessControlAllowOrigin == nil {
+			listener.AccessControlAllowOrigin = p.rawConfig.CorsAllowedOrigins
+		}
+
+		if raw.BatchRequestLimit != nil {
+			listener.BatchLengthLimit = *raw.BatchRequestLimit
+		}
+
+		if raw.BlockRangeLimit != nil {
+			listener.BlockRangeLimit = *raw.BlockRangeLimit
+		}
+
+		if raw.RateLimit != nil {
+			listener.RateLimit = *raw.RateLimit
+		}
+
+		if raw.RateBurst != nil {
+			listener.RateBurst = *raw.RateBurst
+		}
+
+		if raw.MaxExpensiveReqs != nil {
+			listener.MaxExpensiveRequests = *raw.MaxExpensiveReqs
+		}
+
+		if raw.CompressionSize != nil {
+			listener.CompressionThreshold = *raw.CompressionSize
+		}
+
+		if raw.MaxRequestSize != nil {
+			listener.MaxRequestSize = *raw.MaxRequestSize
+		}
+
+		if raw.MaxWSMessageSize != nil {
+			listener.MaxWSMessageSize = *raw.MaxWSMessageSize
+		}
+
+		if raw.Transport == "ipc" {
+			if raw.IPCPath == "" {
+				return fmt.Errorf("json-rpc listener #%d: ipc path not defined", i)
+			}
+		} else {
+			addr, err := helper.ResolveAddr(raw.Addr, helper.AllInterfacesBinding)
+			if err != nil {
+				return fmt.Errorf("json-rpc listener #%d: %w", i, err)
+			}
+
+			listener.JSONRPCAddr = addr
+		}
+
+		if raw.JWTSecretPath != "" {
+			secret, err := jsonrpc.ReadJWTSecret(raw.JWTSecretPath)
+			if err != nil {
+				return fmt.Errorf("json-rpc listener #%d: %w", i, err)
+			}
+
+			listener.JWTSecret = secret
+		}
+
+		p.jsonRPCListeners = append(p.jsonRPCListeners, listener)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initCheckpointSync() error {
+	if p.rawConfig.CheckpointSync == "" {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.checkpointSync, parseErr = syncer.ParseCheckpoint(p.rawConfig.CheckpointSync); parseErr != nil {
+		return fmt.Errorf("invalid checkpoint sync: %w", parseErr)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initBlockGasTarget() error {
 	var parseErr error
 
@@ -216,6 +421,10 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initListenAddresses(); err != nil {
+		return err
+	}
+
 	if err := p.initJSONRPCAddress(); err != nil {
 		return err
 	}
@@ -283,6 +492,21 @@ func (p *serverParams) initDNSAddress() error {
 	return nil
 }
 
+func (p *serverParams) initListenAddresses() error {
+	p.listenAddresses = make([]multiaddr.Multiaddr, 0, len(p.rawConfig.Network.ListenAddrs))
+
+	for _, rawAddr := range p.rawConfig.Network.ListenAddrs {
+		addr, err := multiaddr.NewMultiaddr(rawAddr)
+		if err != nil {
+			return fmt.Errorf("invalid listen address %s: %w", rawAddr, err)
+		}
+
+		p.listenAddresses = append(p.listenAddresses, addr)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initJSONRPCAddress() error {
 	var parseErr error
 
@@ -3,13 +3,18 @@ package server
 import (
 	"errors"
 	"net"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -21,15 +26,39 @@ const (
 	prometheusAddressFlag        = "prometheus"
 	natFlag                      = "nat"
 	dnsFlag                      = "dns"
+	libp2pListenFlag             = "libp2p-listen"
 	sealFlag                     = "seal"
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
+	staticPeersFlag              = "static-peers"
+	trustedPeersFlag             = "trusted-peers"
+	peerAllowlistFlag            = "peer-allowlist"
+	maxPeerBandwidthFlag         = "max-peer-bandwidth"
+	dnsDiscoveryFlag             = "dns-discovery"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCJWTSecretFlag         = "json-rpc-jwt-secret"
+	jsonRPCAPIKeysFlag           = "json-rpc-api-keys"
+	jsonRPCNamespacesFlag        = "json-rpc-namespaces"
+	jsonRPCRateLimitFlag         = "json-rpc-rate-limit"
+	jsonRPCRateBurstFlag         = "json-rpc-rate-burst"
+	jsonRPCMaxExpensiveReqsFlag  = "json-rpc-max-expensive-requests"
+	jsonRPCSlowRequestFlag       = "json-rpc-slow-request-threshold"
+	jsonRPCCompressionFlag       = "json-rpc-compression-threshold"
+	jsonRPCMaxRequestSizeFlag    = "json-rpc-max-request-size"
+	jsonRPCMaxWSMessageSizeFlag  = "json-rpc-max-ws-message-size"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	txLifetimeFlag               = "tx-lifetime"
+	orderingPolicyFlag           = "ordering-policy"
+	maxTxsPerSenderFlag          = "max-txs-per-sender"
+	priorityAddressesFlag        = "priority-addresses"
+	privateTxExpiryFlag          = "private-tx-expiry"
+	privateTxPeersFlag           = "private-tx-peers"
+	txRateLimitFlag              = "tx-rate-limit"
+	txRateBurstFlag              = "tx-rate-burst"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -40,6 +69,8 @@ const (
 
 	relayerFlag               = "relayer"
 	numBlockConfirmationsFlag = "num-block-confirmations"
+	snapSyncFlag              = "snap-sync"
+	checkpointSyncFlag        = "checkpoint-sync"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -74,8 +105,11 @@ type serverParams struct {
 	prometheusAddress *net.TCPAddr
 	natAddress        net.IP
 	dnsAddress        multiaddr.Multiaddr
+	listenAddresses   []multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
+	jsonRPCJWTSecret  []byte
+	jsonRPCListeners  []*server.JSONRPC
 
 	blockGasTarget uint64
 	devInterval    uint64
@@ -88,7 +122,15 @@ type serverParams struct {
 
 	logFileLocation string
 
+	priorityAddresses []types.Address
+	privateTxPeers    []peer.ID
+
+	staticPeers  []*peer.AddrInfo
+	trustedPeers []peer.ID
+
 	relayer bool
+
+	checkpointSync *syncer.Checkpoint
 }
 
 func (p *serverParams) isMaxPeersSet() bool {
@@ -124,6 +166,10 @@ func (p *serverParams) isDevConsensus() bool {
 	return server.ConsensusType(p.genesisConfig.Params.GetEngine()) == server.DevConsensus
 }
 
+func (p *serverParams) jsonRPCSlowRequestThreshold() time.Duration {
+	return time.Duration(p.rawConfig.JSONRPCSlowRequestMs) * time.Millisecond
+}
+
 func (p *serverParams) getRestoreFilePath() *string {
 	if p.rawConfig.RestoreFile != "" {
 		return &p.rawConfig.RestoreFile
@@ -152,9 +198,20 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.rawConfig.CorsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			JWTSecret:                p.jsonRPCJWTSecret,
+			APIKeys:                  p.rawConfig.JSONRPCAPIKeys,
+			Namespaces:               p.rawConfig.JSONRPCNamespaces,
+			RateLimit:                p.rawConfig.JSONRPCRateLimit,
+			RateBurst:                p.rawConfig.JSONRPCRateBurst,
+			MaxExpensiveRequests:     p.rawConfig.JSONRPCMaxExpensiveReqs,
+			SlowRequestThreshold:     p.jsonRPCSlowRequestThreshold(),
+			CompressionThreshold:     p.rawConfig.JSONRPCCompressionSize,
+			MaxRequestSize:           p.rawConfig.JSONRPCMaxRequestSize,
+			MaxWSMessageSize:         p.rawConfig.JSONRPCMaxWSMessageSize,
 		},
-		GRPCAddr:   p.grpcAddress,
-		LibP2PAddr: p.libp2pAddress,
+		JSONRPCListeners: p.jsonRPCListeners,
+		GRPCAddr:         p.grpcAddress,
+		LibP2PAddr:       p.libp2pAddress,
 		Telemetry: &server.Telemetry{
 			PrometheusAddr: p.prometheusAddress,
 		},
@@ -167,6 +224,12 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxPeers:         p.rawConfig.Network.MaxPeers,
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
+			StaticPeers:      p.staticPeers,
+			TrustedPeers:     p.trustedPeers,
+			PeerAllowlist:    p.rawConfig.Network.PeerAllowlist,
+			MaxPeerBandwidth: p.rawConfig.Network.MaxPeerBandwidth,
+			DNSDiscovery:     p.rawConfig.Network.DNSDiscovery,
+			ListenAddrs:      p.listenAddresses,
 			Chain:            p.genesisConfig,
 		},
 		DataDir:            p.rawConfig.DataDir,
@@ -174,6 +237,14 @@ func (p *serverParams) generateConfig() *server.Config {
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
+		TxLifetime:         time.Duration(p.rawConfig.TxPool.TxLifetime) * time.Second,
+		TxOrderingPolicy:   txpool.OrderingPolicyType(p.rawConfig.TxPool.OrderingPolicy),
+		MaxTxsPerSender:    p.rawConfig.TxPool.MaxTxsPerSender,
+		PriorityAddresses:  p.priorityAddresses,
+		PrivateTxExpiry:    p.rawConfig.TxPool.PrivateTxExpiry,
+		PrivateTxPeers:     p.privateTxPeers,
+		TxRateLimit:        p.rawConfig.TxPool.RateLimit,
+		TxRateBurst:        p.rawConfig.TxPool.RateBurst,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
@@ -182,5 +253,7 @@ func (p *serverParams) generateConfig() *server.Config {
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+		SnapSync:              p.rawConfig.SnapSync,
+		CheckpointSync:        p.checkpointSync,
 	}
 }
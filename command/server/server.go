@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"github.com/0xPolygon/polygon-edge/command/server/export"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/spf13/cobra"
 )
 
@@ -96,6 +97,14 @@ func setFlags(cmd *cobra.Command) {
 		"the host DNS address which can be used by a remote peer for connection",
 	)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.ListenAddrs,
+		libp2pListenFlag,
+		defaultConfig.Network.ListenAddrs,
+		"additional multiaddrs the libp2p service listens on, "+
+			"such as QUIC (/ip4/0.0.0.0/udp/1478/quic-v1) or WebSocket (/ip4/0.0.0.0/tcp/1479/ws) ones",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.BlockGasTarget,
 		blockGasTargetFlag,
@@ -157,6 +166,45 @@ func setFlags(cmd *cobra.Command) {
 		-1,
 		"the client's max number of outbound peers allowed",
 	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.StaticPeers,
+		staticPeersFlag,
+		defaultConfig.Network.StaticPeers,
+		"multiaddrs of the peers the client always stays connected to, "+
+			"exempt from the max peers limits",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.TrustedPeers,
+		trustedPeersFlag,
+		defaultConfig.Network.TrustedPeers,
+		"IDs of the peers which are exempt from the max peers limits",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Network.PeerAllowlist,
+		peerAllowlistFlag,
+		defaultConfig.Network.PeerAllowlist,
+		"path to the file of the peers allowed to connect (one peer ID or multiaddr per line), "+
+			"reloaded on change. Only these peers, and the static and trusted ones, are accepted if set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Network.MaxPeerBandwidth,
+		maxPeerBandwidthFlag,
+		defaultConfig.Network.MaxPeerBandwidth,
+		"the inbound bandwidth allowed to each peer, in bytes per second (0 for unlimited). "+
+			"The gRPC messages exceeding it are delayed, and the gossiped ones are dropped",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.DNSDiscovery,
+		dnsDiscoveryFlag,
+		defaultConfig.Network.DNSDiscovery,
+		"URLs of the signed DNS trees the peers are discovered from (enrtree://<key>@<domain>), "+
+			"in addition to the bootnodes",
+	)
 	// override default usage value
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 	cmd.MarkFlagsMutuallyExclusive(maxPeersFlag, maxOutboundPeersFlag)
@@ -185,6 +233,73 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.TxLifetime,
+		txLifetimeFlag,
+		defaultConfig.TxPool.TxLifetime,
+		"maximum time (in seconds) the transactions of an inactive account are kept in the pool, "+
+			"value of 0 (default) disables it",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.TxPool.OrderingPolicy,
+		orderingPolicyFlag,
+		defaultConfig.TxPool.OrderingPolicy,
+		fmt.Sprintf(
+			"the policy used for ordering transactions during block building (%s, %s, %s, %s)",
+			txpool.PriceOrdering,
+			txpool.FIFOOrdering,
+			txpool.SenderCapOrdering,
+			txpool.PriorityLanesOrdering,
+		),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.MaxTxsPerSender,
+		maxTxsPerSenderFlag,
+		defaultConfig.TxPool.MaxTxsPerSender,
+		fmt.Sprintf("maximum number of transactions of a single sender per block (%s ordering policy only)",
+			txpool.SenderCapOrdering),
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.TxPool.PriorityAddresses,
+		priorityAddressesFlag,
+		defaultConfig.TxPool.PriorityAddresses,
+		fmt.Sprintf("addresses whose transactions are included first in a block (%s ordering policy only)",
+			txpool.PriorityLanesOrdering),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PrivateTxExpiry,
+		privateTxExpiryFlag,
+		defaultConfig.TxPool.PrivateTxExpiry,
+		"number of blocks after which a private transaction that was not included is dropped, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.TxPool.PrivateTxPeers,
+		privateTxPeersFlag,
+		defaultConfig.TxPool.PrivateTxPeers,
+		"IDs of the trusted peers (usually validators) private transactions are relayed to",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.RateLimit,
+		txRateLimitFlag,
+		defaultConfig.TxPool.RateLimit,
+		"maximum number of transactions per second a single sender or RPC client can submit, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.RateBurst,
+		txRateBurstFlag,
+		defaultConfig.TxPool.RateBurst,
+		"maximum number of transactions a single sender or RPC client can submit at once",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -204,7 +319,86 @@ func setFlags(cmd *cobra.Command) {
 		jsonRPCBlockRangeLimitFlag,
 		defaultConfig.JSONRPCBlockRangeLimit,
 		"max block range to be considered when executing json-rpc requests "+
-			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
+			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it. "+
+			"For eth_getLogs, the limit applies to the number of blocks matched by the log index "+
+			"and to the number of blocks not indexed yet",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCJWTSecretPath,
+		jsonRPCJWTSecretFlag,
+		defaultConfig.JSONRPCJWTSecretPath,
+		"path to the file holding the hex encoded HS256 secret, "+
+			"JSON-RPC requests are required to carry a JWT signed with it, issued within the last minute, if set",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.JSONRPCAPIKeys,
+		jsonRPCAPIKeysFlag,
+		defaultConfig.JSONRPCAPIKeys,
+		"the API keys accepted by the JSON-RPC server (X-API-Key header or bearer token), "+
+			"JSON-RPC requests are required to carry one of them if set",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.JSONRPCNamespaces,
+		jsonRPCNamespacesFlag,
+		defaultConfig.JSONRPCNamespaces,
+		"the JSON-RPC namespaces to expose (eth, net, web3, txpool, bridge, debug, admin), "+
+			"all of them apart from admin if not set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCRateLimit,
+		"number of credits a single JSON-RPC client (IP address or API key) is granted per second, "+
+			"requests cost from 1 credit (simple getters) to 20 credits (traces), value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateBurst,
+		jsonRPCRateBurstFlag,
+		defaultConfig.JSONRPCRateBurst,
+		"maximum number of credits a single JSON-RPC client can spend at once",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCMaxExpensiveReqs,
+		jsonRPCMaxExpensiveReqsFlag,
+		defaultConfig.JSONRPCMaxExpensiveReqs,
+		"maximum number of expensive requests (calls, logs queries and traces) a single JSON-RPC client "+
+			"can run at once, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCSlowRequestMs,
+		jsonRPCSlowRequestFlag,
+		defaultConfig.JSONRPCSlowRequestMs,
+		"duration in milliseconds from which the JSON-RPC requests are logged "+
+			"(method, params digest and duration), value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCCompressionSize,
+		jsonRPCCompressionFlag,
+		defaultConfig.JSONRPCCompressionSize,
+		"size in bytes from which the JSON-RPC responses are compressed (gzip over HTTP, "+
+			"permessage-deflate over WS) if supported by the client, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCMaxRequestSize,
+		jsonRPCMaxRequestSizeFlag,
+		defaultConfig.JSONRPCMaxRequestSize,
+		"maximum size in bytes of a JSON-RPC HTTP request body or IPC message, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCMaxWSMessageSize,
+		jsonRPCMaxWSMessageSizeFlag,
+		defaultConfig.JSONRPCMaxWSMessageSize,
+		"maximum size in bytes of a JSON-RPC WS message, value of 0 disables it",
 	)
 
 	cmd.Flags().StringVar(
@@ -228,6 +422,23 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.SnapSync,
+		snapSyncFlag,
+		defaultConfig.SnapSync,
+		"bootstrap the node from the state of a recent block synced from the peers, "+
+			"instead of executing all the blocks (ibft PoA only)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.CheckpointSync,
+		checkpointSyncFlag,
+		defaultConfig.CheckpointSync,
+		"bootstrap the node from the trusted block <number:hash> and the state at its root, "+
+			"instead of validating the blocks before it (polybft only, the node never validates, "+
+			"even if it is in the validator set, until it is synced from genesis)",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -10,12 +11,18 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc"
 )
 
+var (
+	ErrHeadNotFound = errors.New("chain head not found")
+)
+
 // Consensus is the public interface for consensus mechanism
 // Each consensus mechanism must implement this interface in order to be valid
 type Consensus interface {
@@ -37,6 +44,9 @@ type Consensus interface {
 	// GetBridgeProvider returns an instance of BridgeDataProvider
 	GetBridgeProvider() BridgeDataProvider
 
+	// GetFinalityProvider returns an instance of FinalityProvider
+	GetFinalityProvider() FinalityProvider
+
 	// FilterExtra filters extra data in header that is not a part of block hash
 	FilterExtra(extra []byte) ([]byte, error)
 
@@ -72,12 +82,18 @@ type Params struct {
 	Network        *network.Server
 	Blockchain     *blockchain.Blockchain
 	Executor       *state.Executor
+	StateStorage   itrie.Storage
 	Grpc           *grpc.Server
 	Logger         hclog.Logger
 	SecretsManager secrets.SecretsManager
 	BlockTime      uint64
 
 	NumBlockConfirmations uint64
+
+	// SnapSync enables bootstrapping the node from the state of a recent block
+	SnapSync bool
+	// CheckpointSync is the trusted block the node is bootstrapped from, if set
+	CheckpointSync *syncer.Checkpoint
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -91,3 +107,33 @@ type BridgeDataProvider interface {
 	// GetStateSyncProof retrieves the StateSync proof
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
 }
+
+// FinalityProvider is an interface providing the finality of the chain blocks,
+// used for resolving the "safe" and "finalized" block tags
+type FinalityProvider interface {
+	// GetSafeBlockNumber returns the number of the latest block which is safe from reorgs
+	GetSafeBlockNumber() (uint64, error)
+
+	// GetFinalizedBlockNumber returns the number of the latest finalized block
+	GetFinalizedBlockNumber() (uint64, error)
+}
+
+// InstantFinality is a FinalityProvider for the consensus mechanisms
+// whose blocks are final as soon as they are inserted into the chain.
+// It returns the header of the chain head.
+type InstantFinality func() *types.Header
+
+// GetSafeBlockNumber returns the number of the chain head
+func (f InstantFinality) GetSafeBlockNumber() (uint64, error) {
+	return f.GetFinalizedBlockNumber()
+}
+
+// GetFinalizedBlockNumber returns the number of the chain head
+func (f InstantFinality) GetFinalizedBlockNumber() (uint64, error) {
+	header := f()
+	if header == nil {
+		return 0, ErrHeadNotFound
+	}
+
+	return header.Number, nil
+}
@@ -250,6 +250,10 @@ func (d *Dev) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dev) GetFinalityProvider() consensus.FinalityProvider {
+	return consensus.InstantFinality(d.blockchain.Header)
+}
+
 func (d *Dev) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
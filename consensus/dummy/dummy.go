@@ -79,6 +79,10 @@ func (d *Dummy) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dummy) GetFinalityProvider() consensus.FinalityProvider {
+	return consensus.InstantFinality(d.blockchain.Header)
+}
+
 func (d *Dummy) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
@@ -3,6 +3,7 @@ package ibft
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -39,6 +40,8 @@ var (
 	ErrInvalidMixHash             = errors.New("invalid mixhash")
 	ErrInvalidSha3Uncles          = errors.New("invalid sha3 uncles")
 	ErrWrongDifficulty            = errors.New("wrong difficulty")
+	ErrCheckpointSyncUnsupported  = errors.New("checkpoint sync is not supported by IBFT")
+	ErrSnapSyncUnsupported        = errors.New("snap sync is not supported by IBFT PoS")
 )
 
 type txPoolInterface interface {
@@ -82,6 +85,8 @@ type backendIBFT struct {
 	currentSigner     signer.Signer         // Signer at current sequence
 	currentValidators validators.Validators // signer at current sequence
 	currentHooks      fork.HooksInterface   // Hooks at current sequence
+	currentHeight     uint64                // Height the current modules are set up for
+	modulesLock       sync.RWMutex          // Lock of the current modules, whose height the gossip validator reads
 
 	// Configurations
 	config             *consensus.Config // Consensus configuration
@@ -95,6 +100,17 @@ type backendIBFT struct {
 
 // Factory implements the base consensus Factory method
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
+	// the validators of the blocks following a checkpoint can't be resolved without the blocks before it
+	if params.CheckpointSync != nil {
+		return nil, ErrCheckpointSyncUnsupported
+	}
+
+	if params.SnapSync {
+		if err := checkSnapSyncSupport(params.Config.Config); err != nil {
+			return nil, err
+		}
+	}
+
 	// defaults for user set fields in genesis
 	var (
 		epochSize          = uint64(DefaultEpochSize)
@@ -148,7 +164,10 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 			params.Logger,
 			params.Network,
 			params.Blockchain,
+			params.StateStorage,
 			time.Duration(params.BlockTime)*3*time.Second,
+			params.SnapSync,
+			nil,
 		),
 		secretsManager: params.SecretsManager,
 		Grpc:           params.Grpc,
@@ -170,6 +189,23 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 	return p, nil
 }
 
+// checkSnapSyncSupport fails if any fork is PoS, as the validators of the PoS forks
+// are read from the state of the epoch blocks, which snap sync doesn't download
+func checkSnapSyncSupport(config map[string]interface{}) error {
+	forks, err := fork.GetIBFTForks(config)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range forks {
+		if f.Type == fork.PoS {
+			return ErrSnapSyncUnsupported
+		}
+	}
+
+	return nil
+}
+
 func (i *backendIBFT) Initialize() error {
 	// register the grpc operator
 	if i.Grpc != nil {
@@ -551,6 +587,12 @@ func (i *backendIBFT) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+// GetFinalityProvider returns an instance of FinalityProvider.
+// IBFT blocks are final as soon as they are inserted into the chain
+func (i *backendIBFT) GetFinalityProvider() consensus.FinalityProvider {
+	return consensus.InstantFinality(i.blockchain.Header)
+}
+
 // FilterExtra is the implementation of Consensus interface
 func (i *backendIBFT) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
@@ -567,9 +609,12 @@ func (i *backendIBFT) updateCurrentModules(height uint64) error {
 		return err
 	}
 
+	i.modulesLock.Lock()
 	i.currentSigner = signer
 	i.currentValidators = validators
 	i.currentHooks = hooks
+	i.currentHeight = height
+	i.modulesLock.Unlock()
 
 	i.logFork(lastSigner, signer)
 
@@ -0,0 +1,44 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory_SnapSync(t *testing.T) {
+	t.Parallel()
+
+	poaConfig := map[string]interface{}{
+		"type": "PoA",
+	}
+
+	// the chain switches to PoS at the second fork
+	posForkConfig := map[string]interface{}{
+		"types": []interface{}{
+			map[string]interface{}{
+				"type": "PoA",
+				"from": "0x0",
+				"to":   "0x63",
+			},
+			map[string]interface{}{
+				"type":       "PoS",
+				"deployment": "0x64",
+				"from":       "0x64",
+			},
+		},
+	}
+
+	assert.NoError(t, checkSnapSyncSupport(poaConfig))
+	assert.ErrorIs(t, checkSnapSyncSupport(posForkConfig), ErrSnapSyncUnsupported)
+
+	_, err := Factory(&consensus.Params{
+		Config:   &consensus.Config{Config: posForkConfig},
+		Logger:   hclog.NewNullLogger(),
+		SnapSync: true,
+	})
+	require.ErrorIs(t, err, ErrSnapSyncUnsupported)
+}
@@ -1,6 +1,8 @@
 package ibft
 
 import (
+	"bytes"
+
 	"github.com/0xPolygon/go-ibft/messages/proto"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -28,7 +30,7 @@ func (i *backendIBFT) Multicast(msg *proto.Message) {
 // setupTransport sets up the gossip transport protocol
 func (i *backendIBFT) setupTransport() error {
 	// Define a new topic
-	topic, err := i.network.NewTopic(ibftProto, &proto.Message{})
+	topic, err := i.network.NewTopic(ibftProto, &proto.Message{}, i.validateGossipMessage)
 	if err != nil {
 		return err
 	}
@@ -65,3 +67,54 @@ func (i *backendIBFT) setupTransport() error {
 
 	return nil
 }
+
+// validateGossipMessage is the validator of the consensus topic, which drops the messages
+// of the block being built that are not sent by one of its validators, before they are
+// relayed to the other peers
+func (i *backendIBFT) validateGossipMessage(obj interface{}, _ peer.ID) network.ValidationResult {
+	msg, ok := obj.(*proto.Message)
+	if !ok || msg.View == nil || msg.Payload == nil {
+		return network.ValidationReject
+	}
+
+	// only the senders of the block following the head can be checked. The messages of the
+	// finalized blocks and of the future blocks are neither delivered, relayed nor penalized
+	if head := i.blockchain.Header().Number; msg.View.Height != head+1 {
+		return network.ValidationIgnore
+	}
+
+	signer, err := i.forkManager.GetSigner(msg.View.Height)
+	if err != nil {
+		return network.ValidationIgnore
+	}
+
+	validators, err := i.forkManager.GetValidators(msg.View.Height)
+	if err != nil {
+		return network.ValidationIgnore
+	}
+
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return network.ValidationReject
+	}
+
+	signerAddress, err := signer.EcrecoverFromIBFTMessage(msg.Signature, msgNoSig)
+	if err != nil || !bytes.Equal(msg.From, signerAddress.Bytes()) {
+		return network.ValidationReject
+	}
+
+	if validators.Includes(signerAddress) {
+		return network.ValidationAccept
+	}
+
+	// the validator set of the block may not be updated yet right after the head is inserted,
+	// until the modules are set up for the block, so a new validator is not penalized meanwhile
+	i.modulesLock.RLock()
+	defer i.modulesLock.RUnlock()
+
+	if i.currentHeight != msg.View.Height {
+		return network.ValidationIgnore
+	}
+
+	return network.ValidationReject
+}
@@ -6,6 +6,8 @@ import (
 	"math/big"
 	"sort"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
@@ -26,12 +28,15 @@ var (
 	currentCheckpointBlockNumMethod, _ = contractsapi.CheckpointManager.Abi.Methods["currentCheckpointBlockNumber"]
 	// frequency at which checkpoints are sent to the rootchain (in blocks count)
 	defaultCheckpointsOffset = uint64(900)
+	// period for which the latest checkpoint block queried from the rootchain is cached
+	checkpointBlockCacheTTL = 10 * time.Second
 )
 
 type CheckpointManager interface {
 	PostBlock(req *PostBlockRequest) error
 	BuildEventRoot(epoch uint64) (types.Hash, error)
 	GenerateExitProof(exitID uint64) (types.Proof, error)
+	FinalizedBlockNumber(head uint64) (uint64, error)
 }
 
 var _ CheckpointManager = (*dummyCheckpointManager)(nil)
@@ -45,6 +50,9 @@ func (d *dummyCheckpointManager) BuildEventRoot(epoch uint64) (types.Hash, error
 func (d *dummyCheckpointManager) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (d *dummyCheckpointManager) FinalizedBlockNumber(head uint64) (uint64, error) {
+	return head, nil
+}
 
 var _ CheckpointManager = (*checkpointManager)(nil)
 
@@ -68,6 +76,12 @@ type checkpointManager struct {
 	logger hclog.Logger
 	// state boltDb instance
 	state *State
+	// checkpointBlockLock guards the cached latest checkpoint block
+	checkpointBlockLock sync.Mutex
+	// checkpointBlock is the cached latest checkpoint block number
+	checkpointBlock uint64
+	// checkpointBlockTime is the time at which checkpointBlock was queried
+	checkpointBlockTime time.Time
 }
 
 // newCheckpointManager creates a new instance of checkpointManager
@@ -111,6 +125,31 @@ func (c *checkpointManager) getLatestCheckpointBlock() (uint64, error) {
 	return latestCheckpointBlockNum, nil
 }
 
+// FinalizedBlockNumber returns the latest checkpointed block, which is final
+// as long as the rootchain is. The checkpoint block is cached for
+// checkpointBlockCacheTTL, to avoid querying the rootchain on every request.
+func (c *checkpointManager) FinalizedBlockNumber(head uint64) (uint64, error) {
+	c.checkpointBlockLock.Lock()
+	defer c.checkpointBlockLock.Unlock()
+
+	if time.Since(c.checkpointBlockTime) >= checkpointBlockCacheTTL {
+		checkpointBlock, err := c.getLatestCheckpointBlock()
+		if err != nil {
+			return 0, err
+		}
+
+		c.checkpointBlock = checkpointBlock
+		c.checkpointBlockTime = time.Now()
+	}
+
+	// a checkpoint can be ahead of a syncing node
+	if c.checkpointBlock > head {
+		return head, nil
+	}
+
+	return c.checkpointBlock, nil
+}
+
 // submitCheckpoint sends a transaction with checkpoint data to the rootchain
 func (c *checkpointManager) submitCheckpoint(latestHeader *types.Header, isEndOfEpoch bool) error {
 	lastCheckpointBlockNumber, err := c.getLatestCheckpointBlock()
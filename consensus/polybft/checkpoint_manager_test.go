@@ -236,6 +236,35 @@ func TestCheckpointManager_getCurrentCheckpointID(t *testing.T) {
 	}
 }
 
+func TestCheckpointManager_FinalizedBlockNumber(t *testing.T) {
+	t.Parallel()
+
+	txRelayerMock := newDummyTxRelayer(t)
+	txRelayerMock.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Return("0x10", error(nil)).
+		Once()
+
+	acc, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+
+	checkpointMgr := &checkpointManager{
+		rootChainRelayer: txRelayerMock,
+		key:              acc.Ecdsa,
+		logger:           hclog.NewNullLogger(),
+	}
+
+	finalized, err := checkpointMgr.FinalizedBlockNumber(20)
+	require.NoError(t, err)
+	require.Equal(t, uint64(16), finalized)
+
+	// cached checkpoint block is used, capped to the chain head
+	finalized, err = checkpointMgr.FinalizedBlockNumber(10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), finalized)
+
+	txRelayerMock.AssertExpectations(t)
+}
+
 func TestCheckpointManager_IsCheckpointBlock(t *testing.T) {
 	t.Parallel()
 
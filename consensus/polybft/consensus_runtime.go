@@ -314,7 +314,11 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		c.logger.Error("failed to post block in stake manager", "err", err)
 	}
 
-	if isEndOfEpoch {
+	// the block doesn't follow the last built block after a checkpoint sync,
+	// so the epoch is restarted from it
+	isCheckpoint := c.lastBuiltBlock != nil && fullBlock.Block.Number() > c.lastBuiltBlock.Number+1
+
+	if isEndOfEpoch || isCheckpoint {
 		if epoch, err = c.restartEpoch(fullBlock.Block.Header); err != nil {
 			c.logger.Error("failed to restart epoch after block inserted", "error", err)
 
@@ -601,6 +605,18 @@ func (c *consensusRuntime) GetStateSyncProof(stateSyncID uint64) (types.Proof, e
 	return c.stateSyncManager.GetStateSyncProof(stateSyncID)
 }
 
+// GetSafeBlockNumber returns the number of the chain head, as polybft blocks
+// are final as soon as they are inserted into the chain
+func (c *consensusRuntime) GetSafeBlockNumber() (uint64, error) {
+	return c.config.blockchain.CurrentHeader().Number, nil
+}
+
+// GetFinalizedBlockNumber returns the latest checkpointed block when the bridge
+// is enabled, or the number of the chain head otherwise
+func (c *consensusRuntime) GetFinalizedBlockNumber() (uint64, error) {
+	return c.checkpointManager.FinalizedBlockNumber(c.config.blockchain.CurrentHeader().Number)
+}
+
 // setIsActiveValidator updates the activeValidatorFlag field
 func (c *consensusRuntime) setIsActiveValidator(isActiveValidator bool) {
 	c.activeValidatorFlag.Store(isActiveValidator)
@@ -661,6 +677,33 @@ func (c *consensusRuntime) IsValidValidator(msg *proto.Message) bool {
 	return true
 }
 
+// IsValidGossipSender checks that a gossiped consensus message of the block following the head
+// is sent by a validator of the current epoch. The sender is known to be invalid if the message
+// is not signed by it, or if the block belongs to the current epoch and the sender is not one of
+// its validators. Otherwise, like when the epoch is not restarted yet after an epoch ending block,
+// the validators of the block are not known. Unlike IsValidValidator, it doesn't require the FSM,
+// so the nodes which are not validators can check the messages they relay
+func (c *consensusRuntime) IsValidGossipSender(msg *proto.Message) (valid bool, known bool) {
+	c.lock.RLock()
+	epoch := c.epoch
+	c.lock.RUnlock()
+
+	if epoch == nil {
+		return false, false
+	}
+
+	signerAddress, err := recoverMessageSender(msg)
+	if err != nil {
+		c.logger.Debug("invalid gossiped IBFT message received", "error", err)
+
+		return false, true
+	}
+
+	lastBlockInEpoch := epoch.FirstBlockInEpoch + c.config.PolyBFTConfig.EpochSize - 1
+
+	return epoch.Validators.ContainsAddress(signerAddress), msg.View.Height <= lastBlockInEpoch
+}
+
 func (c *consensusRuntime) IsProposer(id []byte, height, round uint64) bool {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -240,6 +240,71 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 	systemStateMock.AssertExpectations(t)
 }
 
+func TestConsensusRuntime_OnBlockInserted_Checkpoint(t *testing.T) {
+	t.Parallel()
+
+	const (
+		epochSize       = uint64(10)
+		validatorsCount = 7
+		// the checkpoint is in the middle of an epoch
+		checkpointNumber = 2*epochSize + 5
+	)
+
+	validatorSet := validator.NewTestValidators(t, validatorsCount).GetPublicIdentities()
+	header, headerMap := createTestBlocks(t, checkpointNumber, epochSize, validatorSet)
+	builtBlock := consensus.BuildBlock(consensus.BuildBlockParams{
+		Header: header,
+	})
+
+	checkpointEpochNumber := getEpochNumber(t, checkpointNumber, epochSize)
+	systemStateMock := new(systemStateMock)
+	systemStateMock.On("GetEpoch").Return(checkpointEpochNumber).Once()
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetStateProviderForBlock", mock.Anything).Return(new(stateProviderMock)).Once()
+	blockchainMock.On("GetSystemState", mock.Anything, mock.Anything).Return(systemStateMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headerMap.getHeader)
+
+	polybftBackendMock := new(polybftBackendMock)
+	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(validatorSet)
+
+	txPool := new(txPoolMock)
+	txPool.On("ResetWithHeaders", mock.Anything).Once()
+
+	snapshot := NewProposerSnapshot(checkpointNumber-1, validatorSet)
+	config := &runtimeConfig{
+		PolyBFTConfig: &PolyBFTConfig{
+			EpochSize: epochSize,
+		},
+		blockchain:     blockchainMock,
+		polybftBackend: polybftBackendMock,
+		txPool:         txPool,
+		State:          newTestState(t),
+	}
+	runtime := &consensusRuntime{
+		proposerCalculator: NewProposerCalculatorFromSnapshot(snapshot, config, hclog.NewNullLogger()),
+		logger:             hclog.NewNullLogger(),
+		state:              config.State,
+		config:             config,
+		epoch: &epochMetadata{
+			Number:            1,
+			FirstBlockInEpoch: 1,
+		},
+		lastBuiltBlock:    &types.Header{Number: 0},
+		stateSyncManager:  &dummyStateSyncManager{},
+		checkpointManager: &dummyCheckpointManager{},
+		stakeManager:      &dummyStakeManager{},
+	}
+	runtime.OnBlockInserted(&types.FullBlock{Block: builtBlock})
+
+	require.True(t, runtime.state.EpochStore.isEpochInserted(checkpointEpochNumber))
+	require.Equal(t, checkpointEpochNumber, runtime.epoch.Number)
+	require.Equal(t, header.Number, runtime.lastBuiltBlock.Number)
+
+	blockchainMock.AssertExpectations(t)
+	systemStateMock.AssertExpectations(t)
+}
+
 func TestConsensusRuntime_OnBlockInserted_MiddleOfEpoch(t *testing.T) {
 	t.Parallel()
 
@@ -678,6 +743,68 @@ func TestConsensusRuntime_IsValidValidator_TamperSignature(t *testing.T) {
 	require.False(t, runtime.IsValidValidator(msg))
 }
 
+func TestConsensusRuntime_IsValidGossipSender(t *testing.T) {
+	t.Parallel()
+
+	validatorAccounts := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D", "E", "F"})
+	runtime := &consensusRuntime{
+		config: &runtimeConfig{
+			PolyBFTConfig: &PolyBFTConfig{EpochSize: 10},
+		},
+		epoch: &epochMetadata{
+			FirstBlockInEpoch: 1,
+			Validators:        validatorAccounts.GetPublicIdentities("A", "B", "C", "D"),
+		},
+		lastBuiltBlock: &types.Header{Number: 8},
+		logger:         hclog.NewNullLogger(),
+	}
+
+	signMessage := func(alias string, height uint64) *proto.Message {
+		t.Helper()
+
+		sender := validatorAccounts.GetValidator(alias)
+		msg, err := sender.Key().SignIBFTMessage(&proto.Message{
+			View: &proto.View{Height: height},
+			From: sender.Address().Bytes(),
+		})
+		require.NoError(t, err)
+
+		return msg
+	}
+
+	// the sender is checked against the validators of the current epoch, even though
+	// the node has no FSM and the last built block lags behind
+	valid, known := runtime.IsValidGossipSender(signMessage("A", 10))
+	require.True(t, known)
+	require.True(t, valid)
+
+	valid, known = runtime.IsValidGossipSender(signMessage("F", 10))
+	require.True(t, known)
+	require.False(t, valid)
+
+	tampered := signMessage("A", 10)
+	tampered.From = validatorAccounts.GetValidator("B").Address().Bytes()
+
+	valid, known = runtime.IsValidGossipSender(tampered)
+	require.True(t, known)
+	require.False(t, valid)
+
+	// the validators of the next epoch are not known
+	valid, known = runtime.IsValidGossipSender(signMessage("A", 11))
+	require.False(t, known)
+	require.True(t, valid)
+
+	valid, known = runtime.IsValidGossipSender(signMessage("F", 11))
+	require.False(t, known)
+	require.False(t, valid)
+
+	// nor the ones of any block before the epoch is set up
+	runtime.epoch = nil
+
+	_, known = runtime.IsValidGossipSender(signMessage("A", 10))
+	require.False(t, known)
+}
+
 func TestConsensusRuntime_TamperMessageContent(t *testing.T) {
 	t.Parallel()
 
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
@@ -33,6 +34,7 @@ const (
 
 var (
 	errMissingBridgeConfig = errors.New("invalid genesis configuration, missing bridge configuration")
+	errSnapSyncUnsupported = errors.New("snap sync is not supported by polybft")
 )
 
 // polybftBackend is an interface defining polybft methods needed by fsm and sync tracker
@@ -43,6 +45,12 @@ type polybftBackend interface {
 
 // Factory is the factory function to create a discovery consensus
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
+	// the validator set and bridge stores are built out of the receipts of all the blocks,
+	// which are not synced up to the pivot block
+	if params.SnapSync {
+		return nil, errSnapSyncUnsupported
+	}
+
 	logger := params.Logger.Named("polybft")
 
 	setupHeaderHashFunc()
@@ -99,6 +107,11 @@ type Polybft struct {
 	// reference to the syncer
 	syncer syncer.Syncer
 
+	// historyMissing is set if the node is bootstrapped from a checkpoint,
+	// without the blocks before it. It stays set for the lifetime of the data directory,
+	// so such a node never validates
+	historyMissing bool
+
 	// topic for consensus engine messages
 	consensusTopic *network.Topic
 
@@ -373,7 +386,10 @@ func (p *Polybft) Initialize() error {
 		p.config.Logger.Named("syncer"),
 		p.config.Network,
 		p.config.Blockchain,
+		p.config.StateStorage,
 		time.Duration(p.config.BlockTime)*3*time.Second,
+		p.config.SnapSync,
+		p.config.CheckpointSync,
 	)
 
 	// set blockchain backend
@@ -382,6 +398,14 @@ func (p *Polybft) Initialize() error {
 		executor:   p.config.Executor,
 	}
 
+	// the stores built out of the events of the blocks skipped by a checkpoint sync
+	// (full validator set, exit events and state syncs) are incomplete,
+	// so such a node can't build valid blocks and only follows the chain
+	p.historyMissing = p.config.CheckpointSync != nil || isHistoryMissing(p.config.Blockchain)
+	if p.historyMissing {
+		p.logger.Warn("node is bootstrapped from a checkpoint, it runs as a non-validator")
+	}
+
 	// create bridge and consensus topics
 	if err = p.createTopics(); err != nil {
 		return fmt.Errorf("cannot create topics: %w", err)
@@ -455,6 +479,19 @@ func (p *Polybft) Start() error {
 	return nil
 }
 
+// isHistoryMissing returns true if the blocks before the head are not all stored,
+// as the ancestors of a checkpoint block are written without their bodies
+func isHistoryMissing(bc *blockchain.Blockchain) bool {
+	header, ok := bc.GetHeaderByNumber(1)
+	if !ok {
+		return false
+	}
+
+	_, ok = bc.GetBodyByHash(header.Hash)
+
+	return !ok
+}
+
 // initRuntime creates consensus runtime
 func (p *Polybft) initRuntime() error {
 	runtimeConfig := &runtimeConfig{
@@ -522,6 +559,8 @@ func (p *Polybft) startConsensusProtocol() {
 	var (
 		sequenceCh   <-chan struct{}
 		stopSequence func()
+		// set once the node was warned it doesn't validate despite being in the validator set
+		historyWarned bool
 	)
 
 	for {
@@ -532,7 +571,15 @@ func (p *Polybft) startConsensusProtocol() {
 			p.logger.Error("failed to query current validator set", "block number", latestHeader.Number, "error", err)
 		}
 
-		isValidator := currentValidators.ContainsNodeID(p.key.String())
+		inValidatorSet := currentValidators.ContainsNodeID(p.key.String())
+		isValidator := !p.historyMissing && inValidatorSet
+
+		if inValidatorSet && p.historyMissing && !historyWarned {
+			p.logger.Warn("node is in the validator set, but can't validate as it is bootstrapped from a checkpoint; "+
+				"sync it from genesis to validate", "block number", latestHeader.Number)
+
+			historyWarned = true
+		}
 		p.runtime.setIsActiveValidator(isValidator)
 
 		p.txPool.SetSealing(isValidator) // update tx pool
@@ -667,6 +714,12 @@ func (p *Polybft) GetBridgeProvider() consensus.BridgeDataProvider {
 	return p.runtime
 }
 
+// GetFinalityProvider is an implementation of Consensus interface
+// Returns an instance of FinalityProvider
+func (p *Polybft) GetFinalityProvider() consensus.FinalityProvider {
+	return p.runtime
+}
+
 // GetBridgeProvider is an implementation of Consensus interface
 // Filters extra data to not contain Committed field
 func (p *Polybft) FilterExtra(extra []byte) ([]byte, error) {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
@@ -272,6 +273,22 @@ func Test_Factory(t *testing.T) {
 	assert.Equal(t, txPool, polybft.txPool)
 	assert.Equal(t, epochSize, polybft.consensusConfig.EpochSize)
 	assert.Equal(t, params, polybft.config)
+
+	// the validator set and bridge stores can't be built with snap sync
+	params.SnapSync = true
+
+	_, err = Factory(params)
+	require.ErrorIs(t, err, errSnapSyncUnsupported)
+}
+
+func Test_isHistoryMissing(t *testing.T) {
+	t.Parallel()
+
+	// a new chain has no history yet
+	assert.False(t, isHistoryMissing(blockchain.NewTestBlockchain(t, nil)))
+
+	// the headers written without their bodies are the ancestors of a checkpoint
+	assert.True(t, isHistoryMissing(blockchain.NewTestBlockchain(t, blockchain.NewTestHeaders(3))))
 }
 
 func Test_GenesisPostHookFactory(t *testing.T) {
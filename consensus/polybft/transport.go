@@ -5,6 +5,7 @@ import (
 
 	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
 	polybftProto "github.com/0xPolygon/polygon-edge/consensus/polybft/proto"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -43,13 +44,13 @@ func (p *Polybft) subscribeToIbftTopic() error {
 // createTopics create all topics for a PolyBft instance
 func (p *Polybft) createTopics() (err error) {
 	if p.consensusConfig.IsBridgeEnabled() {
-		p.bridgeTopic, err = p.config.Network.NewTopic(bridgeProto, &polybftProto.TransportMessage{})
+		p.bridgeTopic, err = p.config.Network.NewTopic(bridgeProto, &polybftProto.TransportMessage{}, nil)
 		if err != nil {
 			return fmt.Errorf("failed to create bridge topic: %w", err)
 		}
 	}
 
-	p.consensusTopic, err = p.config.Network.NewTopic(pbftProto, &ibftProto.Message{})
+	p.consensusTopic, err = p.config.Network.NewTopic(pbftProto, &ibftProto.Message{}, p.validateGossipMessage)
 	if err != nil {
 		return fmt.Errorf("failed to create consensus topic: %w", err)
 	}
@@ -57,6 +58,39 @@ func (p *Polybft) createTopics() (err error) {
 	return nil
 }
 
+// validateGossipMessage is the validator of the consensus topic, which drops the messages
+// of the block being built that are not sent by a validator of the current epoch,
+// before they are relayed to the other peers
+func (p *Polybft) validateGossipMessage(obj interface{}, _ peer.ID) network.ValidationResult {
+	msg, ok := obj.(*ibftProto.Message)
+	if !ok || msg.View == nil || msg.Payload == nil {
+		return network.ValidationReject
+	}
+
+	// the runtime is not initialized yet
+	if p.runtime == nil {
+		return network.ValidationIgnore
+	}
+
+	// only the senders of the block following the head can be checked. The messages of the
+	// finalized blocks and of the future blocks are neither delivered, relayed nor penalized
+	if head := p.blockchain.CurrentHeader().Number; msg.View.Height != head+1 {
+		return network.ValidationIgnore
+	}
+
+	valid, known := p.runtime.IsValidGossipSender(msg)
+
+	switch {
+	case valid:
+		return network.ValidationAccept
+	case known:
+		return network.ValidationReject
+	default:
+		// the sender may be a validator of the next epoch, which is not restarted yet
+		return network.ValidationIgnore
+	}
+}
+
 // Multicast is implementation of core.Transport interface
 func (p *Polybft) Multicast(msg *ibftProto.Message) {
 	if err := p.consensusTopic.Publish(msg); err != nil {
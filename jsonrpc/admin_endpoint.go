@@ -0,0 +1,109 @@
+package jsonrpc
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/versioning"
+)
+
+// PeerInfo is the information of a connected peer
+type PeerInfo struct {
+	ID        string          `json:"id"`
+	Addrs     []string        `json:"addrs"`
+	Protocols []string        `json:"protocols"`
+	Network   PeerNetworkInfo `json:"network"`
+}
+
+// PeerNetworkInfo is the connection information of a connected peer
+type PeerNetworkInfo struct {
+	Inbound bool `json:"inbound"`
+	Trusted bool `json:"trusted"`
+}
+
+// NodeInfo is the networking information of the node
+type NodeInfo struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Enode       string   `json:"enode"`
+	ListenAddrs []string `json:"listenAddrs"`
+}
+
+// adminStore provides methods needed for Admin endpoint
+type adminStore interface {
+	// PeersInfo returns the information of the connected peers
+	PeersInfo() []*PeerInfo
+
+	// NodeInfo returns the networking information of the node
+	NodeInfo() (*NodeInfo, error)
+
+	// JoinPeer marks the peer of the given multiaddr ready for dialing
+	JoinPeer(rawPeerMultiaddr string) error
+
+	// RemovePeer disconnects from the peer of the given multiaddr or ID,
+	// and returns false if it was not connected
+	RemovePeer(rawPeer string) (bool, error)
+
+	// AddTrustedPeer marks the peer of the given multiaddr as trusted, and ready for dialing
+	AddTrustedPeer(rawPeerMultiaddr string) error
+
+	// RemoveTrustedPeer removes the trusted mark of the peer of the given multiaddr or ID
+	RemoveTrustedPeer(rawPeer string) error
+}
+
+// Admin is the admin jsonrpc endpoint, managing the peers of the node.
+// It is only exposed if explicitly enabled
+type Admin struct {
+	store     adminStore
+	chainID   uint64
+	chainName string
+}
+
+// Peers returns the information of the connected peers
+func (a *Admin) Peers() (interface{}, error) {
+	return a.store.PeersInfo(), nil
+}
+
+// NodeInfo returns the networking information of the node
+func (a *Admin) NodeInfo() (interface{}, error) {
+	info, err := a.store.NodeInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	info.Name = fmt.Sprintf(clientVersionTemplate, a.chainName, a.chainID, versioning.Version)
+
+	return info, nil
+}
+
+// AddPeer requests a connection to the peer of the given multiaddr
+func (a *Admin) AddPeer(rawPeerMultiaddr string) (interface{}, error) {
+	if err := a.store.JoinPeer(rawPeerMultiaddr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer disconnects from the peer of the given multiaddr or ID
+func (a *Admin) RemovePeer(rawPeer string) (interface{}, error) {
+	return a.store.RemovePeer(rawPeer)
+}
+
+// AddTrustedPeer marks the peer of the given multiaddr as trusted, exempting it
+// from the connection limits, and requests a connection to it
+func (a *Admin) AddTrustedPeer(rawPeerMultiaddr string) (interface{}, error) {
+	if err := a.store.AddTrustedPeer(rawPeerMultiaddr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// RemoveTrustedPeer removes the trusted mark of the peer of the given multiaddr or ID
+func (a *Admin) RemoveTrustedPeer(rawPeer string) (interface{}, error) {
+	if err := a.store.RemoveTrustedPeer(rawPeer); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
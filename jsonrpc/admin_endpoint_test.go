@@ -0,0 +1,149 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type adminEndpointMockStore struct {
+	*mockStore
+
+	peers   []*PeerInfo
+	joined  []string
+	trusted map[string]bool
+}
+
+func newAdminEndpointMockStore() *adminEndpointMockStore {
+	return &adminEndpointMockStore{
+		mockStore: newMockStore(),
+		peers: []*PeerInfo{
+			{
+				ID:        "peer1",
+				Addrs:     []string{"/ip4/127.0.0.1/tcp/10001"},
+				Protocols: []string{"/id/0.1"},
+				Network:   PeerNetworkInfo{Inbound: true},
+			},
+		},
+		trusted: map[string]bool{},
+	}
+}
+
+func (m *adminEndpointMockStore) PeersInfo() []*PeerInfo {
+	return m.peers
+}
+
+func (m *adminEndpointMockStore) NodeInfo() (*NodeInfo, error) {
+	return &NodeInfo{
+		ID:          "node",
+		Enode:       "/ip4/127.0.0.1/tcp/10000/p2p/node",
+		ListenAddrs: []string{"/ip4/127.0.0.1/tcp/10000"},
+	}, nil
+}
+
+func (m *adminEndpointMockStore) JoinPeer(rawPeerMultiaddr string) error {
+	if rawPeerMultiaddr == "" {
+		return errors.New("invalid multiaddr")
+	}
+
+	m.joined = append(m.joined, rawPeerMultiaddr)
+
+	return nil
+}
+
+func (m *adminEndpointMockStore) RemovePeer(rawPeer string) (bool, error) {
+	for i, p := range m.peers {
+		if p.ID == rawPeer {
+			m.peers = append(m.peers[:i], m.peers[i+1:]...)
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *adminEndpointMockStore) AddTrustedPeer(rawPeerMultiaddr string) error {
+	m.trusted[rawPeerMultiaddr] = true
+
+	return m.JoinPeer(rawPeerMultiaddr)
+}
+
+func (m *adminEndpointMockStore) RemoveTrustedPeer(rawPeer string) error {
+	delete(m.trusted, rawPeer)
+
+	return nil
+}
+
+func TestAdminEndpoint(t *testing.T) {
+	t.Parallel()
+
+	store := newAdminEndpointMockStore()
+	admin := &Admin{store: store, chainID: 100, chainName: "test"}
+
+	peers, err := admin.Peers()
+	require.NoError(t, err)
+	assert.Equal(t, store.peers, peers)
+
+	info, err := admin.NodeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, "node", info.(*NodeInfo).ID)
+	assert.Contains(t, info.(*NodeInfo).Name, "[chain-id: 100]")
+
+	res, err := admin.AddPeer("/ip4/127.0.0.1/tcp/10002/p2p/peer2")
+	require.NoError(t, err)
+	assert.Equal(t, true, res)
+	assert.Equal(t, []string{"/ip4/127.0.0.1/tcp/10002/p2p/peer2"}, store.joined)
+
+	_, err = admin.AddPeer("")
+	assert.Error(t, err)
+
+	res, err = admin.RemovePeer("peer1")
+	require.NoError(t, err)
+	assert.Equal(t, true, res)
+
+	res, err = admin.RemovePeer("peer1")
+	require.NoError(t, err)
+	assert.Equal(t, false, res)
+
+	res, err = admin.AddTrustedPeer("/ip4/127.0.0.1/tcp/10003/p2p/peer3")
+	require.NoError(t, err)
+	assert.Equal(t, true, res)
+	assert.True(t, store.trusted["/ip4/127.0.0.1/tcp/10003/p2p/peer3"])
+
+	_, err = admin.RemoveTrustedPeer("/ip4/127.0.0.1/tcp/10003/p2p/peer3")
+	require.NoError(t, err)
+	assert.Empty(t, store.trusted)
+}
+
+func TestDispatcher_AdminNamespaceOptIn(t *testing.T) {
+	t.Parallel()
+
+	request := []byte(`{"id": 1, "method": "admin_peers", "params": []}`)
+
+	// the admin namespace is not exposed by default
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newAdminEndpointMockStore(), &dispatcherParams{})
+
+	resp, err := dispatcher.Handle(context.Background(), request)
+	require.NoError(t, err)
+
+	var peers []*PeerInfo
+
+	assert.Error(t, expectJSONResult(resp, &peers))
+
+	dispatcher = newTestDispatcher(t, hclog.NewNullLogger(), newAdminEndpointMockStore(), &dispatcherParams{
+		namespaces: []string{"eth", "admin"},
+	})
+
+	resp, err = dispatcher.Handle(context.Background(), request)
+	require.NoError(t, err)
+
+	require.NoError(t, expectJSONResult(resp, &peers))
+	require.Len(t, peers, 1)
+	assert.Equal(t, "peer1", peers[0].ID)
+	assert.True(t, peers[0].Network.Inbound)
+}
@@ -0,0 +1,260 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// apiKeyHeader is the header carrying the API key of the client
+	apiKeyHeader = "X-API-Key"
+
+	// jwtClockSkew is the tolerated clock difference between the client and the node,
+	// which is also the lifetime of a token, so a leaked token can't be replayed later on
+	jwtClockSkew = 60 * time.Second
+
+	// minJWTSecretLength is the minimal length of the HS256 secret, in bytes
+	minJWTSecretLength = 32
+)
+
+var (
+	ErrMissingCredentials = errors.New("missing credentials")
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+	ErrInvalidJWT         = errors.New("invalid JWT")
+	ErrJWTExpired         = errors.New("JWT expired")
+	ErrJWTIssuedInFuture  = errors.New("JWT issued in the future")
+	ErrJWTStale           = errors.New("JWT issued too long ago")
+)
+
+// AuthConfig holds the authentication settings of a json-rpc listener.
+// If set, every request must carry either a JWT (HS256) signed with the
+// secret, or one of the API keys.
+type AuthConfig struct {
+	JWTSecret []byte
+	APIKeys   []string
+}
+
+// ReadJWTSecret reads the hex encoded HS256 secret from the given file
+func ReadJWTSecret(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+
+	encoded := strings.TrimPrefix(strings.TrimSpace(string(raw)), "0x")
+
+	secret, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT secret: %w", err)
+	}
+
+	if len(secret) < minJWTSecretLength {
+		return nil, fmt.Errorf("JWT secret must be at least %d bytes long", minJWTSecretLength)
+	}
+
+	return secret, nil
+}
+
+type apiKeyCtxKey struct{}
+
+// withAPIKey returns a copy of the context holding the API key the client authenticated with
+func withAPIKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, key)
+}
+
+// apiKeyFromContext returns the API key the client authenticated with, if any
+func apiKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(apiKeyCtxKey{}).(string)
+
+	return key
+}
+
+// authenticator checks the credentials of the incoming requests
+type authenticator struct {
+	jwtSecret []byte
+	apiKeys   [][]byte
+}
+
+// newAuthenticator returns the authenticator of the given config,
+// or nil if authentication is disabled
+func newAuthenticator(config *AuthConfig) *authenticator {
+	if config == nil || (len(config.JWTSecret) == 0 && len(config.APIKeys) == 0) {
+		return nil
+	}
+
+	a := &authenticator{
+		jwtSecret: config.JWTSecret,
+		apiKeys:   make([][]byte, len(config.APIKeys)),
+	}
+
+	for i, key := range config.APIKeys {
+		a.apiKeys[i] = []byte(key)
+	}
+
+	return a
+}
+
+// authenticate checks the credentials of the request, which are either
+// an API key in the X-API-Key header, or an API key or JWT as bearer token.
+// It returns the API key the client authenticated with, if any.
+func (a *authenticator) authenticate(req *http.Request) (string, error) {
+	if key := req.Header.Get(apiKeyHeader); key != "" {
+		if !a.isAPIKey(key) {
+			return "", ErrInvalidAPIKey
+		}
+
+		return key, nil
+	}
+
+	token, ok := bearerToken(req)
+	if !ok {
+		return "", ErrMissingCredentials
+	}
+
+	if a.isAPIKey(token) {
+		return token, nil
+	}
+
+	if len(a.jwtSecret) == 0 {
+		return "", ErrInvalidAPIKey
+	}
+
+	return "", a.verifyJWT(token, time.Now())
+}
+
+// isAPIKey returns true if the given key is one of the configured API keys
+func (a *authenticator) isAPIKey(key string) bool {
+	valid := false
+
+	for _, apiKey := range a.apiKeys {
+		if subtle.ConstantTimeCompare(apiKey, []byte(key)) == 1 {
+			valid = true
+		}
+	}
+
+	return valid
+}
+
+// jwtHeader is the JOSE header of a JWT
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+// jwtClaims are the JWT claims checked by the node
+type jwtClaims struct {
+	IssuedAt  *int64 `json:"iat"`
+	ExpiresAt *int64 `json:"exp"`
+}
+
+// verifyJWT checks the HS256 signature of the token, that it was issued (iat)
+// within the clock skew of the given time and that it did not expire (exp)
+func (a *authenticator) verifyJWT(token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidJWT
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return ErrInvalidJWT
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrInvalidJWT
+	}
+
+	mac := hmac.New(sha256.New, a.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrInvalidJWT
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil || claims.IssuedAt == nil {
+		return ErrInvalidJWT
+	}
+
+	issuedAt := time.Unix(*claims.IssuedAt, 0)
+
+	if issuedAt.After(now.Add(jwtClockSkew)) {
+		return ErrJWTIssuedInFuture
+	}
+
+	if issuedAt.Before(now.Add(-jwtClockSkew)) {
+		return ErrJWTStale
+	}
+
+	if claims.ExpiresAt != nil && !now.Before(time.Unix(*claims.ExpiresAt, 0).Add(jwtClockSkew)) {
+		return ErrJWTExpired
+	}
+
+	return nil
+}
+
+// decodeJWTSegment decodes a base64url encoded JSON segment of a JWT
+func decodeJWTSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.NewDecoder(bytes.NewReader(raw)).Decode(v)
+}
+
+// bearerToken returns the bearer token of the Authorization header, if any
+func bearerToken(req *http.Request) (string, bool) {
+	const prefix = "Bearer "
+
+	auth := req.Header.Get("Authorization")
+	if len(auth) <= len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(auth[len(prefix):]), true
+}
+
+// authMiddleware rejects the requests without valid credentials,
+// apart from CORS preflight requests
+func (j *JSONRPC) authMiddleware(next http.Handler) http.Handler {
+	if j.auth == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		key, err := j.auth.authenticate(r)
+		if err != nil {
+			j.logger.Debug("unauthorized request", "remote", r.RemoteAddr, "err", err)
+
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		if key != "" {
+			r = r.WithContext(withAPIKey(r.Context(), key))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
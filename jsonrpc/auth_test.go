@@ -0,0 +1,194 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = []byte(strings.Repeat("s", minJWTSecretLength))
+
+func signTestJWT(secret []byte, header, claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	unsigned := encode([]byte(header)) + "." + encode([]byte(claims))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + encode(mac.Sum(nil))
+}
+
+func TestReadJWTSecret(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		return path
+	}
+
+	secret, err := ReadJWTSecret(write("valid", "0x"+hex.EncodeToString(testJWTSecret)+"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, testJWTSecret, secret)
+
+	_, err = ReadJWTSecret(write("short", hex.EncodeToString([]byte("short"))))
+	assert.Error(t, err)
+
+	_, err = ReadJWTSecret(write("invalid", "not hex"))
+	assert.Error(t, err)
+
+	_, err = ReadJWTSecret(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now = time.Now().Unix()
+		hs  = `{"alg":"HS256","typ":"JWT"}`
+	)
+
+	auth := newAuthenticator(&AuthConfig{
+		JWTSecret: testJWTSecret,
+		APIKeys:   []string{"key1", "key2"},
+	})
+
+	testTable := []struct {
+		name    string
+		headers map[string]string
+		err     error
+	}{
+		{"no credentials", nil, ErrMissingCredentials},
+		{"api key header", map[string]string{apiKeyHeader: "key2"}, nil},
+		{"invalid api key header", map[string]string{apiKeyHeader: "key3"}, ErrInvalidAPIKey},
+		{"api key bearer", map[string]string{"Authorization": "Bearer key1"}, nil},
+		{
+			"valid jwt",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs,
+				fmt.Sprintf(`{"iat":%d}`, now))},
+			nil,
+		},
+		{
+			"jwt not expired yet",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs,
+				fmt.Sprintf(`{"iat":%d,"exp":%d}`, now, now+3600))},
+			nil,
+		},
+		{
+			"jwt issued within the clock skew",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs,
+				fmt.Sprintf(`{"iat":%d}`, now-30))},
+			nil,
+		},
+		{
+			"expired jwt",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs,
+				fmt.Sprintf(`{"iat":%d,"exp":%d}`, now-30, now-60))},
+			ErrJWTExpired,
+		},
+		{
+			"stale jwt",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs,
+				fmt.Sprintf(`{"iat":%d}`, now-3600))},
+			ErrJWTStale,
+		},
+		{
+			"stale jwt not expired yet",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs,
+				fmt.Sprintf(`{"iat":%d,"exp":%d}`, now-3600, now+3600))},
+			ErrJWTStale,
+		},
+		{
+			"jwt issued in the future",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs,
+				fmt.Sprintf(`{"iat":%d}`, now+3600))},
+			ErrJWTIssuedInFuture,
+		},
+		{
+			"jwt without iat",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, hs, `{}`)},
+			ErrInvalidJWT,
+		},
+		{
+			"jwt signed with another secret",
+			map[string]string{"Authorization": "Bearer " + signTestJWT([]byte("other"), hs,
+				fmt.Sprintf(`{"iat":%d}`, now))},
+			ErrInvalidJWT,
+		},
+		{
+			"jwt with another algorithm",
+			map[string]string{"Authorization": "Bearer " + signTestJWT(testJWTSecret, `{"alg":"none"}`,
+				fmt.Sprintf(`{"iat":%d}`, now))},
+			ErrInvalidJWT,
+		},
+		{"malformed jwt", map[string]string{"Authorization": "Bearer a.b"}, ErrInvalidJWT},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			_, err := auth.authenticate(req)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestJSONRPC_AuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newAuthenticator(nil))
+	assert.Nil(t, newAuthenticator(&AuthConfig{}))
+
+	j := &JSONRPC{
+		logger: hclog.NewNullLogger(),
+		auth:   newAuthenticator(&AuthConfig{APIKeys: []string{"key"}}),
+	}
+
+	handler := j.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method string, apiKey string) int {
+		req := httptest.NewRequest(method, "/", nil)
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, ""))
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "wrong"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "key"))
+	// CORS preflight requests don't carry credentials
+	assert.Equal(t, http.StatusOK, serve(http.MethodOptions, ""))
+}
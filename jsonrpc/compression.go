@@ -0,0 +1,83 @@
+package jsonrpc
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// acceptsGzip returns true if the client accepts gzip encoded responses,
+// either explicitly or through the wildcard. A zero quality value is a refusal
+func acceptsGzip(req *http.Request) bool {
+	wildcard := false
+
+	for _, encoding := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(encoding, ";")
+		name = strings.TrimSpace(name)
+
+		switch {
+		case strings.EqualFold(name, "gzip"):
+			return qualityValue(params) > 0
+		case name == "*":
+			wildcard = qualityValue(params) > 0
+		}
+	}
+
+	return wildcard
+}
+
+// qualityValue returns the "q" parameter of an Accept-Encoding entry (1 if not set or invalid)
+func qualityValue(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "q") {
+			continue
+		}
+
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 1
+		}
+
+		return q
+	}
+
+	return 1
+}
+
+// writeResponse writes out the response to the HTTP client,
+// gzip encoded if large enough and supported by the client
+func (j *JSONRPC) writeResponse(w http.ResponseWriter, req *http.Request, resp []byte) {
+	threshold := j.config.CompressionThreshold
+	if threshold == 0 || len(resp) < threshold || !acceptsGzip(req) {
+		_, _ = w.Write(resp)
+
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	gz, _ := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(gz)
+
+	gz.Reset(w)
+
+	if _, err := gz.Write(resp); err != nil {
+		j.logger.Error("Unable to write compressed response", "err", err)
+
+		return
+	}
+
+	if err := gz.Close(); err != nil {
+		j.logger.Error("Unable to write compressed response", "err", err)
+	}
+}
@@ -0,0 +1,143 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJSONRPC(t *testing.T, config *Config) *JSONRPC {
+	t.Helper()
+
+	return &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		config:     config,
+		dispatcher: newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{}),
+		wsUpgrader: newWSUpgrader(config.CompressionThreshold != 0),
+	}
+}
+
+func Test_acceptsGzip(t *testing.T) {
+	t.Parallel()
+
+	for header, expected := range map[string]bool{
+		"":                  false,
+		"gzip":              true,
+		"deflate, gzip;q=1": true,
+		"br, deflate":       false,
+		" GZIP ":            true,
+		"gzip;q=0":          false,
+		"gzip; q=0.000":     false,
+		"gzip;q=0.5":        true,
+		"br, *":             true,
+		"*;q=0":             false,
+		"gzip;q=0, *":       false,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Accept-Encoding", header)
+
+		assert.Equal(t, expected, acceptsGzip(req), header)
+	}
+}
+
+func TestJSONRPC_Compression(t *testing.T) {
+	t.Parallel()
+
+	j := newTestJSONRPC(t, &Config{CompressionThreshold: 512})
+
+	post := func(body string, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+
+		rec := httptest.NewRecorder()
+		j.handle(rec, req)
+
+		return rec
+	}
+
+	small := `{"id": 1, "method": "web3_clientVersion", "params": []}`
+	large := "[" + strings.TrimSuffix(strings.Repeat(small+",", 20), ",") + "]"
+
+	// small responses are not compressed
+	rec := post(small, "gzip")
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+
+	// neither are the responses to clients not supporting it
+	rec = post(large, "")
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+
+	rec = post(large, "gzip")
+	require.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	var responses []SuccessResponse
+	require.NoError(t, json.Unmarshal(decompressed, &responses))
+	assert.Len(t, responses, 20)
+}
+
+func TestJSONRPC_MaxRequestSize(t *testing.T) {
+	t.Parallel()
+
+	j := newTestJSONRPC(t, &Config{MaxRequestSize: 64})
+
+	body := `{"id": 1, "method": "web3_clientVersion", "params": []}`
+
+	rec := httptest.NewRecorder()
+	j.handle(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	j.handle(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body+strings.Repeat(" ", 64))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestJSONRPC_WebsocketLimits(t *testing.T) {
+	t.Parallel()
+
+	j := newTestJSONRPC(t, &Config{CompressionThreshold: 512, MaxWSMessageSize: 128})
+
+	srv := httptest.NewServer(http.HandlerFunc(j.handleWs))
+	defer srv.Close()
+
+	dialer := websocket.Dialer{EnableCompression: true}
+
+	conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+
+	defer conn.Close()
+	defer resp.Body.Close()
+
+	// permessage-deflate got negotiated
+	assert.Contains(t, resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate")
+
+	request := `[` + strings.TrimSuffix(strings.Repeat(`{"id":1,"method":"web3_clientVersion"},`, 3), ",") + `]`
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(request)))
+
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var responses []SuccessResponse
+	require.NoError(t, json.Unmarshal(message, &responses))
+	assert.Len(t, responses, 3)
+
+	// messages over the limit close the connection
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, bytes.Repeat([]byte(" "), 256)))
+
+	_, _, err = conn.ReadMessage()
+	assert.Error(t, err)
+}
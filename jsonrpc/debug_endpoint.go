@@ -24,6 +24,9 @@ var (
 )
 
 type debugBlockchainStore interface {
+	finalityGetter
+	pendingBlockGetter
+
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
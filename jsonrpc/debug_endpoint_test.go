@@ -23,12 +23,27 @@ type debugEndpointMockStore struct {
 	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	getSafeFn           func() (uint64, error)
+	getFinalizedFn      func() (uint64, error)
+	getPendingBlockFn   func() (*types.Block, []*types.Receipt, bool)
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
 	return s.headerFn()
 }
 
+func (s *debugEndpointMockStore) GetSafeBlockNumber() (uint64, error) {
+	return s.getSafeFn()
+}
+
+func (s *debugEndpointMockStore) GetFinalizedBlockNumber() (uint64, error) {
+	return s.getFinalizedFn()
+}
+
+func (s *debugEndpointMockStore) GetPendingBlock() (*types.Block, []*types.Receipt, bool) {
+	return s.getPendingBlockFn()
+}
+
 func (s *debugEndpointMockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	return s.getHeaderByNumberFn(num)
 }
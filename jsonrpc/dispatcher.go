@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,13 +23,20 @@ type serviceData struct {
 }
 
 type funcData struct {
-	inNum int
-	reqt  []reflect.Type
-	fv    reflect.Value
-	isDyn bool
+	inNum  int
+	reqt   []reflect.Type
+	fv     reflect.Value
+	isDyn  bool
+	hasCtx bool
 }
 
+// numParams returns the number of json-rpc params the function takes,
+// not counting the receiver and the optional request context
 func (f *funcData) numParams() int {
+	if f.hasCtx {
+		return f.inNum - 2
+	}
+
 	return f.inNum - 1
 }
 
@@ -39,6 +47,7 @@ type endpoints struct {
 	TxPool *TxPool
 	Bridge *Bridge
 	Debug  *Debug
+	Admin  *Admin
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -48,6 +57,7 @@ type Dispatcher struct {
 	serviceMap    map[string]*serviceData
 	filterManager *FilterManager
 	endpoints     endpoints
+	limiter       *rateLimiter
 
 	params *dispatcherParams
 }
@@ -59,20 +69,52 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+
+	// namespaces are the services to register, all of them
+	// apart from the opt-in ones if empty
+	namespaces []string
+
+	// rateLimit is the per client rate limiting config, disabled if nil
+	rateLimit *RateLimitConfig
+
+	// slowRequestThreshold is the duration from which the requests are logged, disabled if 0
+	slowRequestThreshold time.Duration
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
 	return dp.jsonRPCBatchLengthLimit != 0 && value > dp.jsonRPCBatchLengthLimit
 }
 
+// optInNamespaces are the namespaces only registered if explicitly enabled
+var optInNamespaces = map[string]struct{}{
+	"admin": {},
+}
+
+func (dp dispatcherParams) isNamespaceEnabled(namespace string) bool {
+	if len(dp.namespaces) == 0 {
+		_, optIn := optInNamespaces[namespace]
+
+		return !optIn
+	}
+
+	for _, ns := range dp.namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
 func newDispatcher(
 	logger hclog.Logger,
 	store JSONRPCStore,
 	params *dispatcherParams,
 ) (*Dispatcher, error) {
 	d := &Dispatcher{
-		logger: logger.Named("dispatcher"),
-		params: params,
+		logger:  logger.Named("dispatcher"),
+		params:  params,
+		limiter: newRateLimiter(params.rateLimit),
 	}
 
 	if store != nil {
@@ -112,30 +154,46 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 	d.endpoints.Debug = &Debug{
 		store,
 	}
-
-	var err error
-
-	if err = d.registerService("eth", d.endpoints.Eth); err != nil {
-		return err
+	d.endpoints.Admin = &Admin{
+		store,
+		d.params.chainID,
+		d.params.chainName,
 	}
 
-	if err = d.registerService("net", d.endpoints.Net); err != nil {
-		return err
+	services := []struct {
+		namespace string
+		service   interface{}
+	}{
+		{"eth", d.endpoints.Eth},
+		{"net", d.endpoints.Net},
+		{"web3", d.endpoints.Web3},
+		{"txpool", d.endpoints.TxPool},
+		{"bridge", d.endpoints.Bridge},
+		{"debug", d.endpoints.Debug},
+		{"admin", d.endpoints.Admin},
 	}
 
-	if err = d.registerService("web3", d.endpoints.Web3); err != nil {
-		return err
-	}
+	known := make(map[string]struct{}, len(services))
+
+	for _, s := range services {
+		known[s.namespace] = struct{}{}
+
+		if !d.params.isNamespaceEnabled(s.namespace) {
+			continue
+		}
 
-	if err = d.registerService("txpool", d.endpoints.TxPool); err != nil {
-		return err
+		if err := d.registerService(s.namespace, s.service); err != nil {
+			return err
+		}
 	}
 
-	if err = d.registerService("bridge", d.endpoints.Bridge); err != nil {
-		return err
+	for _, ns := range d.params.namespaces {
+		if _, ok := known[ns]; !ok {
+			return fmt.Errorf("jsonrpc: unknown namespace '%s'", ns)
+		}
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	return nil
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -209,6 +267,17 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+	} else if subscribeMethod == "newPendingTransactions" {
+		// the optional second param requests the full transactions instead of the hashes
+		fullTx := false
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+		filterID = d.filterManager.NewPendingTxFilter(fullTx, conn)
+	} else if subscribeMethod == "syncing" {
+		filterID = d.filterManager.NewSyncingFilter(conn)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -238,7 +307,7 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+func (d *Dispatcher) HandleWs(ctx context.Context, reqBody []byte, conn wsConn) ([]byte, error) {
 	const (
 		openSquareBracket  byte = '['
 		closeSquareBracket byte = ']'
@@ -258,6 +327,8 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		}
 
 		// if not disabled, avoid handling long batch requests
+		batchSize.WithLabelValues(transportFromContext(ctx)).Observe(float64(len(batchReq)))
+
 		if d.params.isExceedingBatchLengthLimit(uint64(len(batchReq))) {
 			return NewRPCResponse(
 				nil,
@@ -270,7 +341,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		responses := make([][]byte, len(batchReq))
 
 		for i, req := range batchReq {
-			responses[i], err = d.handleSingleWs(req, conn).Bytes()
+			responses[i], err = d.handleSingleWs(ctx, req, conn).Bytes()
 			if err != nil {
 				return nil, err
 			}
@@ -292,10 +363,10 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
-	return d.handleSingleWs(req, conn).Bytes()
+	return d.handleSingleWs(ctx, req, conn).Bytes()
 }
 
-func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
+func (d *Dispatcher) handleSingleWs(ctx context.Context, req Request, conn wsConn) Response {
 	id, err := formatID(req.ID)
 	if err != nil {
 		return NewRPCResponse(nil, "2.0", nil, err)
@@ -303,6 +374,12 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 
 	var response []byte
 
+	// subscriptions are a part of the eth namespace
+	if (req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe") &&
+		!d.params.isNamespaceEnabled("eth") {
+		return NewRPCResponse(id, "2.0", nil, NewMethodNotFoundError(req.Method))
+	}
+
 	switch req.Method {
 	case "eth_subscribe":
 		var filterID string
@@ -319,13 +396,13 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 		}
 	default:
 		// its a normal query that we handle with the dispatcher
-		response, err = d.handleReq(req)
+		response, err = d.handleReq(ctx, req)
 	}
 
 	return NewRPCResponse(id, "2.0", response, err)
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+func (d *Dispatcher) Handle(ctx context.Context, reqBody []byte) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -341,7 +418,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		resp, err := d.handleReq(ctx, req)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -358,6 +435,8 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	}
 
 	// if not disabled, avoid handling long batch requests
+	batchSize.WithLabelValues(transportFromContext(ctx)).Observe(float64(len(requests)))
+
 	if d.params.isExceedingBatchLengthLimit(uint64(len(requests))) {
 		return NewRPCResponse(
 			nil,
@@ -370,7 +449,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		var response, err = d.handleReq(ctx, req)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", response, err)
 			responses = append(responses, errorResponse)
@@ -390,7 +469,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
-func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
+func (d *Dispatcher) handleReq(ctx context.Context, req Request) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
 	service, fd, ferr := d.getFnHandler(req)
@@ -398,15 +477,58 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		return nil, ferr
 	}
 
+	transport := transportFromContext(ctx)
+	start := time.Now()
+
+	requestsTotal.WithLabelValues(req.Method, transport).Inc()
+
+	data, err := d.call(ctx, req, service, fd)
+
+	duration := time.Since(start)
+	requestDuration.WithLabelValues(req.Method, transport).Observe(duration.Seconds())
+
+	if err != nil {
+		errorsTotal.WithLabelValues(req.Method, transport).Inc()
+	}
+
+	if d.params.slowRequestThreshold != 0 && duration >= d.params.slowRequestThreshold {
+		d.logger.Warn(
+			"slow request",
+			"method", req.Method,
+			"params", paramsDigest(req.Params),
+			"transport", transport,
+			"duration", duration,
+		)
+	}
+
+	return data, err
+}
+
+// call charges the request to the rate limiter and calls the endpoint function
+func (d *Dispatcher) call(ctx context.Context, req Request, service *serviceData, fd *funcData) ([]byte, Error) {
+	release, lerr := d.limiter.acquire(clientIDFromContext(ctx), req.Method)
+	if lerr != nil {
+		return nil, lerr
+	}
+
+	defer release()
+
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
+	// the request context (if required) goes right after the receiver
+	offset := 1
+	if fd.hasCtx {
+		inArgs[1] = reflect.ValueOf(ctx)
+		offset = 2
+	}
+
 	inputs := make([]interface{}, fd.numParams())
 
-	for i := 0; i < fd.inNum-1; i++ {
-		val := reflect.New(fd.reqt[i+1])
+	for i := 0; i < fd.numParams(); i++ {
+		val := reflect.New(fd.reqt[i+offset])
 		inputs[i] = val.Interface()
-		inArgs[i+1] = val.Elem()
+		inArgs[i+offset] = val.Elem()
 	}
 
 	if fd.numParams() > 0 {
@@ -492,9 +614,11 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) er
 		if fd.inNum, fd.reqt, err = validateFunc(funcName, fd.fv, true); err != nil {
 			return fmt.Errorf("jsonrpc: %w", err)
 		}
+		// check if the function takes the request context
+		fd.hasCtx = fd.inNum > 1 && fd.reqt[1] == contextType
 		// check if last item is a pointer
 		if fd.numParams() != 0 {
-			last := fd.reqt[fd.numParams()]
+			last := fd.reqt[fd.inNum-1]
 			if last.Kind() == reflect.Ptr {
 				fd.isDyn = true
 			}
@@ -552,7 +676,10 @@ func validateFunc(funcName string, fv reflect.Value, _ bool) (inNum int, reqt []
 	return
 }
 
-var errt = reflect.TypeOf((*error)(nil)).Elem()
+var (
+	errt        = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
 
 func isErrorType(t reflect.Type) bool {
 	return t.Implements(errt)
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -80,7 +81,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+		if _, err := dispatcher.HandleWs(context.Background(), req, mockConnection); err != nil {
 			t.Fatal(err)
 		}
 
@@ -162,9 +163,41 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 			}`),
 			false,
 		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["newPendingTransactions"],
+				"id": 3
+			}`),
+			false,
+		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["newPendingTransactions", true],
+				"id": 4
+			}`),
+			false,
+		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["newPendingTransactions", "full"],
+				"id": 5
+			}`),
+			true,
+		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["syncing"],
+				"id": 6
+			}`),
+			false,
+		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(context.Background(), c.msg, mockConnection)
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -214,6 +247,43 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 	return nil, nil
 }
 
+func (m *mockService) WithContext(ctx context.Context, str string) (interface{}, error) {
+	m.msgCh <- clientAddrFromContext(ctx) + "/" + str
+
+	return nil, nil
+}
+
+func TestDispatcherRequestContext(t *testing.T) {
+	t.Parallel()
+
+	srv := &mockService{msgCh: make(chan interface{}, 10)}
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	require.NoError(t, dispatcher.registerService("mock", srv))
+
+	fd := dispatcher.serviceMap["mock"].funcMap["withContext"]
+	require.True(t, fd.hasCtx)
+	assert.Equal(t, 1, fd.numParams())
+
+	ctx := context.WithValue(context.Background(), clientAddrKey{}, "127.0.0.1")
+
+	_, err := dispatcher.handleReq(ctx, Request{
+		Method: "mock_withContext",
+		Params: []byte(`["a"]`),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "127.0.0.1/a", <-srv.msgCh)
+}
+
 func TestDispatcherFuncDecode(t *testing.T) {
 	t.Parallel()
 
@@ -233,7 +303,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	require.NoError(t, dispatcher.registerService("mock", srv))
 
 	handleReq := func(typ string, msg string) interface{} {
-		_, err := dispatcher.handleReq(Request{
+		_, err := dispatcher.handleReq(context.Background(), Request{
 			Method: "mock_" + typ,
 			Params: []byte(msg),
 		})
@@ -259,6 +329,16 @@ func TestDispatcherFuncDecode(t *testing.T) {
 			`["latest"]`,
 			LatestBlockNumber,
 		},
+		{
+			"block",
+			`["safe"]`,
+			SafeBlockNumber,
+		},
+		{
+			"block",
+			`["finalized"]`,
+			FinalizedBlockNumber,
+		},
 		{
 			"block",
 			`["0x1"]`,
@@ -282,7 +362,12 @@ func TestDispatcherFuncDecode(t *testing.T) {
 		{
 			"filter",
 			`[{"fromBlock": "pending", "toBlock": "earliest"}]`,
-			LogQuery{fromBlock: LatestBlockNumber, toBlock: EarliestBlockNumber}, // pending = latest
+			LogQuery{fromBlock: PendingBlockNumber, toBlock: EarliestBlockNumber},
+		},
+		{
+			"filter",
+			`[{"fromBlock": "finalized", "toBlock": "safe"}]`,
+			LogQuery{fromBlock: FinalizedBlockNumber, toBlock: SafeBlockNumber},
 		},
 	}
 
@@ -474,11 +559,11 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			t.Parallel()
 
-			res, _ := c.dispatcher.HandleWs(c.reqBody, mock)
+			res, _ := c.dispatcher.HandleWs(context.Background(), c.reqBody, mock)
 
 			check(c, res)
 
-			res, _ = c.dispatcher.Handle(c.reqBody)
+			res, _ = c.dispatcher.Handle(context.Background(), c.reqBody)
 
 			check(c, res)
 		})
@@ -516,25 +601,87 @@ func TestDispatcher_WebsocketConnection_Unsubscribe(t *testing.T) {
 	}
 
 	// non existing subscription
-	r, err := dispatcher.HandleWs(reqUnsub("\"787832\""), mockConn)
+	r, err := dispatcher.HandleWs(context.Background(), reqUnsub("\"787832\""), mockConn)
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
 	assert.Equal(t, "false", string(resp.Result))
 
-	r, err = dispatcher.HandleWs([]byte(`{"method": "eth_subscribe", "params": ["newHeads"]}`), mockConn)
+	r, err = dispatcher.HandleWs(context.Background(), []byte(`{"method": "eth_subscribe", "params": ["newHeads"]}`), mockConn)
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
 
 	// existing subscription
-	r, err = dispatcher.HandleWs(reqUnsub(string(resp.Result)), mockConn)
+	r, err = dispatcher.HandleWs(context.Background(), reqUnsub(string(resp.Result)), mockConn)
 	require.NoError(t, err)
 
 	require.NoError(t, json.Unmarshal(r, &resp))
 	assert.Equal(t, "true", string(resp.Result))
 }
 
+func TestDispatcher_Namespaces(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:    10,
+			namespaces: []string{"eth", "net"},
+		},
+	)
+
+	assert.Contains(t, dispatcher.serviceMap, "eth")
+	assert.Contains(t, dispatcher.serviceMap, "net")
+	assert.NotContains(t, dispatcher.serviceMap, "debug")
+
+	resp := SuccessResponse{}
+
+	r, err := dispatcher.Handle(context.Background(), []byte(`{"method": "net_version", "params": []}`))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(r, &resp))
+	assert.Nil(t, resp.Error)
+
+	r, err = dispatcher.Handle(context.Background(), []byte(`{"method": "debug_traceBlockByNumber", "params": ["0x1"]}`))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(r, &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, NewMethodNotFoundError("debug_traceBlockByNumber").ErrorCode(), resp.Error.Code)
+
+	t.Run("subscriptions are disabled along with the eth namespace", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				namespaces: []string{"web3"},
+			},
+		)
+
+		resp := SuccessResponse{}
+
+		r, err := dispatcher.HandleWs(
+			context.Background(),
+			[]byte(`{"method": "eth_subscribe", "params": ["newHeads"]}`),
+			&mockWsConn{},
+		)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(r, &resp))
+		require.NotNil(t, resp.Error)
+	})
+
+	t.Run("unknown namespace", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+			namespaces: []string{"eth", "admin2"},
+		})
+		require.ErrorContains(t, err, "unknown namespace")
+	})
+}
+
 func newTestDispatcher(t *testing.T, logger hclog.Logger, store JSONRPCStore, params *dispatcherParams) *Dispatcher {
 	t.Helper()
 
@@ -76,6 +76,19 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+// rateLimitedError is the json-rpc counterpart of the HTTP 429 status
+type rateLimitedError struct {
+	err string
+}
+
+func (e *rateLimitedError) Error() string {
+	return e.err
+}
+
+func (e *rateLimitedError) ErrorCode() int {
+	return -32005
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -90,6 +103,10 @@ func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
 
+func NewRateLimitedError(msg string) *rateLimitedError {
+	return &rateLimitedError{msg}
+}
+
 func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
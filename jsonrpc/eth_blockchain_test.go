@@ -51,6 +51,48 @@ func TestEth_Block_GetBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestEth_Block_GetBlockByNumber_SafeAndFinalized(t *testing.T) {
+	store := &mockBlockStore{finalized: 5}
+	for i := 0; i < 10; i++ {
+		store.add(newTestBlock(uint64(i), hash1))
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetBlockByNumber(SafeBlockNumber, false)
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(9), res.(*block).Number) //nolint:forcetypeassert
+
+	res, err = eth.GetBlockByNumber(FinalizedBlockNumber, false)
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(5), res.(*block).Number) //nolint:forcetypeassert
+}
+
+func TestEth_Block_GetBlockByNumber_Pending(t *testing.T) {
+	store := &mockBlockStore{}
+	for i := 0; i < 10; i++ {
+		store.add(newTestBlock(uint64(i), hash1))
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	// falls back to latest until the pending block is built
+	res, err := eth.GetBlockByNumber(PendingBlockNumber, false)
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(9), res.(*block).Number) //nolint:forcetypeassert
+
+	store.pending = newTestBlock(10, hash2)
+	store.pending.Transactions = []*types.Transaction{{Nonce: 0}}
+
+	res, err = eth.GetBlockByNumber(PendingBlockNumber, false)
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(10), res.(*block).Number) //nolint:forcetypeassert
+
+	count, err := eth.GetBlockTransactionCountByNumber(PendingBlockNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1", count)
+}
+
 func TestEth_Block_GetBlockByHash(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(newTestBlock(1, hash1))
@@ -405,6 +447,10 @@ type mockBlockStore struct {
 	averageGasPrice int64
 	ethCallError    error
 	returnValue     []byte
+	finalized       uint64
+	pending         *types.Block
+	indexedBlocks   []uint64
+	unindexedBlocks uint64
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -535,6 +581,22 @@ func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
 
+func (m *mockBlockStore) GetPendingBlock() (*types.Block, []*types.Receipt, bool) {
+	if m.pending == nil {
+		return nil, nil, false
+	}
+
+	return m.pending, nil, true
+}
+
+func (m *mockBlockStore) GetSafeBlockNumber() (uint64, error) {
+	return m.Header().Number, nil
+}
+
+func (m *mockBlockStore) GetFinalizedBlockNumber() (uint64, error) {
+	return m.finalized, nil
+}
+
 func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	for _, block := range m.blocks {
 		for _, txn := range block.Transactions {
@@ -557,6 +619,26 @@ func (m *mockBlockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bo
 	return nil, false
 }
 
+func (m *mockBlockStore) FilterBlocks(from, to uint64, criteria [][][]byte, maxUnindexed uint64) ([]uint64, error) {
+	if m.indexedBlocks == nil {
+		return nil, errLogIndexUnavailable
+	}
+
+	if maxUnindexed != 0 && m.unindexedBlocks > maxUnindexed {
+		return nil, blockchain.ErrTooManyUnindexedBlocks
+	}
+
+	blocks := make([]uint64, 0)
+
+	for _, number := range m.indexedBlocks {
+		if number >= from && number <= to {
+			blocks = append(blocks, number)
+		}
+	}
+
+	return blocks, nil
+}
+
 func (m *mockBlockStore) GetSyncProgression() *progress.Progression {
 	if m.isSyncing {
 		return &progress.Progression{
@@ -581,6 +663,10 @@ func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction,
 	}, nil
 }
 
+func (m *mockBlockStore) SubscribePromotedTxs() (<-chan types.Hash, func()) {
+	return nil, func() {}
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -17,8 +18,12 @@ import (
 )
 
 type ethTxPoolStore interface {
-	// AddTx adds a new transaction to the tx pool
-	AddTx(tx *types.Transaction) error
+	// AddTxFromClient adds a new transaction, sent by the given client, to the tx pool
+	AddTxFromClient(tx *types.Transaction, clientAddr string) error
+
+	// AddPrivateTxFromClient adds a new transaction to the tx pool without gossiping it,
+	// along with the address of the client which submitted it (if known)
+	AddPrivateTxFromClient(tx *types.Transaction, clientAddr string) error
 
 	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
 	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
@@ -40,6 +45,9 @@ type ethStateStore interface {
 }
 
 type ethBlockchainStore interface {
+	finalityGetter
+	pendingBlockGetter
+
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
@@ -118,16 +126,11 @@ func (e *Eth) Syncing() (interface{}, error) {
 
 // GetBlockByNumber returns information about a block by block number
 func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, error) {
-	num, err := GetNumericBlockNumber(number, e.store)
-	if err != nil {
+	block, ok, err := e.getBlockByNumber(number)
+	if err != nil || !ok {
 		return nil, err
 	}
 
-	block, ok := e.store.GetBlockByNumber(num, true)
-	if !ok {
-		return nil, nil
-	}
-
 	if err := e.filterExtra(block); err != nil {
 		return nil, err
 	}
@@ -166,16 +169,29 @@ func (e *Eth) filterExtra(block *types.Block) error {
 	return nil
 }
 
-func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{}, error) {
+// getBlockByNumber returns the block with the given number,
+// or the pending block if available and requested
+func (e *Eth) getBlockByNumber(number BlockNumber) (*types.Block, bool, error) {
+	if number == PendingBlockNumber {
+		if block, _, ok := e.store.GetPendingBlock(); ok {
+			return block, true, nil
+		}
+	}
+
 	num, err := GetNumericBlockNumber(number, e.store)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	block, ok := e.store.GetBlockByNumber(num, true)
 
-	if !ok {
-		return nil, nil
+	return block, ok, nil
+}
+
+func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{}, error) {
+	block, ok, err := e.getBlockByNumber(number)
+	if err != nil || !ok {
+		return nil, err
 	}
 
 	return *types.EncodeUint64(uint64(len(block.Transactions))), nil
@@ -192,7 +208,7 @@ func (e *Eth) BlockNumber() (interface{}, error) {
 }
 
 // SendRawTransaction sends a raw transaction
-func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
+func (e *Eth) SendRawTransaction(ctx context.Context, buf argBytes) (interface{}, error) {
 	tx := &types.Transaction{}
 	if err := tx.UnmarshalRLP(buf); err != nil {
 		return nil, err
@@ -200,7 +216,24 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 
 	tx.ComputeHash()
 
-	if err := e.store.AddTx(tx); err != nil {
+	if err := e.store.AddTxFromClient(tx, clientAddrFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SendPrivateRawTransaction sends a raw transaction which is not gossiped to the network,
+// but only handed over to the trusted peers of the node
+func (e *Eth) SendPrivateRawTransaction(ctx context.Context, buf argBytes) (interface{}, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(buf); err != nil {
+		return nil, err
+	}
+
+	tx.ComputeHash()
+
+	if err := e.store.AddPrivateTxFromClient(tx, clientAddrFromContext(ctx)); err != nil {
 		return nil, err
 	}
 
@@ -776,6 +809,11 @@ func (e *Eth) NewBlockFilter() (interface{}, error) {
 	return e.filterManager.NewBlockFilter(nil), nil
 }
 
+// NewPendingTransactionFilter creates a filter in the node, to notify when new pending transactions arrive
+func (e *Eth) NewPendingTransactionFilter() (interface{}, error) {
+	return e.filterManager.NewPendingTxFilter(false, nil), nil
+}
+
 // GetFilterChanges is a polling method for a filter, which returns an array of logs which occurred since last poll.
 func (e *Eth) GetFilterChanges(id string) (interface{}, error) {
 	return e.filterManager.GetFilterChanges(id)
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
@@ -19,7 +20,7 @@ func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
 	txn.ComputeHash()
 
 	data := txn.MarshalRLP()
-	_, err := eth.SendRawTransaction(data)
+	_, err := eth.SendRawTransaction(context.Background(), data)
 	assert.NoError(t, err)
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 
@@ -41,19 +42,51 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 		GasPrice: big.NewInt(int64(1)),
 	}
 
-	_, err := eth.SendRawTransaction(txToSend.MarshalRLP())
+	_, err := eth.SendRawTransaction(context.Background(), txToSend.MarshalRLP())
 	assert.NoError(t, err)
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
 
+func TestEth_TxnPool_SendPrivateRawTransaction(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(1),
+	}
+	txn.ComputeHash()
+
+	ctx := context.WithValue(context.Background(), clientAddrKey{}, "127.0.0.1")
+
+	hash, err := eth.SendPrivateRawTransaction(ctx, txn.MarshalRLP())
+	assert.NoError(t, err)
+	assert.Equal(t, txn.Hash.String(), hash)
+
+	// the txn should only be added privately, along with the client address
+	assert.Nil(t, store.txn)
+	assert.Equal(t, txn.Hash, store.privateTxn.Hash)
+	assert.Equal(t, "127.0.0.1", store.clientAddr)
+}
+
 type mockStoreTxn struct {
 	ethStore
-	accounts map[types.Address]*mockAccount
-	txn      *types.Transaction
+	accounts   map[types.Address]*mockAccount
+	txn        *types.Transaction
+	privateTxn *types.Transaction
+	clientAddr string
 }
 
-func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
+func (m *mockStoreTxn) AddTxFromClient(tx *types.Transaction, clientAddr string) error {
 	m.txn = tx
+	m.clientAddr = clientAddr
+
+	return nil
+}
+
+func (m *mockStoreTxn) AddPrivateTxFromClient(tx *types.Transaction, clientAddr string) error {
+	m.privateTxn = tx
+	m.clientAddr = clientAddr
 
 	return nil
 }
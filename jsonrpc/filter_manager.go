@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -30,6 +31,9 @@ var (
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
 var defaultTimeout = 1 * time.Minute
 
+// syncingPollInterval is the interval at which the sync status is checked for the syncing filters
+var syncingPollInterval = 1 * time.Second
+
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
@@ -212,8 +216,127 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// pendingTxFilter is a filter to store the transactions promoted in the pool
+type pendingTxFilter struct {
+	filterBase
+	sync.Mutex
+
+	// fullTx is the flag indicating whether the full transactions are returned instead of the hashes
+	fullTx bool
+	txs    []*types.Transaction
+}
+
+// appendTx appends new transaction to txs
+func (f *pendingTxFilter) appendTx(tx *types.Transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.txs = append(f.txs, tx)
+}
+
+// takeTxUpdates returns all saved transactions in filter and set new transaction slice
+func (f *pendingTxFilter) takeTxUpdates() []*types.Transaction {
+	f.Lock()
+	defer f.Unlock()
+
+	txs := f.txs
+	f.txs = []*types.Transaction{}
+
+	return txs
+}
+
+// toUpdate returns the JSON serializable form of the transaction
+func (f *pendingTxFilter) toUpdate(tx *types.Transaction) interface{} {
+	if f.fullTx {
+		return toPendingTransaction(tx)
+	}
+
+	return tx.Hash.String()
+}
+
+// getUpdates returns stored transactions (or their hashes)
+func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	txs := f.takeTxUpdates()
+
+	updates := make([]interface{}, len(txs))
+	for index, tx := range txs {
+		updates[index] = f.toUpdate(tx)
+	}
+
+	return updates, nil
+}
+
+// sendUpdates writes stored transactions (or their hashes) to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	txs := f.takeTxUpdates()
+
+	for _, tx := range txs {
+		raw, err := json.Marshal(f.toUpdate(tx))
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncingFilter is a filter to store the changes of the sync status
+type syncingFilter struct {
+	filterBase
+	sync.Mutex
+
+	updates []*syncingStatus
+}
+
+// appendStatus appends new sync status to updates
+func (f *syncingFilter) appendStatus(status *syncingStatus) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.updates = append(f.updates, status)
+}
+
+// takeStatusUpdates returns all saved sync statuses in filter and set new slice
+func (f *syncingFilter) takeStatusUpdates() []*syncingStatus {
+	f.Lock()
+	defer f.Unlock()
+
+	updates := f.updates
+	f.updates = []*syncingStatus{}
+
+	return updates
+}
+
+// getUpdates returns stored sync statuses
+func (f *syncingFilter) getUpdates() (interface{}, error) {
+	return f.takeStatusUpdates(), nil
+}
+
+// sendUpdates writes stored sync statuses to web socket stream
+func (f *syncingFilter) sendUpdates() error {
+	for _, status := range f.takeStatusUpdates() {
+		raw, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
+	finalityGetter
+	pendingBlockGetter
+
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
@@ -228,6 +351,21 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// SubscribePromotedTxs subscribes for the hashes of the transactions promoted
+	// in the pool, except the private ones. The returned function cancels the subscription
+	SubscribePromotedTxs() (<-chan types.Hash, func())
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
+
+	// FilterBlocks returns the numbers of the blocks in the [from, to] range whose
+	// logs bloom possibly matches all the criteria, using the log index.
+	// It fails if more than maxUnindexed blocks are not indexed yet (no limit if 0)
+	FilterBlocks(from, to uint64, criteria [][][]byte, maxUnindexed uint64) ([]uint64, error)
 }
 
 // FilterManager manages all running filters
@@ -243,6 +381,12 @@ type FilterManager struct {
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
+	promotedTxCh     <-chan types.Hash
+	cancelPromotedTx func()
+
+	// syncing is the sync status reported to the syncing filters the last time
+	syncing bool
+
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
@@ -273,6 +417,11 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 	// start the head watcher
 	m.subscription = store.SubscribeEvents()
 
+	// start the promoted transactions watcher
+	m.promotedTxCh, m.cancelPromotedTx = store.SubscribePromotedTxs()
+
+	m.syncing = store.GetSyncProgression() != nil
+
 	return m
 }
 
@@ -293,6 +442,9 @@ func (f *FilterManager) Run() {
 
 	var timeoutCh <-chan time.Time
 
+	syncingTicker := time.NewTicker(syncingPollInterval)
+	defer syncingTicker.Stop()
+
 	for {
 		// check for the next filter to be removed
 		filterID, filterExpiresAt := f.nextTimeoutFilter()
@@ -309,6 +461,23 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case hash, ok := <-f.promotedTxCh:
+			if !ok {
+				f.promotedTxCh = nil
+
+				continue
+			}
+
+			// new transaction promoted in the pool
+			if err := f.dispatchPromotedTx(hash); err != nil {
+				f.logger.Error("failed to dispatch promoted tx", "err", err)
+			}
+
+		case <-syncingTicker.C:
+			if err := f.dispatchSyncStatus(); err != nil {
+				f.logger.Error("failed to dispatch sync status", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -328,6 +497,7 @@ func (f *FilterManager) Run() {
 
 // Close closed closeCh so that terminate worker
 func (f *FilterManager) Close() {
+	f.cancelPromotedTx()
 	close(f.closeCh)
 }
 
@@ -359,6 +529,33 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		fullTx:     fullTx,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
+// NewSyncingFilter adds new SyncingFilter
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	filter := &syncingFilter{
+		filterBase: newFilterBase(ws),
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -375,6 +572,11 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 		return nil, err
 	}
 
+	return matchBlockLogs(query, block, receipts), nil
+}
+
+// matchBlockLogs returns the logs of the block receipts matching the query
+func matchBlockLogs(query *LogQuery, block *types.Block, receipts []*types.Receipt) []*Log {
 	logIdx := uint64(0)
 	logs := make([]*Log, 0)
 
@@ -397,7 +599,7 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 		}
 	}
 
-	return logs, nil
+	return logs
 }
 
 func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
@@ -421,17 +623,19 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 		from = 1
 	}
 
-	// if not disabled, avoid handling large block ranges
-	if f.blockRangeLimit != 0 && to-from > f.blockRangeLimit {
-		return nil, ErrBlockRangeTooHigh
+	blocks, err := f.candidateBlocks(query, from, to)
+	if err != nil {
+		return nil, err
 	}
 
 	logs := make([]*Log, 0)
 
-	for i := from; i <= to; i++ {
+	for _, i := range blocks {
 		block, ok := f.store.GetBlockByNumber(i, true)
 		if !ok {
-			break
+			// the candidate blocks are sparse,
+			// the later ones can still match
+			continue
 		}
 
 		if len(block.Transactions) == 0 {
@@ -447,9 +651,55 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 		logs = append(logs, blockLogs...)
 	}
 
+	// the logs of the pending block are included on request
+	if query.toBlock == PendingBlockNumber {
+		if block, receipts, ok := f.store.GetPendingBlock(); ok {
+			logs = append(logs, matchBlockLogs(query, block, receipts)...)
+		}
+	}
+
 	return logs, nil
 }
 
+// candidateBlocks returns the numbers of the blocks of the [from, to] range which
+// possibly hold logs matching the query. The log index is used if available, in which
+// case the block range limit applies to the number of candidate blocks, and to the number
+// of blocks not indexed yet, instead.
+func (f *FilterManager) candidateBlocks(query *LogQuery, from, to uint64) ([]uint64, error) {
+	// if not disabled, the blocks not indexed yet are scanned within the block range limit
+	maxUnindexed := uint64(0)
+	if f.blockRangeLimit != 0 {
+		maxUnindexed = f.blockRangeLimit + 1
+	}
+
+	blocks, err := f.store.FilterBlocks(from, to, query.bloomCriteria(), maxUnindexed)
+	if errors.Is(err, blockchain.ErrTooManyUnindexedBlocks) {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	if err != nil {
+		f.logger.Debug("log index not usable, scanning the block range", "err", err)
+
+		// if not disabled, avoid handling large block ranges
+		if f.blockRangeLimit != 0 && to-from > f.blockRangeLimit {
+			return nil, ErrBlockRangeTooHigh
+		}
+
+		blocks = make([]uint64, 0, to-from+1)
+		for i := from; i <= to; i++ {
+			blocks = append(blocks, i)
+		}
+
+		return blocks, nil
+	}
+
+	if f.blockRangeLimit != 0 && uint64(len(blocks)) > f.blockRangeLimit+1 {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	return blocks, nil
+}
+
 // GetLogsForQuery return array of logs for given query
 func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 	if query.BlockHash != nil {
@@ -551,6 +801,10 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
+	if filter.hasWSConn() {
+		activeSubscriptions.Dec()
+	}
+
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
 	}
@@ -591,6 +845,8 @@ func (f *FilterManager) addFilter(filter filter) string {
 	// Set timeout and add to heap if filter doesn't have web socket connection
 	if !filter.hasWSConn() {
 		f.addFilterTimeout(base)
+	} else {
+		activeSubscriptions.Inc()
 	}
 
 	return base.id
@@ -651,6 +907,92 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	}
 }
 
+// dispatchPromotedTx is an event handler for new promoted transaction event
+func (f *FilterManager) dispatchPromotedTx(hash types.Hash) error {
+	if !f.appendTxToFilters(hash) {
+		return nil
+	}
+
+	return f.flushWsFilters()
+}
+
+// appendTxToFilters makes each PendingTxFilter append the promoted transaction,
+// returns false if there are no filters interested in it
+func (f *FilterManager) appendTxToFilters(hash types.Hash) bool {
+	f.RLock()
+	defer f.RUnlock()
+
+	txFilters := make([]*pendingTxFilter, 0)
+
+	for _, f := range f.filters {
+		if txFilter, ok := f.(*pendingTxFilter); ok {
+			txFilters = append(txFilters, txFilter)
+		}
+	}
+
+	if len(txFilters) == 0 {
+		return false
+	}
+
+	tx, ok := f.store.GetPendingTx(hash)
+	if !ok {
+		// already included in a block or dropped from the pool
+		return false
+	}
+
+	for _, txFilter := range txFilters {
+		txFilter.appendTx(tx)
+	}
+
+	return true
+}
+
+// dispatchSyncStatus notifies the syncing filters if the sync status has changed
+func (f *FilterManager) dispatchSyncStatus() error {
+	if !f.appendSyncStatusToFilters() {
+		return nil
+	}
+
+	return f.flushWsFilters()
+}
+
+// appendSyncStatusToFilters makes each SyncingFilter append the new sync status,
+// returns false if the sync status has not changed since the last check
+func (f *FilterManager) appendSyncStatusToFilters() bool {
+	syncProgression := f.store.GetSyncProgression()
+
+	syncing := syncProgression != nil
+	if syncing == f.syncing {
+		return false
+	}
+
+	f.syncing = syncing
+
+	status := &syncingStatus{
+		Syncing: syncing,
+	}
+
+	if syncing {
+		status.Status = &progression{
+			Type:          string(syncProgression.SyncType),
+			StartingBlock: argUint64(syncProgression.StartingBlock),
+			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
+			HighestBlock:  argUint64(syncProgression.HighestBlock),
+		}
+	}
+
+	f.RLock()
+	defer f.RUnlock()
+
+	for _, f := range f.filters {
+		if syncFilter, ok := f.(*syncingFilter); ok {
+			syncFilter.appendStatus(status)
+		}
+	}
+
+	return true
+}
+
 // appendLogsToFilters makes each LogFilters append logs in the header
 func (f *FilterManager) appendLogsToFilters(header *block) error {
 	receipts, err := f.store.GetReceiptsByHash(header.Hash)
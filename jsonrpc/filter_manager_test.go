@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -155,6 +156,96 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_LogIndex(t *testing.T) {
+	t.Parallel()
+
+	topics := [][]types.Hash{{types.StringToHash("4")}, {types.StringToHash("5")}, {types.StringToHash("6")}}
+
+	store := &mockBlockStore{
+		topics:        []types.Hash{topics[0][0], topics[1][0], topics[2][0]},
+		indexedBlocks: []uint64{2},
+	}
+	store.setupLogs()
+
+	for i := 0; i < 5; i++ {
+		store.add(&types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{{Value: big.NewInt(10)}, {Value: big.NewInt(11)}, {Value: big.NewInt(12)}},
+		})
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 10)
+
+	t.Cleanup(func() {
+		defer f.Close()
+	})
+
+	// only the candidate blocks of the index are read
+	logs, err := f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 3, Topics: topics})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, argUint64(2), logs[0].BlockNumber)
+
+	// the block range limit applies to the number of candidate blocks
+	logs, err = f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 1000, Topics: topics})
+	require.NoError(t, err)
+	assert.Len(t, logs, 1)
+
+	store.indexedBlocks = make([]uint64, 20)
+	for i := range store.indexedBlocks {
+		store.indexedBlocks[i] = uint64(i + 1)
+	}
+
+	_, err = f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 1000, Topics: topics})
+	assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+
+	// and to the number of blocks not indexed yet, which are not scanned past it
+	store.indexedBlocks = []uint64{2}
+	store.unindexedBlocks = 12
+
+	_, err = f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 3, Topics: topics})
+	assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+}
+
+func Test_GetLogsForQuery_LogIndexMissingBlock(t *testing.T) {
+	t.Parallel()
+
+	topics := [][]types.Hash{{types.StringToHash("4")}, {types.StringToHash("5")}, {types.StringToHash("6")}}
+
+	store := &mockBlockStore{
+		topics:        []types.Hash{topics[0][0], topics[1][0], topics[2][0]},
+		indexedBlocks: []uint64{1, 2, 3},
+	}
+	store.setupLogs()
+
+	// the candidate block 2 can not be read
+	for _, i := range []int{0, 1, 3} {
+		store.add(&types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{{Value: big.NewInt(10)}, {Value: big.NewInt(11)}, {Value: big.NewInt(12)}},
+		})
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 10)
+
+	t.Cleanup(func() {
+		defer f.Close()
+	})
+
+	// the candidate blocks after the missing one are still read
+	logs, err := f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 3, Topics: topics})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, argUint64(1), logs[0].BlockNumber)
+	assert.Equal(t, argUint64(3), logs[1].BlockNumber)
+}
+
 func Test_getLogsFromBlock(t *testing.T) {
 	t.Parallel()
 
@@ -423,6 +514,89 @@ func TestRemoveFilterByWebsocket(t *testing.T) {
 	assert.False(t, m.Exists(id))
 }
 
+func TestPendingTxFilter(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	tx := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(1),
+		V:        big.NewInt(1),
+		R:        big.NewInt(2),
+		S:        big.NewInt(3),
+		Hash:     types.StringToHash("1"),
+	}
+	store.pendingTxs[tx.Hash] = tx
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	hashesID := m.NewPendingTxFilter(false, nil)
+	fullTxsID := m.NewPendingTxFilter(true, nil)
+
+	ws, msgCh := newMockWsConnWithMsgCh()
+	m.NewPendingTxFilter(false, ws)
+
+	store.promotedTxCh <- tx.Hash
+
+	select {
+	case msg := <-msgCh:
+		assert.Contains(t, string(msg), tx.Hash.String())
+	case <-time.After(2 * time.Second):
+		t.Fatal("promoted tx not received in 2 seconds")
+	}
+
+	hashes, err := m.GetFilterChanges(hashesID)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{tx.Hash.String()}, hashes)
+
+	txs, err := m.GetFilterChanges(fullTxsID)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{toPendingTransaction(tx)}, txs)
+}
+
+func TestSyncingFilter(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	ws, msgCh := newMockWsConnWithMsgCh()
+	m.NewSyncingFilter(ws)
+
+	store.setSyncProgression(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  2,
+		HighestBlock:  3,
+	})
+
+	select {
+	case msg := <-msgCh:
+		assert.Contains(t, string(msg), `"syncing":true`)
+		assert.Contains(t, string(msg), `"highestBlock":"0x3"`)
+	case <-time.After(3 * time.Second):
+		t.Fatal("sync status not received in 3 seconds")
+	}
+
+	store.setSyncProgression(nil)
+
+	select {
+	case msg := <-msgCh:
+		assert.Contains(t, string(msg), `"syncing":false`)
+	case <-time.After(3 * time.Second):
+		t.Fatal("sync status not received in 3 seconds")
+	}
+}
+
 func Test_flushWsFilters(t *testing.T) {
 	t.Parallel()
 
@@ -16,7 +16,23 @@ var (
 	ErrNoDataInContractCreation = errors.New("contract creation without data provided")
 )
 
+// finalityGetter resolves the "safe" and "finalized" block tags
+type finalityGetter interface {
+	// GetSafeBlockNumber returns the number of the latest block which is safe from reorgs
+	GetSafeBlockNumber() (uint64, error)
+
+	// GetFinalizedBlockNumber returns the number of the latest finalized block
+	GetFinalizedBlockNumber() (uint64, error)
+}
+
+// pendingBlockGetter returns the pending block, built on top of the chain head
+// out of the top transactions of the pool, along with its receipts
+type pendingBlockGetter interface {
+	GetPendingBlock() (*types.Block, []*types.Receipt, bool)
+}
+
 type latestHeaderGetter interface {
+	finalityGetter
 	Header() *types.Header
 }
 
@@ -31,6 +47,12 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 		return latest.Number, nil
 
+	case SafeBlockNumber:
+		return store.GetSafeBlockNumber()
+
+	case FinalizedBlockNumber:
+		return store.GetFinalizedBlockNumber()
+
 	case EarliestBlockNumber:
 		return 0, nil
 
@@ -44,6 +66,8 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 }
 
 type headerGetter interface {
+	finalityGetter
+	pendingBlockGetter
 	Header() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 }
@@ -51,7 +75,15 @@ type headerGetter interface {
 // GetBlockHeader returns a header using the provided number
 func GetBlockHeader(number BlockNumber, store headerGetter) (*types.Header, error) {
 	switch number {
-	case PendingBlockNumber, LatestBlockNumber:
+	case PendingBlockNumber:
+		if block, _, ok := store.GetPendingBlock(); ok {
+			return block.Header, nil
+		}
+
+		// the pending block is not built yet, fall back to the latest one
+		return store.Header(), nil
+
+	case LatestBlockNumber:
 		return store.Header(), nil
 
 	case EarliestBlockNumber:
@@ -62,6 +94,19 @@ func GetBlockHeader(number BlockNumber, store headerGetter) (*types.Header, erro
 
 		return header, nil
 
+	case SafeBlockNumber, FinalizedBlockNumber:
+		num, err := GetNumericBlockNumber(number, store)
+		if err != nil {
+			return nil, err
+		}
+
+		header, ok := store.GetHeaderByNumber(num)
+		if !ok {
+			return nil, fmt.Errorf("error fetching block number %d header", num)
+		}
+
+		return header, nil
+
 	default:
 		// Convert the block number from hex to uint64
 		header, ok := store.GetHeaderByNumber(uint64(number))
@@ -100,6 +145,8 @@ func GetTxAndBlockByTxHash(txHash types.Hash, store txLookupAndBlockGetter) (*ty
 }
 
 type blockGetter interface {
+	finalityGetter
+	pendingBlockGetter
 	Header() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
@@ -131,6 +178,8 @@ func GetHeaderFromBlockNumberOrHash(bnh BlockNumberOrHash, store blockGetter) (*
 }
 
 type nonceGetter interface {
+	finalityGetter
+	pendingBlockGetter
 	Header() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetNonce(types.Address) uint64
@@ -139,9 +139,31 @@ func TestGetNumericBlockNumber(t *testing.T) {
 			expected: 10,
 			err:      nil,
 		},
+		{
+			name: "should return the safe block number if safe is given",
+			num:  SafeBlockNumber,
+			store: &debugEndpointMockStore{
+				getSafeFn: func() (uint64, error) {
+					return 9, nil
+				},
+			},
+			expected: 9,
+			err:      nil,
+		},
+		{
+			name: "should return the finalized block number if finalized is given",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				getFinalizedFn: func() (uint64, error) {
+					return 8, nil
+				},
+			},
+			expected: 8,
+			err:      nil,
+		},
 		{
 			name:     "should return error if negative number is given",
-			num:      -5,
+			num:      -10,
 			store:    &debugEndpointMockStore{},
 			expected: 0,
 			err:      ErrNegativeBlockNumber,
@@ -217,12 +239,26 @@ func TestGetBlockHeader(t *testing.T) {
 			err:      ErrFailedFetchGenesis,
 		},
 		{
-			name: "should return latest if pending is given",
+			name: "should return the pending block's header if pending is given",
+			num:  PendingBlockNumber,
+			store: &debugEndpointMockStore{
+				getPendingBlockFn: func() (*types.Block, []*types.Receipt, bool) {
+					return testBlock10, nil, true
+				},
+			},
+			expected: testBlock10.Header,
+			err:      nil,
+		},
+		{
+			name: "should return latest if pending is given and the pending block is not built",
 			num:  PendingBlockNumber,
 			store: &debugEndpointMockStore{
 				headerFn: func() *types.Header {
 					return testLatestHeader
 				},
+				getPendingBlockFn: func() (*types.Block, []*types.Receipt, bool) {
+					return nil, nil, false
+				},
 			},
 			expected: testLatestHeader,
 			err:      nil,
@@ -0,0 +1,154 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
+)
+
+var errIPCMessageTooLarge = errors.New("IPC message too large")
+
+// ipcConn is an IPC client connection. Like on WS connections,
+// the subscription notifications are pushed to the client.
+type ipcConn struct {
+	sync.Mutex
+
+	conn     net.Conn
+	filterID string
+}
+
+func (c *ipcConn) SetFilterID(filterID string) {
+	c.filterID = filterID
+}
+
+func (c *ipcConn) GetFilterID() string {
+	return c.filterID
+}
+
+// WriteMessage writes out the newline delimited message to the IPC client
+func (c *ipcConn) WriteMessage(_ int, data []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, err := c.conn.Write(data); err != nil {
+		return err
+	}
+
+	_, err := c.conn.Write([]byte{'\n'})
+
+	return err
+}
+
+// ipcMessageReader limits the size of the messages read from an IPC connection.
+// The limit applies from the end of the previous message, so the bytes of the next
+// one which are already buffered by the decoder are taken into account
+type ipcMessageReader struct {
+	reader io.Reader
+	limit  int64
+
+	// read is the number of bytes read from the connection,
+	// and end the offset of the end of the previous message
+	read int64
+	end  int64
+}
+
+func (r *ipcMessageReader) Read(p []byte) (int, error) {
+	remaining := r.limit - (r.read - r.end)
+	if remaining <= 0 {
+		return 0, errIPCMessageTooLarge
+	}
+
+	if int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+
+	return n, err
+}
+
+// setupIPC starts a server accepting connections on the configured IPC path
+func (j *JSONRPC) setupIPC() error {
+	lis, err := ipc.Listen(j.config.IPCPath)
+	if err != nil {
+		return err
+	}
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				j.logger.Error("closed ipc listener", "err", err)
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+// handleIPC serves the requests sent over the IPC connection,
+// which is a stream of JSON encoded requests (or batches).
+// The connection is closed if a message exceeds the max request size
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	defer func() {
+		if err := conn.Close(); err != nil {
+			j.logger.Error("Unable to gracefully close IPC connection", "err", err)
+		}
+	}()
+
+	wrapConn := &ipcConn{conn: conn}
+	ctx := withTransport(context.Background(), serverIPC)
+
+	var (
+		reader  io.Reader = conn
+		limited *ipcMessageReader
+	)
+
+	if j.config.MaxRequestSize != 0 {
+		limited = &ipcMessageReader{reader: conn, limit: j.config.MaxRequestSize}
+		reader = limited
+	}
+
+	decoder := json.NewDecoder(reader)
+
+	defer j.dispatcher.RemoveFilterByWs(wrapConn)
+
+	for {
+		var message json.RawMessage
+		if err := decoder.Decode(&message); err != nil {
+			if !errors.Is(err, io.EOF) {
+				j.logger.Error("Unable to read IPC message", "err", err)
+			}
+
+			return
+		}
+
+		// the size of the next message is counted from the end of this one
+		if limited != nil {
+			limited.end = decoder.InputOffset()
+		}
+
+		go func() {
+			resp, err := j.dispatcher.HandleWs(ctx, message, wrapConn)
+			if err != nil {
+				j.logger.Error("Unable to handle IPC request", "err", err)
+
+				return
+			}
+
+			_ = wrapConn.WriteMessage(0, resp)
+		}()
+	}
+}
@@ -1,7 +1,9 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -22,6 +24,20 @@ const (
 	serverWS
 )
 
+// parseServerType returns the server type of the given transport name, http if empty
+func parseServerType(transport string) (serverType, error) {
+	switch transport {
+	case "", serverHTTP.String():
+		return serverHTTP, nil
+	case serverWS.String():
+		return serverWS, nil
+	case serverIPC.String():
+		return serverIPC, nil
+	default:
+		return 0, fmt.Errorf("unknown json-rpc transport '%s'", transport)
+	}
+}
+
 func (s serverType) String() string {
 	switch s {
 	case serverIPC:
@@ -40,12 +56,33 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+	auth       *authenticator
+	wsUpgrader *websocket.Upgrader
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(ctx context.Context, reqBody []byte, conn wsConn) ([]byte, error)
+	Handle(ctx context.Context, reqBody []byte) ([]byte, error)
+}
+
+type clientAddrKey struct{}
+
+// withClientAddr returns a copy of the request context holding the address of the client
+func withClientAddr(ctx context.Context, req *http.Request) context.Context {
+	addr, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		addr = req.RemoteAddr
+	}
+
+	return context.WithValue(ctx, clientAddrKey{}, addr)
+}
+
+// clientAddrFromContext returns the address of the client which sent the request, if known
+func clientAddrFromContext(ctx context.Context) string {
+	addr, _ := ctx.Value(clientAddrKey{}).(string)
+
+	return addr
 }
 
 // JSONRPCStore defines all the methods required
@@ -57,21 +94,49 @@ type JSONRPCStore interface {
 	filterManagerStore
 	bridgeStore
 	debugStore
+	adminStore
 }
 
+// Config is the configuration of a single json-rpc listener
 type Config struct {
 	Store                    JSONRPCStore
 	Addr                     *net.TCPAddr
+	IPCPath                  string
 	ChainID                  uint64
 	ChainName                string
 	AccessControlAllowOrigin []string
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+
+	// Auth enables the authentication of the requests, if set
+	Auth *AuthConfig
+	// Transport is the transport served by the listener (http, ws or ipc), http if empty.
+	// The http transport also accepts WS connections on the /ws path
+	Transport string
+	// CompressionThreshold is the size in bytes from which the responses are compressed
+	// (gzip over HTTP, permessage-deflate over WS) if the client supports it, disabled if 0
+	CompressionThreshold int
+	// MaxRequestSize is the maximal size in bytes of an HTTP request body or of an IPC message, unlimited if 0
+	MaxRequestSize int64
+	// MaxWSMessageSize is the maximal size in bytes of a WS message, unlimited if 0
+	MaxWSMessageSize int64
+	// SlowRequestThreshold is the duration from which the requests are logged, disabled if 0
+	SlowRequestThreshold time.Duration
+	// RateLimit enables the per client rate limiting, if set
+	RateLimit *RateLimitConfig
+	// Namespaces are the services exposed by the server (e.g. eth, net, debug),
+	// all of them are exposed if empty
+	Namespaces []string
 }
 
-// NewJSONRPC returns the JSONRPC http server
+// NewJSONRPC returns the JSONRPC server listening on the configured transport
 func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
+	transport, err := parseServerType(config.Transport)
+	if err != nil {
+		return nil, err
+	}
+
 	d, err := newDispatcher(
 		logger,
 		config.Store,
@@ -81,6 +146,9 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			priceLimit:              config.PriceLimit,
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
+			namespaces:              config.Namespaces,
+			rateLimit:               config.RateLimit,
+			slowRequestThreshold:    config.SlowRequestThreshold,
 		},
 	)
 
@@ -92,10 +160,20 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		logger:     logger.Named("jsonrpc"),
 		config:     config,
 		dispatcher: d,
+		auth:       newAuthenticator(config.Auth),
+		wsUpgrader: newWSUpgrader(config.CompressionThreshold != 0),
 	}
 
-	// start http server
-	if err := srv.setupHTTP(); err != nil {
+	switch transport {
+	case serverIPC:
+		err = srv.setupIPC()
+	case serverWS:
+		err = srv.setupWS()
+	default:
+		err = srv.setupHTTP()
+	}
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -103,13 +181,6 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 }
 
 func (j *JSONRPC) setupHTTP() error {
-	j.logger.Info("http server started", "addr", j.config.Addr.String())
-
-	lis, err := net.Listen("tcp", j.config.Addr.String())
-	if err != nil {
-		return err
-	}
-
 	// NewServeMux must be used, as it disables all debug features.
 	// For some strange reason, with DefaultServeMux debug/vars is always enabled (but not debug/pprof).
 	// If pprof need to be enabled, this should be DefaultServeMux
@@ -117,18 +188,42 @@ func (j *JSONRPC) setupHTTP() error {
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	mux.Handle("/", middlewareFactory(j.config)(j.authMiddleware(jsonRPCHandler)))
+
+	// WS clients are authenticated before the connection gets upgraded
+	mux.Handle("/ws", j.authMiddleware(http.HandlerFunc(j.handleWs)))
+
+	return j.serve(serverHTTP, mux)
+}
+
+// setupWS starts a server accepting WS connections only
+func (j *JSONRPC) setupWS() error {
+	mux := http.NewServeMux()
+
+	wsHandler := j.authMiddleware(http.HandlerFunc(j.handleWs))
+	mux.Handle("/", wsHandler)
+	mux.Handle("/ws", wsHandler)
 
-	mux.HandleFunc("/ws", j.handleWs)
+	return j.serve(serverWS, mux)
+}
+
+// serve serves the given handler on the configured address
+func (j *JSONRPC) serve(transport serverType, handler http.Handler) error {
+	lis, err := net.Listen("tcp", j.config.Addr.String())
+	if err != nil {
+		return err
+	}
+
+	j.logger.Info(transport.String()+" server started", "addr", j.config.Addr.String())
 
 	srv := http.Server{
-		Handler:           mux,
+		Handler:           handler,
 		ReadHeaderTimeout: 60 * time.Second,
 	}
 
 	go func() {
 		if err := srv.Serve(lis); err != nil {
-			j.logger.Error("closed http connection", "err", err)
+			j.logger.Error("closed "+transport.String()+" connection", "err", err)
 		}
 	}()
 
@@ -159,14 +254,20 @@ func middlewareFactory(config *Config) func(http.Handler) http.Handler {
 	}
 }
 
-// wsUpgrader defines upgrade parameters for the WS connection
-var wsUpgrader = websocket.Upgrader{
-	// Uses the default HTTP buffer sizes for Read / Write buffers.
-	// Documentation specifies that they are 4096B in size.
-	// There is no need to have them be 4x in size when requests / responses
-	// shouldn't exceed 1024B
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
+// newWSUpgrader returns the upgrade parameters for the WS connections
+func newWSUpgrader(enableCompression bool) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		// Uses the default HTTP buffer sizes for Read / Write buffers.
+		// Documentation specifies that they are 4096B in size.
+		// There is no need to have them be 4x in size when requests / responses
+		// shouldn't exceed 1024B
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		// permessage-deflate is negotiated with the clients supporting it
+		EnableCompression: enableCompression,
+		// CORS rule - Allow requests from anywhere
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
 }
 
 // wsWrapper is a wrapping object for the web socket connection and logger
@@ -176,6 +277,10 @@ type wsWrapper struct {
 	ws       *websocket.Conn // the actual WS connection
 	logger   hclog.Logger    // module logger
 	filterID string          // filter ID
+
+	// compressionThreshold is the size from which the messages are compressed
+	// (if negotiated with the peer), disabled if 0
+	compressionThreshold int
 }
 
 func (w *wsWrapper) SetFilterID(filterID string) {
@@ -190,6 +295,11 @@ func (w *wsWrapper) GetFilterID() string {
 func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
 	w.Lock()
 	defer w.Unlock()
+
+	if w.compressionThreshold != 0 {
+		w.ws.EnableWriteCompression(len(data) >= w.compressionThreshold)
+	}
+
 	writeErr := w.ws.WriteMessage(messageType, data)
 
 	if writeErr != nil {
@@ -207,12 +317,41 @@ func isSupportedWSType(messageType int) bool {
 		messageType == websocket.BinaryMessage
 }
 
-func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
-	// CORS rule - Allow requests from anywhere
-	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
+// readWSMessage reads the next message of the WS connection. The size limit
+// applies to the decompressed message, as compressed frames can inflate a lot.
+func (j *JSONRPC) readWSMessage(ws *websocket.Conn) (int, []byte, error) {
+	msgType, reader, err := ws.NextReader()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if j.config.MaxWSMessageSize == 0 {
+		message, err := io.ReadAll(reader)
+
+		return msgType, message, err
+	}
 
+	message, err := io.ReadAll(io.LimitReader(reader, j.config.MaxWSMessageSize+1))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if int64(len(message)) > j.config.MaxWSMessageSize {
+		_ = ws.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseMessageTooBig, ""),
+			time.Now().Add(time.Second),
+		)
+
+		return 0, nil, websocket.ErrReadLimit
+	}
+
+	return msgType, message, nil
+}
+
+func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	// Upgrade the connection to a WS one
-	ws, err := wsUpgrader.Upgrade(w, req, nil)
+	ws, err := j.wsUpgrader.Upgrade(w, req, nil)
 	if err != nil {
 		j.logger.Error(fmt.Sprintf("Unable to upgrade to a WS connection, %s", err.Error()))
 
@@ -229,13 +368,28 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 	}(ws)
 
-	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	if j.config.MaxWSMessageSize != 0 {
+		ws.SetReadLimit(j.config.MaxWSMessageSize)
+	}
+
+	wrapConn := &wsWrapper{
+		ws:                   ws,
+		logger:               j.logger,
+		compressionThreshold: j.config.CompressionThreshold,
+	}
+	// the request context is canceled once the connection gets closed,
+	// while the requests may still be handled
+	ctx := withAPIKey(withClientAddr(context.Background(), req), apiKeyFromContext(req.Context()))
+	ctx = withTransport(ctx, serverWS)
+
+	wsConnections.Inc()
+	defer wsConnections.Dec()
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
 	for {
 		// Read the incoming message
-		msgType, message, err := ws.ReadMessage()
+		msgType, message, err := j.readWSMessage(ws)
 		if err != nil {
 			if websocket.IsCloseError(err,
 				websocket.CloseGoingAway,
@@ -256,7 +410,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := j.dispatcher.HandleWs(ctx, message, wrapConn)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -277,7 +431,7 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
 	w.Header().Set(
 		"Access-Control-Allow-Headers",
-		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
+		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key",
 	)
 
 	switch req.Method {
@@ -293,8 +447,17 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request) {
+	if j.config.MaxRequestSize != 0 {
+		req.Body = http.MaxBytesReader(w, req.Body, j.config.MaxRequestSize)
+	}
+
 	data, err := io.ReadAll(req.Body)
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}
+
 		_, _ = w.Write([]byte(err.Error()))
 
 		return
@@ -303,12 +466,12 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.Handle(withTransport(withClientAddr(req.Context(), req), serverHTTP), data)
 
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
 	} else {
-		_, _ = w.Write(resp)
+		j.writeResponse(w, req, resp)
 	}
 
 	j.logger.Debug("handle", "response", string(resp))
@@ -1,14 +1,20 @@
 package jsonrpc
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -32,6 +38,105 @@ func TestHTTPServer(t *testing.T) {
 	}
 }
 
+func TestIPCServer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("named pipes are not covered")
+	}
+
+	path := filepath.Join(t.TempDir(), "edge.ipc")
+
+	_, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:      newMockStore(),
+		Transport:  "ipc",
+		IPCPath:    path,
+		ChainID:    100,
+		Namespaces: []string{"net"},
+	})
+	require.NoError(t, err)
+
+	conn, err := ipc.Dial(path)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	request := func(req string) *SuccessResponse {
+		_, err := conn.Write([]byte(req))
+		require.NoError(t, err)
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+
+		resp := &SuccessResponse{}
+		require.NoError(t, json.Unmarshal(line, resp))
+
+		return resp
+	}
+
+	resp := request(`{"jsonrpc": "2.0", "id": 1, "method": "net_version", "params": []}`)
+	require.Nil(t, resp.Error)
+	assert.Equal(t, `"100"`, string(resp.Result))
+
+	// the namespaces of the listener apply
+	resp = request(`{"jsonrpc": "2.0", "id": 2, "method": "eth_chainId", "params": []}`)
+	assert.NotNil(t, resp.Error)
+}
+
+func TestIPCServer_MaxRequestSize(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("named pipes are not covered")
+	}
+
+	path := filepath.Join(t.TempDir(), "edge.ipc")
+
+	_, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:          newMockStore(),
+		Transport:      "ipc",
+		IPCPath:        path,
+		ChainID:        100,
+		MaxRequestSize: 100,
+	})
+	require.NoError(t, err)
+
+	conn, err := ipc.Dial(path)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	req := `{"jsonrpc": "2.0", "id": 1, "method": "net_version", "params": []}`
+
+	// the limit applies to every message, not to the whole stream
+	_, err = conn.Write([]byte(req + req + req))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+
+		resp := &SuccessResponse{}
+		require.NoError(t, json.Unmarshal(line, resp))
+		assert.Equal(t, `"100"`, string(resp.Result))
+	}
+
+	// the connection is closed once a message exceeds it
+	_, err = conn.Write([]byte(`{"jsonrpc": "2.0", "id": 2, "method": "net_version", "params": ["` +
+		strings.Repeat("a", 100) + `"]}`))
+	require.NoError(t, err)
+
+	_, err = reader.ReadBytes('\n')
+	assert.Error(t, err)
+}
+
+func TestNewJSONRPC_UnknownTransport(t *testing.T) {
+	_, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:     newMockStore(),
+		Transport: "udp",
+	})
+	assert.ErrorContains(t, err, "unknown json-rpc transport")
+}
+
 func Test_handleGetRequest(t *testing.T) {
 	var (
 		chainName = "polygon-edge-test"
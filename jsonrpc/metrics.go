@@ -0,0 +1,93 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The per method metrics are registered directly on the prometheus registry
+// (served by the prometheus server of the node), as the go-metrics sink
+// exports samples as summaries, which can't be aggregated across nodes.
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "edge",
+		Subsystem: jsonRPCMetric,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the json-rpc requests",
+		Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "transport"})
+
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "edge",
+		Subsystem: jsonRPCMetric,
+		Name:      "requests_total",
+		Help:      "Number of json-rpc requests",
+	}, []string{"method", "transport"})
+
+	errorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "edge",
+		Subsystem: jsonRPCMetric,
+		Name:      "errors_total",
+		Help:      "Number of json-rpc requests which failed",
+	}, []string{"method", "transport"})
+
+	batchSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "edge",
+		Subsystem: jsonRPCMetric,
+		Name:      "batch_size",
+		Help:      "Number of requests of the json-rpc batches",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"transport"})
+
+	wsConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "edge",
+		Subsystem: jsonRPCMetric,
+		Name:      "ws_connections",
+		Help:      "Number of active WS connections",
+	})
+
+	activeSubscriptions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "edge",
+		Subsystem: jsonRPCMetric,
+		Name:      "subscriptions",
+		Help:      "Number of active subscriptions",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		requestDuration,
+		requestsTotal,
+		errorsTotal,
+		batchSize,
+		wsConnections,
+		activeSubscriptions,
+	)
+}
+
+type transportCtxKey struct{}
+
+// withTransport returns a copy of the context holding the transport the request was received on
+func withTransport(ctx context.Context, transport serverType) context.Context {
+	return context.WithValue(ctx, transportCtxKey{}, transport.String())
+}
+
+// transportFromContext returns the transport the request was received on
+func transportFromContext(ctx context.Context) string {
+	if transport, ok := ctx.Value(transportCtxKey{}).(string); ok {
+		return transport
+	}
+
+	return "unknown"
+}
+
+// paramsDigest returns a short digest of the request params, which allows
+// correlating identical requests without logging their (possibly large) params
+func paramsDigest(params []byte) string {
+	digest := sha256.Sum256(params)
+
+	return hex.EncodeToString(digest[:8])
+}
@@ -0,0 +1,54 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_Metrics(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+
+	dispatcher := newTestDispatcher(t,
+		hclog.New(&hclog.LoggerOptions{Output: &logs, Level: hclog.Warn}),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:              10,
+			slowRequestThreshold: time.Nanosecond,
+		},
+	)
+
+	ctx := withTransport(context.Background(), serverIPC)
+
+	requests := testutil.ToFloat64(requestsTotal.WithLabelValues("web3_clientVersion", "ipc"))
+	errors := testutil.ToFloat64(errorsTotal.WithLabelValues("web3_sha3", "ipc"))
+
+	_, err := dispatcher.Handle(ctx, []byte(`[
+		{"id": 1, "method": "web3_clientVersion", "params": []},
+		{"id": 2, "method": "web3_sha3", "params": {}}
+	]`))
+	require.NoError(t, err)
+
+	assert.Equal(t, requests+1, testutil.ToFloat64(requestsTotal.WithLabelValues("web3_clientVersion", "ipc")))
+	assert.Equal(t, errors+1, testutil.ToFloat64(errorsTotal.WithLabelValues("web3_sha3", "ipc")))
+
+	// the slow requests are logged with the digest of their params
+	assert.Contains(t, logs.String(), "slow request")
+	assert.Contains(t, logs.String(), "method=web3_clientVersion")
+	assert.Contains(t, logs.String(), "params="+paramsDigest([]byte("[]")))
+}
+
+func Test_transportFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "unknown", transportFromContext(context.Background()))
+	assert.Equal(t, "ws", transportFromContext(withTransport(context.Background(), serverWS)))
+}
@@ -1,13 +1,17 @@
 package jsonrpc
 
 import (
+	"errors"
 	"math/big"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+var errLogIndexUnavailable = errors.New("log index not available")
+
 type mockAccount struct {
 	address types.Address
 	code    []byte
@@ -52,6 +56,11 @@ type mockStore struct {
 
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
+
+	promotedTxCh    chan types.Hash
+	pendingTxs      map[types.Hash]*types.Transaction
+	syncLock        sync.Mutex
+	syncProgression *progress.Progression
 }
 
 func newMockStore() *mockStore {
@@ -59,6 +68,8 @@ func newMockStore() *mockStore {
 		header:       &types.Header{Number: 0},
 		subscription: blockchain.NewMockSubscription(),
 		accounts:     map[types.Address]*Account{},
+		promotedTxCh: make(chan types.Hash),
+		pendingTxs:   map[types.Hash]*types.Transaction{},
 	}
 	m.addHeader(m.header)
 
@@ -137,6 +148,34 @@ func (m *mockStore) SubscribeEvents() blockchain.Subscription {
 	return m.subscription
 }
 
+func (m *mockStore) SubscribePromotedTxs() (<-chan types.Hash, func()) {
+	return m.promotedTxCh, func() {}
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	tx, ok := m.pendingTxs[txHash]
+
+	return tx, ok
+}
+
+func (m *mockStore) GetSyncProgression() *progress.Progression {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	return m.syncProgression
+}
+
+func (m *mockStore) FilterBlocks(from, to uint64, criteria [][][]byte, maxUnindexed uint64) ([]uint64, error) {
+	return nil, errLogIndexUnavailable
+}
+
+func (m *mockStore) setSyncProgression(syncProgression *progress.Progression) {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	m.syncProgression = syncProgression
+}
+
 func (m *mockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	header := m.headerLoop(func(header *types.Header) bool {
 		return header.Number == num
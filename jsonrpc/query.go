@@ -213,3 +213,28 @@ func (q *LogQuery) Match(log *types.Log) bool {
 
 	return true
 }
+
+// bloomCriteria returns the values the logs bloom of a block must contain for the
+// block to possibly hold matching logs: one of the addresses, and one of the topics
+// of every position (an empty set of values matches every bloom)
+func (q *LogQuery) bloomCriteria() [][][]byte {
+	criteria := make([][][]byte, 0, len(q.Topics)+1)
+
+	addresses := make([][]byte, len(q.Addresses))
+	for i, addr := range q.Addresses {
+		addresses[i] = addr.Bytes()
+	}
+
+	criteria = append(criteria, addresses)
+
+	for _, sub := range q.Topics {
+		topics := make([][]byte, len(sub))
+		for i, topic := range sub {
+			topics[i] = topic.Bytes()
+		}
+
+		criteria = append(criteria, topics)
+	}
+
+	return criteria
+}
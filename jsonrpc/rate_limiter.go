@@ -0,0 +1,182 @@
+package jsonrpc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// the costs of the requests, in credits
+	defaultMethodCost = 1
+	callMethodCost    = 5
+	logsMethodCost    = 10
+	traceMethodCost   = 20
+
+	// expensiveMethodCost is the cost from which the methods are
+	// subject to the concurrency cap
+	expensiveMethodCost = callMethodCost
+
+	// idle client buckets are removed after this interval
+	rateLimiterIdleTimeout = 10 * time.Minute
+)
+
+// RateLimitConfig holds the per client rate limiting settings of a json-rpc listener.
+// Clients are identified by their API key if authenticated with one, or their IP address otherwise.
+type RateLimitConfig struct {
+	// CreditsPerSecond is the number of credits granted to a client every second,
+	// a value of 0 disables the credit limiting
+	CreditsPerSecond float64
+	// Burst is the maximal number of credits a client can accumulate,
+	// it is raised to the cost of the most expensive method if lower
+	Burst uint64
+	// MaxConcurrentExpensive is the maximal number of expensive requests (calls,
+	// logs queries and traces) a client can run at once, a value of 0 disables it
+	MaxConcurrentExpensive uint64
+}
+
+// methodCost returns the cost of the method, in credits
+func methodCost(method string) int {
+	switch {
+	case strings.HasPrefix(method, "debug_trace"):
+		return traceMethodCost
+	case method == "eth_getLogs" || method == "eth_getFilterLogs":
+		return logsMethodCost
+	case method == "eth_call" || method == "eth_estimateGas":
+		return callMethodCost
+	default:
+		return defaultMethodCost
+	}
+}
+
+// clientIDFromContext returns the identifier of the client the rate limits apply to,
+// empty if unknown (e.g. IPC clients)
+func clientIDFromContext(ctx context.Context) string {
+	if key := apiKeyFromContext(ctx); key != "" {
+		return "key:" + key
+	}
+
+	if addr := clientAddrFromContext(ctx); addr != "" {
+		return "ip:" + addr
+	}
+
+	return ""
+}
+
+// rateLimiter charges the requests of each client to a bucket of credits
+// refilled over time, and caps the number of concurrent expensive requests
+type rateLimiter struct {
+	sync.Mutex
+
+	creditsPerSecond rate.Limit
+	burst            int
+	maxConcurrent    uint64
+
+	buckets   map[string]*clientBucket
+	lastSweep time.Time
+}
+
+// clientBucket holds the credits and the running expensive requests of a client
+type clientBucket struct {
+	limiter  *rate.Limiter
+	inflight uint64
+	lastSeen time.Time
+}
+
+// newRateLimiter returns the rate limiter of the given config,
+// or nil if rate limiting is disabled
+func newRateLimiter(config *RateLimitConfig) *rateLimiter {
+	if config == nil || (config.CreditsPerSecond == 0 && config.MaxConcurrentExpensive == 0) {
+		return nil
+	}
+
+	burst := int(config.Burst)
+	if burst < traceMethodCost {
+		burst = traceMethodCost
+	}
+
+	// the credits are not limited if only the concurrency is
+	creditsPerSecond := rate.Limit(config.CreditsPerSecond)
+	if creditsPerSecond == 0 {
+		creditsPerSecond = rate.Inf
+	}
+
+	return &rateLimiter{
+		creditsPerSecond: creditsPerSecond,
+		burst:            burst,
+		maxConcurrent:    config.MaxConcurrentExpensive,
+		buckets:          make(map[string]*clientBucket),
+		lastSweep:        time.Now(),
+	}
+}
+
+// acquire charges the cost of the method to the client. The returned function
+// must be called once the request has been handled.
+func (l *rateLimiter) acquire(client, method string) (func(), Error) {
+	if l == nil || client == "" {
+		return func() {}, nil
+	}
+
+	cost := methodCost(method)
+
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	bucket := l.bucket(client, now)
+	expensive := cost >= expensiveMethodCost
+
+	if expensive && l.maxConcurrent != 0 && bucket.inflight >= l.maxConcurrent {
+		return nil, NewRateLimitedError("too many concurrent expensive requests")
+	}
+
+	if !bucket.limiter.AllowN(now, cost) {
+		return nil, NewRateLimitedError("request rate limit exceeded")
+	}
+
+	if !expensive {
+		return func() {}, nil
+	}
+
+	bucket.inflight++
+
+	return func() {
+		l.Lock()
+		defer l.Unlock()
+
+		bucket.inflight--
+	}, nil
+}
+
+// bucket returns the bucket of the given client, creating it if needed
+func (l *rateLimiter) bucket(client string, now time.Time) *clientBucket {
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &clientBucket{limiter: rate.NewLimiter(l.creditsPerSecond, l.burst)}
+		l.buckets[client] = bucket
+	}
+
+	bucket.lastSeen = now
+
+	return bucket
+}
+
+// sweep removes the buckets of the clients which were idle for a while
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimiterIdleTimeout {
+		return
+	}
+
+	for client, bucket := range l.buckets {
+		if bucket.inflight == 0 && now.Sub(bucket.lastSeen) >= rateLimiterIdleTimeout {
+			delete(l.buckets, client)
+		}
+	}
+
+	l.lastSweep = now
+}
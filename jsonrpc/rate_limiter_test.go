@@ -0,0 +1,114 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_methodCost(t *testing.T) {
+	t.Parallel()
+
+	assert.Greater(t, methodCost("debug_traceTransaction"), methodCost("eth_getLogs"))
+	assert.Greater(t, methodCost("eth_getLogs"), methodCost("eth_call"))
+	assert.Greater(t, methodCost("eth_call"), methodCost("eth_blockNumber"))
+	assert.Equal(t, methodCost("eth_call"), methodCost("eth_estimateGas"))
+}
+
+func TestRateLimiter_Credits(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newRateLimiter(nil))
+	assert.Nil(t, newRateLimiter(&RateLimitConfig{Burst: 10}))
+
+	// the refill is negligible during the test
+	limiter := newRateLimiter(&RateLimitConfig{CreditsPerSecond: 0.001, Burst: 30})
+
+	// a trace leaves 10 credits, enough for a logs query but not for another trace
+	release, err := limiter.acquire("client1", "debug_traceBlockByNumber")
+	require.Nil(t, err)
+	release()
+
+	_, err = limiter.acquire("client1", "debug_traceBlockByNumber")
+	require.NotNil(t, err)
+	assert.Equal(t, -32005, err.ErrorCode())
+
+	release, err = limiter.acquire("client1", "eth_getLogs")
+	require.Nil(t, err)
+	release()
+
+	_, err = limiter.acquire("client1", "eth_blockNumber")
+	require.NotNil(t, err)
+
+	// other clients are not affected
+	_, err = limiter.acquire("client2", "eth_getLogs")
+	require.Nil(t, err)
+
+	// unknown clients are not limited
+	_, err = limiter.acquire("", "eth_blockNumber")
+	require.Nil(t, err)
+}
+
+func TestRateLimiter_ConcurrentExpensive(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter(&RateLimitConfig{MaxConcurrentExpensive: 1})
+
+	release, err := limiter.acquire("client", "eth_call")
+	require.Nil(t, err)
+
+	_, err = limiter.acquire("client", "debug_traceTransaction")
+	require.NotNil(t, err)
+
+	// cheap methods are not capped
+	_, err = limiter.acquire("client", "eth_chainId")
+	require.Nil(t, err)
+
+	release()
+
+	_, err = limiter.acquire("client", "debug_traceTransaction")
+	require.Nil(t, err)
+}
+
+func TestDispatcher_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:   10,
+			rateLimit: &RateLimitConfig{CreditsPerSecond: 0.001, Burst: 20},
+		},
+	)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	ctx := withClientAddr(context.Background(), req)
+
+	request := func(ctx context.Context) *SuccessResponse {
+		resp := &SuccessResponse{}
+
+		r, err := dispatcher.Handle(ctx, []byte(`{"method": "net_version", "params": []}`))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(r, resp))
+
+		return resp
+	}
+
+	for i := 0; i < 20; i++ {
+		require.Nil(t, request(ctx).Error)
+	}
+
+	resp := request(ctx)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32005, resp.Error.Code)
+
+	// clients authenticated with an API key have their own budget
+	assert.Nil(t, request(withAPIKey(ctx, "key")).Error)
+}
@@ -14,6 +14,21 @@ type txPoolStore interface {
 
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
+
+	// GetTxsFrom gets the pending and queued transactions of the given account
+	GetTxsFrom(addr types.Address) ([]*types.Transaction, []*types.Transaction)
+
+	// GetPoolTx gets a transaction from the pool along with its status and position
+	GetPoolTx(txHash types.Hash) (*PoolTx, bool)
+}
+
+// PoolTx is a transaction found in the tx pool
+type PoolTx struct {
+	Tx *types.Transaction
+	// Status is either pending or queued
+	Status string
+	// Position is the number of transactions of the same account ahead of this one
+	Position uint64
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -33,6 +48,17 @@ type InspectResponse struct {
 	MaxCapacity     uint64                       `json:"maxCapacity"`
 }
 
+type ContentFromResponse struct {
+	Pending map[uint64]*txpoolTransaction `json:"pending"`
+	Queued  map[uint64]*txpoolTransaction `json:"queued"`
+}
+
+type TransactionResponse struct {
+	Transaction *txpoolTransaction `json:"transaction"`
+	Status      string             `json:"status"`
+	Position    argUint64          `json:"position"`
+}
+
 type StatusResponse struct {
 	Pending uint64 `json:"pending"`
 	Queued  uint64 `json:"queued"`
@@ -191,3 +217,42 @@ func (t *TxPool) Status() (interface{}, error) {
 
 	return resp, nil
 }
+
+// Create response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-txpool#txpool-contentfrom.
+func (t *TxPool) ContentFrom(addr types.Address) (interface{}, error) {
+	pendingTxs, queuedTxs := t.store.GetTxsFrom(addr)
+
+	resp := ContentFromResponse{
+		Pending: make(map[uint64]*txpoolTransaction, len(pendingTxs)),
+		Queued:  make(map[uint64]*txpoolTransaction, len(queuedTxs)),
+	}
+
+	for _, tx := range pendingTxs {
+		resp.Pending[tx.Nonce] = toTxPoolTransaction(tx)
+	}
+
+	for _, tx := range queuedTxs {
+		resp.Queued[tx.Nonce] = toTxPoolTransaction(tx)
+	}
+
+	return resp, nil
+}
+
+// Create response for txpool_getTransaction request.
+// Returns the transaction along with its status and position in the account queue,
+// or null if the transaction is not in the pool.
+func (t *TxPool) GetTransaction(hash types.Hash) (interface{}, error) {
+	poolTx, ok := t.store.GetPoolTx(hash)
+	if !ok {
+		return nil, nil
+	}
+
+	resp := TransactionResponse{
+		Transaction: toTxPoolTransaction(poolTx.Tx),
+		Status:      poolTx.Status,
+		Position:    argUint64(poolTx.Position),
+	}
+
+	return resp, nil
+}
@@ -228,6 +228,71 @@ func TestStatusEndpoint(t *testing.T) {
 	})
 }
 
+func TestContentFromEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	address2 := types.Address{0x2}
+	mockStore.pending[address1] = []*types.Transaction{newTestTransaction(0, address1), newTestTransaction(1, address1)}
+	mockStore.queued[address1] = []*types.Transaction{newTestTransaction(3, address1)}
+	mockStore.pending[address2] = []*types.Transaction{newTestTransaction(0, address2)}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, _ := txPoolEndpoint.ContentFrom(address1)
+	//nolint:forcetypeassert
+	response := result.(ContentFromResponse)
+
+	assert.Len(t, response.Pending, 2)
+	assert.Len(t, response.Queued, 1)
+	assert.Equal(t, address1, response.Pending[1].From)
+	assert.Equal(t, argUint64(3), response.Queued[3].Nonce)
+
+	// unknown account
+	result, _ = txPoolEndpoint.ContentFrom(types.Address{0x3})
+	//nolint:forcetypeassert
+	response = result.(ContentFromResponse)
+
+	assert.Len(t, response.Pending, 0)
+	assert.Len(t, response.Queued, 0)
+}
+
+func TestGetTransactionEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	mockStore.pending[address1] = []*types.Transaction{newTestTransaction(0, address1), newTestTransaction(1, address1)}
+	mockStore.queued[address1] = []*types.Transaction{newTestTransaction(3, address1)}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	queuedTx := mockStore.queued[address1][0]
+
+	result, err := txPoolEndpoint.GetTransaction(queuedTx.Hash)
+	assert.NoError(t, err)
+	//nolint:forcetypeassert
+	response := result.(TransactionResponse)
+
+	assert.Equal(t, queuedTx.Hash, response.Transaction.Hash)
+	assert.Equal(t, "queued", response.Status)
+	assert.Equal(t, argUint64(0), response.Position)
+
+	pendingTx := mockStore.pending[address1][1]
+
+	result, err = txPoolEndpoint.GetTransaction(pendingTx.Hash)
+	assert.NoError(t, err)
+	//nolint:forcetypeassert
+	response = result.(TransactionResponse)
+
+	assert.Equal(t, "pending", response.Status)
+	assert.Equal(t, argUint64(1), response.Position)
+
+	// unknown transaction
+	result, err = txPoolEndpoint.GetTransaction(types.Hash{0x1})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
 type mockTxPoolStore struct {
 	pending       map[types.Address][]*types.Transaction
 	queued        map[types.Address][]*types.Transaction
@@ -253,6 +318,30 @@ func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
 
+func (s *mockTxPoolStore) GetTxsFrom(addr types.Address) ([]*types.Transaction, []*types.Transaction) {
+	return s.pending[addr], s.queued[addr]
+}
+
+func (s *mockTxPoolStore) GetPoolTx(txHash types.Hash) (*PoolTx, bool) {
+	find := func(all map[types.Address][]*types.Transaction, status string) (*PoolTx, bool) {
+		for _, txs := range all {
+			for i, tx := range txs {
+				if tx.Hash == txHash {
+					return &PoolTx{Tx: tx, Status: status, Position: uint64(i)}, true
+				}
+			}
+		}
+
+		return nil, false
+	}
+
+	if poolTx, ok := find(s.pending, "pending"); ok {
+		return poolTx, true
+	}
+
+	return find(s.queued, "queued")
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
@@ -344,3 +344,9 @@ type progression struct {
 	CurrentBlock  argUint64 `json:"currentBlock"`
 	HighestBlock  argUint64 `json:"highestBlock"`
 }
+
+// syncingStatus is the update sent to the syncing subscriptions
+type syncingStatus struct {
+	Syncing bool         `json:"syncing"`
+	Status  *progression `json:"status,omitempty"`
+}
@@ -17,6 +17,24 @@ const (
 	PriorityRandomDial    DialPriority = 10
 )
 
+// Penalty is the amount of reputation a peer loses for a misbehavior
+type Penalty int64
+
+const (
+	// PenaltyInvalidTx is applied to peers gossiping transactions which can never be valid
+	PenaltyInvalidTx Penalty = 5
+	// PenaltyMalformedMessage is applied to peers sending messages which can't be decoded
+	PenaltyMalformedMessage Penalty = 10
+	// PenaltyInvalidMessage is applied to peers relaying gossip messages rejected by the topic validator
+	PenaltyInvalidMessage Penalty = 10
+	// PenaltyFailedHandshake is applied to peers failing the identity handshake
+	PenaltyFailedHandshake Penalty = 25
+	// PenaltyInvalidBlock is applied to peers serving blocks which fail verification
+	PenaltyInvalidBlock Penalty = 50
+	// PenaltyInvalidState is applied to peers serving state ranges or items which fail verification
+	PenaltyInvalidState Penalty = 50
+)
+
 const (
 	DiscProto     = "/disc/0.1"
 	IdentityProto = "/id/0.1"
@@ -82,6 +100,30 @@ func AddrInfoToString(addr *peer.AddrInfo) (string, error) {
 	return dialAddress + "/p2p/" + addr.ID.String(), nil
 }
 
+// AddrInfoToStrings converts an AddrInfo into the string representations of all its addresses,
+// which can be dialed from another node. The loopback addresses are listed last
+func AddrInfoToStrings(addr *peer.AddrInfo) ([]string, error) {
+	// Safety check
+	if len(addr.Addrs) == 0 {
+		return nil, errors.New("no dial addresses found")
+	}
+
+	dialAddresses := make([]string, 0, len(addr.Addrs))
+	loopbackAddresses := make([]string, 0)
+
+	for _, address := range addr.Addrs {
+		dialAddress := address.String() + "/p2p/" + addr.ID.String()
+
+		if loopbackRegex.MatchString(address.String()) {
+			loopbackAddresses = append(loopbackAddresses, dialAddress)
+		} else {
+			dialAddresses = append(dialAddresses, dialAddress)
+		}
+	}
+
+	return append(dialAddresses, loopbackAddresses...), nil
+}
+
 // MultiAddrFromDNS constructs a multiAddr from the passed in DNS address and port combination
 func MultiAddrFromDNS(addr string, port int) (multiaddr.Multiaddr, error) {
 	var (
@@ -5,6 +5,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -20,6 +21,12 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+	StaticPeers      []*peer.AddrInfo       // the peers always (re)connected to, exempt from the connection limits
+	TrustedPeers     []peer.ID              // the peers exempt from the connection limits
+	PeerAllowlist    string                 // the path of the file listing the only peers allowed to connect, if set
+	MaxPeerBandwidth uint64                 // the inbound bytes per second allowed to each peer, 0 if unlimited
+	DNSDiscovery     []string               // the URLs of the DNS trees the peers are discovered from
+	ListenAddrs      []multiaddr.Multiaddr  // the additional listen addresses, such as QUIC or WebSocket ones
 }
 
 func DefaultConfig() *Config {
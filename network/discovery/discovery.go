@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -29,6 +30,13 @@ const (
 	// bootnodeDiscoveryInterval is the interval at which
 	// random bootnodes are dialed for their peer sets
 	bootnodeDiscoveryInterval = 60 * time.Second
+
+	// dnsDiscoveryInterval is the interval at which
+	// the DNS trees are resolved for their nodes
+	dnsDiscoveryInterval = 5 * time.Minute
+
+	// dnsResolveTimeout is the timeout of the resolution of a DNS tree
+	dnsResolveTimeout = 30 * time.Second
 )
 
 // networkingServer defines the base communication interface between
@@ -94,6 +102,10 @@ type DiscoveryService struct {
 	logger       hclog.Logger     // The DiscoveryService logger
 	routingTable *kb.RoutingTable // Kademlia 'k-bucket' routing table that contains connected nodes info
 
+	localID   peer.ID         // The ID of the local node, which is skipped in the DNS trees
+	dnsTrees  []string        // The URLs of the DNS trees the nodes are resolved from
+	dnsClient *dnsdisc.Client // The client resolving the DNS trees
+
 	closeCh chan struct{} // Channel used for stopping the DiscoveryService
 }
 
@@ -111,6 +123,15 @@ func NewDiscoveryService(
 	}
 }
 
+// EnableDNSDiscovery makes the discovery service periodically resolve the nodes
+// of the given DNS trees, and add them to the routing table.
+// It has to be called before the service is started
+func (d *DiscoveryService) EnableDNSDiscovery(localID peer.ID, trees []string, resolver dnsdisc.Resolver) {
+	d.localID = localID
+	d.dnsTrees = trees
+	d.dnsClient = dnsdisc.NewClient(resolver)
+}
+
 // Start starts the discovery service
 func (d *DiscoveryService) Start() {
 	go d.startDiscovery()
@@ -191,6 +212,10 @@ func (d *DiscoveryService) addToTable(node *peer.AddrInfo) error {
 
 // addPeersToTable adds the passed in peers to the peer store and the routing table
 func (d *DiscoveryService) addPeersToTable(nodeAddrStrs []string) {
+	// A peer is listed once per address, so its addresses are merged
+	nodes := make([]*peer.AddrInfo, 0, len(nodeAddrStrs))
+	nodesByID := make(map[peer.ID]*peer.AddrInfo, len(nodeAddrStrs))
+
 	for _, nodeAddrStr := range nodeAddrStrs {
 		// Convert the string address info to a working type
 		nodeInfo, err := common.StringToAddrInfo(nodeAddrStr)
@@ -204,6 +229,17 @@ func (d *DiscoveryService) addPeersToTable(nodeAddrStrs []string) {
 			continue
 		}
 
+		if node, ok := nodesByID[nodeInfo.ID]; ok {
+			node.Addrs = append(node.Addrs, nodeInfo.Addrs...)
+
+			continue
+		}
+
+		nodesByID[nodeInfo.ID] = nodeInfo
+		nodes = append(nodes, nodeInfo)
+	}
+
+	for _, nodeInfo := range nodes {
 		if err := d.addToTable(nodeInfo); err != nil {
 			d.logger.Error(
 				"Failed to add new peer to routing table",
@@ -268,12 +304,17 @@ func (d *DiscoveryService) findPeersCall(
 func (d *DiscoveryService) startDiscovery() {
 	peerDiscoveryTicker := time.NewTicker(peerDiscoveryInterval)
 	bootnodeDiscoveryTicker := time.NewTicker(bootnodeDiscoveryInterval)
+	dnsDiscoveryTicker := time.NewTicker(dnsDiscoveryInterval)
 
 	defer func() {
 		peerDiscoveryTicker.Stop()
 		bootnodeDiscoveryTicker.Stop()
+		dnsDiscoveryTicker.Stop()
 	}()
 
+	// The DNS trees are resolved right away, as they may be the only source of peers
+	go d.dnsPeerDiscovery()
+
 	for {
 		select {
 		case <-d.closeCh:
@@ -282,6 +323,8 @@ func (d *DiscoveryService) startDiscovery() {
 			go d.regularPeerDiscovery()
 		case <-bootnodeDiscoveryTicker.C:
 			go d.bootnodePeerDiscovery()
+		case <-dnsDiscoveryTicker.C:
+			go d.dnsPeerDiscovery()
 		}
 	}
 }
@@ -382,6 +425,40 @@ func (d *DiscoveryService) bootnodePeerDiscovery() {
 	d.addPeersToTable(foundNodes)
 }
 
+// dnsPeerDiscovery resolves the nodes of the DNS trees, and adds them to the routing table
+func (d *DiscoveryService) dnsPeerDiscovery() {
+	for _, tree := range d.dnsTrees {
+		ctx, cancelFn := context.WithTimeout(context.Background(), dnsResolveTimeout)
+		nodes, err := d.dnsClient.SyncTree(ctx, tree)
+
+		cancelFn()
+
+		if err != nil {
+			d.logger.Error("Unable to resolve DNS tree", "tree", tree, "err", err)
+
+			continue
+		}
+
+		d.logger.Debug("Resolved DNS tree", "tree", tree, "nodes", len(nodes))
+
+		for _, node := range nodes {
+			if node.ID == d.localID {
+				continue
+			}
+
+			if err := d.addToTable(node); err != nil {
+				d.logger.Error(
+					"Failed to add new peer to routing table",
+					"peer",
+					node.ID,
+					"err",
+					err,
+				)
+			}
+		}
+	}
+}
+
 // FindPeers implements the proto service for finding the target's peers
 func (d *DiscoveryService) FindPeers(
 	ctx context.Context,
@@ -424,12 +501,13 @@ func (d *DiscoveryService) FindPeers(
 		}
 
 		if info := d.baseServer.GetPeerInfo(id); len(info.Addrs) > 0 {
-			addr, err := common.AddrInfoToString(info)
+			// all the addresses are shared, as the peer may not be reachable over all transports
+			addrs, err := common.AddrInfoToStrings(info)
 			if err != nil {
 				return nil, err
 			}
 
-			filteredPeers = append(filteredPeers, addr)
+			filteredPeers = append(filteredPeers, addrs...)
 		}
 	}
 
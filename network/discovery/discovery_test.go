@@ -3,11 +3,14 @@ package discovery
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	networkTesting "github.com/0xPolygon/polygon-edge/network/testing"
 	"github.com/hashicorp/go-hclog"
@@ -301,3 +304,68 @@ func TestDiscoveryService_RegularPeerDiscoveryUnconnected(t *testing.T) {
 	// Make sure that no peers were added to the peer store
 	assert.Len(t, peerStore, 0)
 }
+
+// dnsResolver resolves the TXT records from a map
+type dnsResolver map[string]string
+
+func (r dnsResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	record, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("no such host %s", name)
+	}
+
+	return []string{record}, nil
+}
+
+// TestDiscoveryService_DNSPeerDiscovery makes sure the nodes of the DNS trees
+// are added to the peer store, except the local node
+func TestDiscoveryService_DNSPeerDiscovery(t *testing.T) {
+	randomPeers := getRandomPeers(t, 3)
+	peerStore := make(map[peer.ID]*peer.AddrInfo)
+
+	nodes := make([]string, 0, len(randomPeers))
+	for _, info := range randomPeers {
+		nodes = append(nodes, fmt.Sprintf("%s/p2p/%s", info.Addrs[0], info.ID))
+	}
+
+	key, err := crypto.GenerateECDSAKey()
+	if err != nil {
+		t.Fatalf("Unable to generate tree key, %v", err)
+	}
+
+	tree, err := dnsdisc.MakeTree(1, nodes, key)
+	if err != nil {
+		t.Fatalf("Unable to generate tree, %v", err)
+	}
+
+	domain := "nodes.example.org"
+
+	discoveryService, setupErr := newDiscoveryService(
+		func(server *networkTesting.MockNetworkingServer) {
+			// Define the peer store addition
+			server.HookAddToPeerStore(func(info *peer.AddrInfo) {
+				peerStore[info.ID] = info
+			})
+		},
+	)
+	if setupErr != nil {
+		t.Fatalf("Unable to setup the discovery service")
+	}
+
+	// The first node is the local one
+	discoveryService.EnableDNSDiscovery(
+		randomPeers[0].ID,
+		[]string{
+			dnsdisc.MakeURL(domain, &key.PublicKey),
+			dnsdisc.MakeURL("unknown.example.org", &key.PublicKey),
+		},
+		dnsResolver(tree.Records(domain)),
+	)
+
+	discoveryService.dnsPeerDiscovery()
+
+	assert.Len(t, peerStore, 2)
+	assert.NotContains(t, peerStore, randomPeers[0].ID)
+	assert.Equal(t, randomPeers[1:], []*peer.AddrInfo{peerStore[randomPeers[1].ID], peerStore[randomPeers[2].ID]})
+	assert.Equal(t, 2, discoveryService.RoutingTableSize())
+}
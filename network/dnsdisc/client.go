@@ -0,0 +1,176 @@
+package dnsdisc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// maxTreeEntries is the maximum number of entries resolved from a tree,
+	// so that a misconfigured tree doesn't resolve forever
+	maxTreeEntries = 10000
+)
+
+var (
+	ErrRootNotFound     = errors.New("tree root not found")
+	ErrSeqDecreased     = errors.New("tree sequence number decreased")
+	ErrHashMismatch     = errors.New("tree entry doesn't match its hash")
+	ErrTooManyEntries   = errors.New("too many tree entries")
+	ErrDuplicateEntries = errors.New("tree entry referenced more than once")
+)
+
+// Resolver looks up the TXT records of the DNS names. It is implemented by net.Resolver
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// resolvedTree is the last version of a tree which was resolved
+type resolvedTree struct {
+	root  *rootEntry
+	nodes []*peer.AddrInfo
+}
+
+// Client resolves the nodes of the trees published in DNS, and keeps the last
+// version of each tree, so that the trees are walked again only once they are updated
+type Client struct {
+	resolver Resolver
+
+	lock  sync.Mutex
+	trees map[string]*resolvedTree // the resolved trees, by URL
+}
+
+// NewClient creates a client resolving the trees with the given resolver,
+// or with the default resolver if it is nil
+func NewClient(resolver Resolver) *Client {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &Client{
+		resolver: resolver,
+		trees:    map[string]*resolvedTree{},
+	}
+}
+
+// SyncTree resolves the nodes of the tree with the given URL [Thread safe]
+func (c *Client) SyncTree(ctx context.Context, url string) ([]*peer.AddrInfo, error) {
+	domain, pubKey, err := ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := c.resolveRoot(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if !root.verifySignature(pubKey) {
+		return nil, ErrInvalidSignature
+	}
+
+	c.lock.Lock()
+	previous, ok := c.trees[url]
+	c.lock.Unlock()
+
+	if ok {
+		if root.seq < previous.root.seq {
+			return nil, fmt.Errorf("%w: %d < %d", ErrSeqDecreased, root.seq, previous.root.seq)
+		}
+
+		// the tree didn't change since it was last resolved
+		if root.nodesRoot == previous.root.nodesRoot {
+			return previous.nodes, nil
+		}
+	}
+
+	nodes, err := c.resolveNodes(ctx, domain, root.nodesRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.trees[url] = &resolvedTree{root: root, nodes: nodes}
+	c.lock.Unlock()
+
+	return nodes, nil
+}
+
+// resolveRoot resolves the root of the tree published under the domain
+func (c *Client) resolveRoot(ctx context.Context, domain string) (*rootEntry, error) {
+	records, err := c.resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if strings.HasPrefix(record, rootPrefix) {
+			return parseRoot(record)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrRootNotFound, domain)
+}
+
+// resolveNodes walks the subtree with the given root, and returns the nodes of its leaves
+func (c *Client) resolveNodes(ctx context.Context, domain, rootHash string) ([]*peer.AddrInfo, error) {
+	var (
+		nodes   []*peer.AddrInfo
+		pending = []string{rootHash}
+		visited = map[string]struct{}{}
+	)
+
+	for len(pending) > 0 {
+		hash := pending[0]
+		pending = pending[1:]
+
+		if _, ok := visited[hash]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateEntries, hash)
+		}
+
+		if len(visited) >= maxTreeEntries {
+			return nil, ErrTooManyEntries
+		}
+
+		visited[hash] = struct{}{}
+
+		text, err := c.resolveEntry(ctx, domain, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		children, node, err := parseEntry(text)
+		if err != nil {
+			return nil, err
+		}
+
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+
+		pending = append(pending, children...)
+	}
+
+	return nodes, nil
+}
+
+// resolveEntry resolves the entry of the tree with the given hash, and checks it matches the hash
+func (c *Client) resolveEntry(ctx context.Context, domain, hash string) (string, error) {
+	records, err := c.resolver.LookupTXT(ctx, hash+"."+domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if entryHash(record) == hash {
+			return record, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrHashMismatch, hash)
+}
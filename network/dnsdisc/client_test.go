@@ -0,0 +1,159 @@
+package dnsdisc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapResolver resolves the TXT records from a map
+type mapResolver map[string]string
+
+func (r mapResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	record, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("no such host %s", name)
+	}
+
+	return []string{record}, nil
+}
+
+// generateNodes returns random node multiaddrs
+func generateNodes(t *testing.T, count int) []string {
+	t.Helper()
+
+	nodes := make([]string, count)
+	for i := range nodes {
+		nodes[i] = tests.GenerateTestMultiAddr(t).String()
+	}
+
+	return nodes
+}
+
+func TestClient_SyncTree(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	domain := "nodes.example.org"
+	url := MakeURL(domain, &key.PublicKey)
+
+	// the nodes don't fit in a single branch
+	nodes := generateNodes(t, 2*maxChildren+1)
+
+	tree, err := MakeTree(1, nodes, key)
+	require.NoError(t, err)
+
+	resolver := mapResolver(tree.Records(domain))
+	client := NewClient(resolver)
+
+	resolved, err := client.SyncTree(context.Background(), url)
+	require.NoError(t, err)
+
+	resolvedNodes := make([]string, 0, len(resolved))
+	for _, node := range resolved {
+		resolvedNodes = append(resolvedNodes, fmt.Sprintf("%s/p2p/%s", node.Addrs[0], node.ID))
+	}
+
+	assert.ElementsMatch(t, nodes, resolvedNodes)
+
+	// the unchanged tree is not walked again
+	for name := range resolver {
+		if name != domain {
+			delete(resolver, name)
+		}
+	}
+
+	resolved, err = client.SyncTree(context.Background(), url)
+	require.NoError(t, err)
+	assert.Len(t, resolved, len(nodes))
+
+	// the updated tree is walked again
+	updated, err := MakeTree(2, nodes[:1], key)
+	require.NoError(t, err)
+
+	for name, record := range updated.Records(domain) {
+		resolver[name] = record
+	}
+
+	resolved, err = client.SyncTree(context.Background(), url)
+	require.NoError(t, err)
+	assert.Len(t, resolved, 1)
+
+	// the tree can't be rolled back
+	resolver[domain] = tree.Records(domain)[domain]
+
+	_, err = client.SyncTree(context.Background(), url)
+	assert.ErrorIs(t, err, ErrSeqDecreased)
+}
+
+func TestClient_SyncTreeDuplicateNodes(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	domain := "nodes.example.org"
+	nodes := generateNodes(t, 3)
+
+	// the duplicate nodes are published once
+	tree, err := MakeTree(1, append(nodes, nodes[0], nodes[2]), key)
+	require.NoError(t, err)
+
+	client := NewClient(mapResolver(tree.Records(domain)))
+
+	resolved, err := client.SyncTree(context.Background(), MakeURL(domain, &key.PublicKey))
+	require.NoError(t, err)
+	assert.Len(t, resolved, len(nodes))
+}
+
+func TestClient_SyncTreeInvalid(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	otherKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	domain := "nodes.example.org"
+	nodes := generateNodes(t, 3)
+
+	tree, err := MakeTree(1, nodes, key)
+	require.NoError(t, err)
+
+	// the tree has to be signed by the key of the URL
+	_, err = NewClient(mapResolver(tree.Records(domain))).SyncTree(
+		context.Background(),
+		MakeURL(domain, &otherKey.PublicKey),
+	)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// the entries have to match their hash
+	records := tree.Records(domain)
+
+	for name, record := range records {
+		if record == leafPrefix+nodes[0] {
+			records[name] = leafPrefix + nodes[1]
+		}
+	}
+
+	_, err = NewClient(mapResolver(records)).SyncTree(context.Background(), MakeURL(domain, &key.PublicKey))
+	assert.ErrorIs(t, err, ErrHashMismatch)
+
+	// the root has to be published
+	_, err = NewClient(mapResolver{domain: "v=spf1 -all"}).SyncTree(
+		context.Background(),
+		MakeURL(domain, &key.PublicKey),
+	)
+	assert.ErrorIs(t, err, ErrRootNotFound)
+
+	_, err = MakeTree(1, []string{"/ip4/127.0.0.1/tcp/1478"}, key)
+	assert.Error(t, err)
+}
@@ -0,0 +1,287 @@
+package dnsdisc
+
+import (
+	"crypto/ecdsa"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// The tree follows the structure of EIP-1459, with multiaddr leaves instead of ENRs:
+//
+//	<domain>         TXT  enrtree-root:v1 e=<nodes-root> l=<links-root> seq=<seq> sig=<sig>
+//	<hash>.<domain>  TXT  enrtree-branch:<hash>,<hash>,...
+//	<hash>.<domain>  TXT  maddr:/ip4/127.0.0.1/tcp/1478/p2p/<peer-id>
+//
+// Every entry is published under the hash of its content, so the root signature covers the whole tree
+const (
+	rootPrefix   = "enrtree-root:v1"
+	branchPrefix = "enrtree-branch:"
+	leafPrefix   = "maddr:"
+	urlScheme    = "enrtree://"
+
+	// maxChildren is the maximum number of children of a branch, for it to fit in a TXT record
+	maxChildren = 13
+
+	// hashAbbrevSize is the size of the hashes of the entries, which are used as DNS labels
+	hashAbbrevSize = 16
+)
+
+var (
+	b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+	b64 = base64.RawURLEncoding
+
+	ErrInvalidURL       = errors.New("invalid tree URL")
+	ErrInvalidRoot      = errors.New("invalid tree root")
+	ErrInvalidSignature = errors.New("invalid tree signature")
+	ErrInvalidEntry     = errors.New("invalid tree entry")
+)
+
+// rootEntry is the signed root of a tree
+type rootEntry struct {
+	nodesRoot string // the hash of the root of the nodes subtree
+	linksRoot string // the hash of the root of the links subtree, which are not followed
+	seq       uint64 // the sequence number of the tree, increased on every update
+	sig       []byte // the signature of the root by the key of the tree
+}
+
+// signedText returns the text of the root which is signed
+func (r *rootEntry) signedText() string {
+	return fmt.Sprintf("%s e=%s l=%s seq=%d", rootPrefix, r.nodesRoot, r.linksRoot, r.seq)
+}
+
+func (r *rootEntry) String() string {
+	return fmt.Sprintf("%s sig=%s", r.signedText(), b64.EncodeToString(r.sig))
+}
+
+// sigHash returns the hash of the root which is signed
+func (r *rootEntry) sigHash() []byte {
+	return crypto.Keccak256([]byte(r.signedText()))
+}
+
+// verifySignature checks that the root is signed by the key of the tree
+func (r *rootEntry) verifySignature(pubKey *ecdsa.PublicKey) bool {
+	signer, err := crypto.RecoverPubkey(r.sig, r.sigHash())
+	if err != nil {
+		return false
+	}
+
+	return signer.X.Cmp(pubKey.X) == 0 && signer.Y.Cmp(pubKey.Y) == 0
+}
+
+// parseRoot parses the root TXT record of a tree
+func parseRoot(text string) (*rootEntry, error) {
+	fields := strings.Fields(text)
+	if len(fields) != 5 || fields[0] != rootPrefix {
+		return nil, ErrInvalidRoot
+	}
+
+	values := make(map[string]string, len(fields)-1)
+
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, ErrInvalidRoot
+		}
+
+		values[key] = value
+	}
+
+	root := &rootEntry{
+		nodesRoot: values["e"],
+		linksRoot: values["l"],
+	}
+
+	if !isHash(root.nodesRoot) || !isHash(root.linksRoot) {
+		return nil, ErrInvalidRoot
+	}
+
+	var err error
+
+	if root.seq, err = strconv.ParseUint(values["seq"], 10, 64); err != nil {
+		return nil, ErrInvalidRoot
+	}
+
+	if root.sig, err = b64.DecodeString(values["sig"]); err != nil || len(root.sig) != 65 {
+		return nil, ErrInvalidSignature
+	}
+
+	return root, nil
+}
+
+// entryHash returns the hash an entry is published under
+func entryHash(text string) string {
+	return b32.EncodeToString(crypto.Keccak256([]byte(text))[:hashAbbrevSize])
+}
+
+// isHash checks if the DNS label is the hash of an entry
+func isHash(label string) bool {
+	raw, err := b32.DecodeString(label)
+
+	return err == nil && len(raw) == hashAbbrevSize
+}
+
+// parseEntry parses a TXT record of the nodes subtree, which is either
+// a branch, returning the hashes of its children, or a leaf, returning its node
+func parseEntry(text string) ([]string, *peer.AddrInfo, error) {
+	switch {
+	case strings.HasPrefix(text, branchPrefix):
+		children := strings.TrimPrefix(text, branchPrefix)
+		if children == "" {
+			return []string{}, nil, nil
+		}
+
+		hashes := strings.Split(children, ",")
+		for _, hash := range hashes {
+			if !isHash(hash) {
+				return nil, nil, fmt.Errorf("%w: invalid child hash %q", ErrInvalidEntry, hash)
+			}
+		}
+
+		return hashes, nil, nil
+	case strings.HasPrefix(text, leafPrefix):
+		node, err := common.StringToAddrInfo(strings.TrimPrefix(text, leafPrefix))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+		}
+
+		return nil, node, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown entry %q", ErrInvalidEntry, text)
+	}
+}
+
+// Tree is a signed tree of the multiaddrs of nodes, published as DNS TXT records
+type Tree struct {
+	root    *rootEntry
+	entries map[string]string // the entries of the tree, by hash
+}
+
+// MakeTree creates the tree of the given node multiaddrs, signed with the key
+func MakeTree(seq uint64, nodes []string, key *ecdsa.PrivateKey) (*Tree, error) {
+	tree := &Tree{entries: map[string]string{}}
+
+	// the leaves are sorted, so the same nodes always produce the same tree.
+	// The duplicate nodes are skipped, as the clients reject the duplicate entries
+	leaves := make([]string, 0, len(nodes))
+	added := make(map[string]struct{}, len(nodes))
+
+	for _, node := range nodes {
+		if _, err := common.StringToAddrInfo(node); err != nil {
+			return nil, fmt.Errorf("invalid node %s: %w", node, err)
+		}
+
+		if _, ok := added[node]; ok {
+			continue
+		}
+
+		added[node] = struct{}{}
+		leaves = append(leaves, leafPrefix+node)
+	}
+
+	sort.Strings(leaves)
+
+	hashes := make([]string, 0, len(leaves))
+	for _, leaf := range leaves {
+		hashes = append(hashes, tree.add(leaf))
+	}
+
+	tree.root = &rootEntry{
+		nodesRoot: tree.makeSubtree(hashes),
+		linksRoot: tree.add(branchPrefix),
+		seq:       seq,
+	}
+
+	sig, err := crypto.Sign(key, tree.root.sigHash())
+	if err != nil {
+		return nil, err
+	}
+
+	tree.root.sig = sig
+
+	return tree, nil
+}
+
+// add adds the entry to the tree, and returns its hash
+func (t *Tree) add(text string) string {
+	hash := entryHash(text)
+	t.entries[hash] = text
+
+	return hash
+}
+
+// makeSubtree adds the branches over the given entries, and returns the hash of their root
+func (t *Tree) makeSubtree(hashes []string) string {
+	for len(hashes) > maxChildren {
+		parents := make([]string, 0, (len(hashes)+maxChildren-1)/maxChildren)
+
+		for start := 0; start < len(hashes); start += maxChildren {
+			end := start + maxChildren
+			if end > len(hashes) {
+				end = len(hashes)
+			}
+
+			parents = append(parents, t.add(branchPrefix+strings.Join(hashes[start:end], ",")))
+		}
+
+		hashes = parents
+	}
+
+	return t.add(branchPrefix + strings.Join(hashes, ","))
+}
+
+// Seq returns the sequence number of the tree
+func (t *Tree) Seq() uint64 {
+	return t.root.seq
+}
+
+// Records returns the TXT records publishing the tree under the domain, by name
+func (t *Tree) Records(domain string) map[string]string {
+	records := map[string]string{
+		domain: t.root.String(),
+	}
+
+	for hash, text := range t.entries {
+		records[hash+"."+domain] = text
+	}
+
+	return records
+}
+
+// MakeURL returns the URL of the tree published under the domain, and signed with the key
+func MakeURL(domain string, pubKey *ecdsa.PublicKey) string {
+	return urlScheme + b32.EncodeToString((*btcec.PublicKey)(pubKey).SerializeCompressed()) + "@" + domain
+}
+
+// ParseURL parses the URL of a tree, returning its domain and its public key
+func ParseURL(url string) (string, *ecdsa.PublicKey, error) {
+	if !strings.HasPrefix(url, urlScheme) {
+		return "", nil, fmt.Errorf("%w: missing %s scheme", ErrInvalidURL, urlScheme)
+	}
+
+	rawKey, domain, ok := strings.Cut(strings.TrimPrefix(url, urlScheme), "@")
+	if !ok || domain == "" {
+		return "", nil, fmt.Errorf("%w: missing domain", ErrInvalidURL)
+	}
+
+	keyBytes, err := b32.DecodeString(rawKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: invalid public key", ErrInvalidURL)
+	}
+
+	pubKey, err := btcec.ParsePubKey(keyBytes, crypto.S256)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: invalid public key", ErrInvalidURL)
+	}
+
+	return domain, pubKey.ToECDSA(), nil
+}
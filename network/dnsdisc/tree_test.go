@@ -0,0 +1,33 @@
+package dnsdisc
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTree_URL(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	url := MakeURL("nodes.example.org", &key.PublicKey)
+
+	domain, pubKey, err := ParseURL(url)
+	require.NoError(t, err)
+	assert.Equal(t, "nodes.example.org", domain)
+	assert.True(t, key.PublicKey.Equal(pubKey))
+
+	for _, invalid := range []string{
+		"nodes.example.org",
+		"enrtree://nodes.example.org",
+		"enrtree://invalid@nodes.example.org",
+		"enrtree://AAAA@nodes.example.org",
+	} {
+		_, _, err := ParseURL(invalid)
+		assert.ErrorIs(t, err, ErrInvalidURL, invalid)
+	}
+}
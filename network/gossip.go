@@ -7,6 +7,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -21,14 +22,38 @@ const (
 	subscribeOutputBufferSize = 1024
 )
 
+// ValidationResult is the decision of a topic validator about a gossiped message
+type ValidationResult = pubsub.ValidationResult
+
+const (
+	// ValidationAccept delivers the message, and relays it to the other peers
+	ValidationAccept = pubsub.ValidationAccept
+	// ValidationIgnore drops the message without penalizing the peer which relayed it
+	ValidationIgnore = pubsub.ValidationIgnore
+	// ValidationReject drops the message, and penalizes the peer which relayed it
+	ValidationReject = pubsub.ValidationReject
+)
+
+// TopicValidator validates a decoded gossiped message, sent by the given peer,
+// before it is delivered to the subscribers and relayed to the other peers
+type TopicValidator func(obj interface{}, from peer.ID) ValidationResult
+
 type Topic struct {
 	logger hclog.Logger
 
+	ps        *pubsub.PubSub
 	topic     *pubsub.Topic
+	validated bool
 	typ       reflect.Type
 	closeCh   chan struct{}
 	closed    atomic.Bool
 	waitGroup sync.WaitGroup
+
+	// reportPeer penalizes the peers relaying malformed messages
+	reportPeer func(peerID peer.ID, penalty common.Penalty, reason string)
+
+	// allowPeer checks if a received message is within the bandwidth of the peer which relayed it
+	allowPeer func(peerID peer.ID, size int) bool
 }
 
 func (t *Topic) createObj() proto.Message {
@@ -51,6 +76,12 @@ func (t *Topic) Close() {
 
 	// if all subscribers are finished, close the topic
 	if t.topic != nil {
+		if t.validated {
+			if err := t.ps.UnregisterTopicValidator(t.topic.String()); err != nil {
+				t.logger.Error("failed to unregister topic validator", "err", err)
+			}
+		}
+
 		t.topic.Close()
 		t.topic = nil
 	}
@@ -107,12 +138,12 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 		}
 
 		go func() {
-			obj := t.createObj()
-			if err := proto.Unmarshal(msg.Data, obj); err != nil {
-				t.logger.Error("failed to unmarshal topic", "err", err)
-				metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
-
-				return
+			// the validated messages were already decoded by the topic validator
+			obj, ok := msg.ValidatorData.(proto.Message)
+			if !ok {
+				if obj, ok = t.decode(msg); !ok {
+					return
+				}
 			}
 
 			metrics.SetGauge([]string{networkMetrics, "ingress_bytes"}, float32(len(msg.Data)))
@@ -122,19 +153,92 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 	}
 }
 
-func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
+// decode unmarshals the gossiped message, and penalizes the peer which relayed it if it is malformed
+func (t *Topic) decode(msg *pubsub.Message) (proto.Message, bool) {
+	obj := t.createObj()
+	if err := proto.Unmarshal(msg.Data, obj); err != nil {
+		t.logger.Error("failed to unmarshal topic", "err", err)
+		metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
+		t.reportPeer(msg.ReceivedFrom, common.PenaltyMalformedMessage, "malformed gossip message")
+
+		return nil, false
+	}
+
+	return obj, true
+}
+
+// validate is the pubsub validator of the topic, which drops the received messages exceeding
+// the bandwidth of the relaying peer, decodes the others, and checks them with the topic validator,
+// if any, before they are delivered and relayed
+func (t *Topic) validate(
+	localID peer.ID,
+	validator TopicValidator,
+) func(context.Context, peer.ID, *pubsub.Message) ValidationResult {
+	return func(_ context.Context, pid peer.ID, msg *pubsub.Message) ValidationResult {
+		// the messages published by this node are trusted
+		if pid == localID {
+			return ValidationAccept
+		}
+
+		if !t.allowPeer(pid, msg.Size()) {
+			metrics.IncrCounter([]string{networkMetrics, "throttled_messages"}, float32(1))
+
+			return ValidationIgnore
+		}
+
+		obj, ok := t.decode(msg)
+		if !ok {
+			return ValidationReject
+		}
+
+		if validator == nil {
+			msg.ValidatorData = obj
+
+			return ValidationAccept
+		}
+
+		result := validator(obj, msg.GetFrom())
+
+		switch result {
+		case ValidationAccept:
+			msg.ValidatorData = obj
+		case ValidationReject:
+			metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
+			t.reportPeer(pid, common.PenaltyInvalidMessage, "invalid gossip message")
+		}
+
+		return result
+	}
+}
+
+// NewTopic joins the gossip topic of the given protocol, whose messages are of the type of obj.
+// If a validator is given, the received messages are delivered and relayed only once accepted by it.
+// The received messages are also validated when the bandwidth of the peers is limited
+func (s *Server) NewTopic(protoID string, obj proto.Message, validator TopicValidator) (*Topic, error) {
 	topic, err := s.ps.Join(protoID)
 	if err != nil {
 		return nil, err
 	}
 
 	tt := &Topic{
-		logger:  s.logger.Named(protoID),
-		topic:   topic,
-		typ:     reflect.TypeOf(obj).Elem(),
-		closeCh: make(chan struct{}),
+		logger:     s.logger.Named(protoID),
+		ps:         s.ps,
+		topic:      topic,
+		validated:  validator != nil || s.config.MaxPeerBandwidth > 0,
+		typ:        reflect.TypeOf(obj).Elem(),
+		closeCh:    make(chan struct{}),
+		reportPeer: s.ReportPeer,
+		allowPeer:  s.traffic.allowGossip,
 	}
 	tt.closed.Store(false)
 
+	if tt.validated {
+		if err := s.ps.RegisterTopicValidator(protoID, tt.validate(s.host.ID(), validator)); err != nil {
+			topic.Close()
+
+			return nil, err
+		}
+	}
+
 	return tt, nil
 }
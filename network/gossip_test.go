@@ -7,8 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	testproto "github.com/0xPolygon/polygon-edge/network/proto"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func NumSubscribers(srv *Server, topic string) int {
@@ -54,7 +57,7 @@ func TestSimpleGossip(t *testing.T) {
 	serverTopics := make([]*Topic, numServers)
 
 	for i := 0; i < numServers; i++ {
-		topic, topicErr := servers[i].NewTopic(topicName, &testproto.GenericMessage{})
+		topic, topicErr := servers[i].NewTopic(topicName, &testproto.GenericMessage{}, nil)
 		if topicErr != nil {
 			t.Fatalf("Unable to create topic, %v", topicErr)
 		}
@@ -108,6 +111,76 @@ func TestSimpleGossip(t *testing.T) {
 	}
 }
 
+func TestTopicValidator(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	topicName := "msg-validated"
+	messageCh := make(chan string, 10)
+
+	// Server 1 only accepts the valid messages
+	validator := func(obj interface{}, _ peer.ID) ValidationResult {
+		genericMessage, ok := obj.(*testproto.GenericMessage)
+		if !ok || genericMessage.Message != "valid" {
+			return ValidationReject
+		}
+
+		return ValidationAccept
+	}
+
+	publisherTopic, err := servers[0].NewTopic(topicName, &testproto.GenericMessage{}, nil)
+	require.NoError(t, err)
+
+	receiverTopic, err := servers[1].NewTopic(topicName, &testproto.GenericMessage{}, validator)
+	require.NoError(t, err)
+
+	require.NoError(t, publisherTopic.Subscribe(func(interface{}, peer.ID) {}))
+	require.NoError(t, receiverTopic.Subscribe(func(obj interface{}, _ peer.ID) {
+		genericMessage, ok := obj.(*testproto.GenericMessage)
+		if ok {
+			messageCh <- genericMessage.Message
+		}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, WaitForSubscribers(ctx, servers[0], topicName, 1))
+
+	// the messages are published until the valid one gets through the mesh
+	for received := false; !received; {
+		require.NoError(t, publisherTopic.Publish(&testproto.GenericMessage{Message: "invalid"}))
+		require.NoError(t, publisherTopic.Publish(&testproto.GenericMessage{Message: "valid"}))
+
+		select {
+		case message := <-messageCh:
+			assert.Equal(t, "valid", message)
+
+			received = true
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			t.Fatalf("Valid message not received before timeout")
+		}
+	}
+
+	// the peer which relayed the invalid message is penalized
+	assert.Eventually(t, func() bool {
+		score, _ := servers[1].GetPeerReputation(servers[0].host.ID())
+
+		return score <= -int64(common.PenaltyInvalidMessage)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func Test_RepeatedClose(t *testing.T) {
 	topic := &Topic{
 		closeCh: make(chan struct{}),
@@ -22,16 +22,30 @@ type GrpcStream struct {
 	streamCh chan network.Stream
 
 	grpcServer *grpc.Server
+
+	// traffic records the traffic of the served and the client connections
+	traffic *trafficHandler
 }
 
 func NewGrpcStream() *GrpcStream {
+	traffic := &trafficHandler{}
+
 	return &GrpcStream{
-		ctx:        context.Background(),
-		streamCh:   make(chan network.Stream),
-		grpcServer: grpc.NewServer(grpc.UnaryInterceptor(interceptor)),
+		ctx:      context.Background(),
+		streamCh: make(chan network.Stream),
+		grpcServer: grpc.NewServer(
+			grpc.UnaryInterceptor(interceptor),
+			grpc.StatsHandler(traffic),
+		),
+		traffic: traffic,
 	}
 }
 
+// SetTrafficRecorder sets the recorder of the messages exchanged over the protocol
+func (g *GrpcStream) SetTrafficRecorder(recorder TrafficRecorder) {
+	g.traffic.setRecorder(recorder)
+}
+
 type Context struct {
 	context.Context
 	PeerID peer.ID
@@ -75,7 +89,7 @@ func interceptor(
 }
 
 func (g *GrpcStream) Client(stream network.Stream) (*grpc.ClientConn, error) {
-	return WrapClient(stream)
+	return wrapClient(stream, g.traffic.getRecorder())
 }
 
 func (g *GrpcStream) Serve() {
@@ -125,11 +139,28 @@ func (g *GrpcStream) Close() error {
 // --- conn ---
 
 func WrapClient(s network.Stream) (*grpc.ClientConn, error) {
+	return wrapClient(s, nil)
+}
+
+// wrapClient creates a client connection over the stream,
+// whose traffic is recorded with the given recorder if any
+func wrapClient(s network.Stream, recorder TrafficRecorder) (*grpc.ClientConn, error) {
 	opts := grpc.WithContextDialer(func(ctx context.Context, peerIdStr string) (net.Conn, error) {
 		return &streamConn{s}, nil
 	})
 
-	return grpc.Dial("", grpc.WithTransportCredentials(insecure.NewCredentials()), opts)
+	traffic := &trafficHandler{
+		recorder: recorder,
+		peerID:   s.Conn().RemotePeer(),
+		protocol: string(s.Protocol()),
+	}
+
+	return grpc.Dial(
+		"",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(traffic),
+		opts,
+	)
 }
 
 // streamConn represents a net.Conn wrapped to be compatible with net.conn
@@ -138,7 +169,8 @@ type streamConn struct {
 }
 
 type wrapLibp2pAddr struct {
-	id peer.ID
+	id       peer.ID
+	protocol string
 	net.Addr
 }
 
@@ -146,7 +178,8 @@ type wrapLibp2pAddr struct {
 func (c *streamConn) LocalAddr() net.Addr {
 	addr, err := manet.ToNetAddr(c.Stream.Conn().LocalMultiaddr())
 	if err != nil {
-		return fakeRemoteAddr()
+		// the multiaddrs of some transports, such as QUIC, have no net.Addr counterpart
+		addr = fakeLocalAddr()
 	}
 
 	return &wrapLibp2pAddr{Addr: addr, id: c.Stream.Conn().LocalPeer()}
@@ -156,10 +189,11 @@ func (c *streamConn) LocalAddr() net.Addr {
 func (c *streamConn) RemoteAddr() net.Addr {
 	addr, err := manet.ToNetAddr(c.Stream.Conn().RemoteMultiaddr())
 	if err != nil {
-		return fakeRemoteAddr()
+		// the peer ID is kept, as the handlers rely on it
+		addr = fakeRemoteAddr()
 	}
 
-	return &wrapLibp2pAddr{Addr: addr, id: c.Stream.Conn().RemotePeer()}
+	return &wrapLibp2pAddr{Addr: addr, id: c.Stream.Conn().RemotePeer(), protocol: string(c.Stream.Protocol())}
 }
 
 var _ net.Conn = &streamConn{}
@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	grpcPeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+)
+
+// TrafficRecorder records the messages exchanged with the peers over the gRPC protocols
+type TrafficRecorder interface {
+	// RecordInbound records a message received from the peer.
+	// It may block while the peer exceeds its bandwidth limit
+	RecordInbound(peerID peer.ID, protocol string, size int)
+
+	// RecordOutbound records a message sent to the peer
+	RecordOutbound(peerID peer.ID, protocol string, size int)
+}
+
+// trafficHandler is the gRPC stats handler recording the payloads exchanged
+// over a libp2p stream with the traffic recorder
+type trafficHandler struct {
+	lock     sync.RWMutex
+	recorder TrafficRecorder
+
+	// peerID and protocol are set for the client connections, which are bound to a single stream.
+	// The server connections take them from the address of the peer
+	peerID   peer.ID
+	protocol string
+}
+
+func (h *trafficHandler) setRecorder(recorder TrafficRecorder) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.recorder = recorder
+}
+
+func (h *trafficHandler) getRecorder() TrafficRecorder {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.recorder
+}
+
+// streamPeer returns the peer and the protocol of the stream the RPC is made over
+func (h *trafficHandler) streamPeer(ctx context.Context) (peer.ID, string, bool) {
+	if h.peerID != "" {
+		return h.peerID, h.protocol, true
+	}
+
+	contextPeer, ok := grpcPeer.FromContext(ctx)
+	if !ok {
+		return "", "", false
+	}
+
+	addr, ok := contextPeer.Addr.(*wrapLibp2pAddr)
+	if !ok {
+		return "", "", false
+	}
+
+	return addr.id, addr.protocol, true
+}
+
+// HandleRPC implements the stats.Handler interface
+func (h *trafficHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	recorder := h.getRecorder()
+	if recorder == nil {
+		return
+	}
+
+	switch payload := s.(type) {
+	case *stats.InPayload:
+		if peerID, protocol, ok := h.streamPeer(ctx); ok {
+			recorder.RecordInbound(peerID, protocol, payload.WireLength)
+		}
+	case *stats.OutPayload:
+		if peerID, protocol, ok := h.streamPeer(ctx); ok {
+			recorder.RecordOutbound(peerID, protocol, payload.WireLength)
+		}
+	}
+}
+
+// TagRPC implements the stats.Handler interface
+func (h *trafficHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// TagConn implements the stats.Handler interface
+func (h *trafficHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements the stats.Handler interface
+func (h *trafficHandler) HandleConn(context.Context, stats.ConnStats) {}
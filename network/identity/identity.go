@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/hashicorp/go-hclog"
 
@@ -19,6 +20,8 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrPeerNotAllowed   = errors.New("peer not allowed")
+	ErrPeerBanned       = errors.New("peer banned")
 )
 
 // networkingServer defines the base communication interface between
@@ -52,6 +55,20 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// IsTrustedPeer checks if the peer is trusted, and so exempt from the connection limits [Thread safe]
+	IsTrustedPeer(peerID peer.ID) bool
+
+	// IsPeerAllowed checks if the peer is allowed to connect [Thread safe]
+	IsPeerAllowed(peerID peer.ID) bool
+
+	// REPUTATION //
+
+	// IsPeerBanned checks if the peer is banned for misbehaving [Thread safe]
+	IsPeerBanned(peerID peer.ID) bool
+
+	// ReportPeer penalizes the peer for a misbehavior [Thread safe]
+	ReportPeer(peerID peer.ID, penalty common.Penalty, reason string)
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -93,7 +110,7 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) && !i.baseServer.IsTrustedPeer(peerID) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
@@ -106,6 +123,11 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				eventType := event.PeerDialCompleted
 
 				if err := i.handleConnected(peerID, conn.Stat().Direction); err != nil {
+					// Peers on a different chain keep failing the handshake
+					if errors.Is(err, ErrInvalidChainID) {
+						i.baseServer.ReportPeer(peerID, common.PenaltyFailedHandshake, err.Error())
+					}
+
 					// Close the connection to the peer
 					i.disconnectFromPeer(peerID, err.Error())
 
@@ -160,6 +182,14 @@ func (i *IdentityService) disconnectFromPeer(peerID peer.ID, reason string) {
 
 // handleConnected handles new network connections (handshakes)
 func (i *IdentityService) handleConnected(peerID peer.ID, direction network.Direction) error {
+	if i.baseServer.IsPeerBanned(peerID) {
+		return ErrPeerBanned
+	}
+
+	if !i.baseServer.IsPeerAllowed(peerID) {
+		return ErrPeerNotAllowed
+	}
+
 	clt, clientErr := i.baseServer.NewIdentityClient(peerID)
 	if clientErr != nil {
 		return fmt.Errorf(
@@ -200,6 +230,14 @@ func (i *IdentityService) Hello(_ context.Context, req *proto.Status) (*proto.St
 		return nil, err
 	}
 
+	if i.baseServer.IsPeerBanned(peerID) {
+		return nil, ErrPeerBanned
+	}
+
+	if !i.baseServer.IsPeerAllowed(peerID) {
+		return nil, ErrPeerNotAllowed
+	}
+
 	return i.constructStatus(peerID), nil
 }
 
@@ -122,3 +122,67 @@ func TestHandshake_Errors(t *testing.T) {
 	// Make sure no peers have been  added to the base networking server
 	assert.Len(t, peersArray, 0)
 }
+
+// TestHandshake_PeerNotAllowed tests that the peers not allowed to connect are rejected
+func TestHandshake_PeerNotAllowed(t *testing.T) {
+	peersArray := make([]peer.ID, 0)
+
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookIsPeerAllowed(func(id peer.ID) bool {
+				return id == "AllowedPeer"
+			})
+
+			server.HookAddPeer(func(id peer.ID, direction network.Direction) {
+				peersArray = append(peersArray, id)
+			})
+
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				return &proto.Status{}, nil
+			})
+		},
+	)
+
+	assert.ErrorIs(t, identityService.handleConnected("OtherPeer", network.DirInbound), ErrPeerNotAllowed)
+	assert.NoError(t, identityService.handleConnected("AllowedPeer", network.DirInbound))
+
+	assert.Equal(t, []peer.ID{"AllowedPeer"}, peersArray)
+}
+
+func TestHandshake_PeerBanned(t *testing.T) {
+	bannedPeer := "16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW"
+
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookIsPeerBanned(func(id peer.ID) bool {
+				return id.String() == bannedPeer
+			})
+
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				return &proto.Status{}, nil
+			})
+		},
+	)
+
+	bannedID, err := peer.Decode(bannedPeer)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, identityService.handleConnected(bannedID, network.DirInbound), ErrPeerBanned)
+	assert.NoError(t, identityService.handleConnected("OtherPeer", network.DirInbound))
+
+	// banned peers are rejected on both sides of the handshake
+	_, err = identityService.Hello(context.Background(), &proto.Status{
+		Metadata: map[string]string{
+			PeerID: bannedPeer,
+		},
+	})
+	assert.ErrorIs(t, err, ErrPeerBanned)
+}
@@ -0,0 +1,163 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// allowlistReloadInterval is the interval at which the allowlist file is checked for changes
+var allowlistReloadInterval = 10 * time.Second
+
+// peerAllowlist is the list of the peers allowed to connect to the node,
+// read from a file which is reloaded whenever it changes.
+//
+// The file holds one peer per line, as peer ID or p2p multiaddr.
+// Empty lines and lines starting with '#' are ignored.
+type peerAllowlist struct {
+	path string
+
+	lock    sync.RWMutex
+	peers   map[peer.ID]struct{}
+	modTime time.Time
+}
+
+// newPeerAllowlist creates the allowlist of the given file, and loads it
+func newPeerAllowlist(path string) (*peerAllowlist, error) {
+	a := &peerAllowlist{
+		path:  path,
+		peers: map[peer.ID]struct{}{},
+	}
+
+	if _, err := a.reload(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// contains checks if the peer is in the allowlist [Thread safe]
+func (a *peerAllowlist) contains(peerID peer.ID) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	_, ok := a.peers[peerID]
+
+	return ok
+}
+
+// reload reads the allowlist file again if it changed since the last read,
+// and returns true if it did
+func (a *peerAllowlist) reload() (bool, error) {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return false, fmt.Errorf("unable to read peer allowlist: %w", err)
+	}
+
+	a.lock.RLock()
+	unchanged := info.ModTime().Equal(a.modTime)
+	a.lock.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	raw, err := os.ReadFile(a.path)
+	if err != nil {
+		return false, fmt.Errorf("unable to read peer allowlist: %w", err)
+	}
+
+	peers, err := parsePeerAllowlist(raw)
+	if err != nil {
+		return false, err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.peers = peers
+	a.modTime = info.ModTime()
+
+	return true, nil
+}
+
+// parsePeerAllowlist parses the content of an allowlist file
+func parsePeerAllowlist(raw []byte) (map[peer.ID]struct{}, error) {
+	peers := map[peer.ID]struct{}{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		peerID, err := parsePeerEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer allowlist entry at line %d: %w", line, err)
+		}
+
+		peers[peerID] = struct{}{}
+	}
+
+	return peers, scanner.Err()
+}
+
+// parsePeerEntry returns the ID of the peer, given either as ID or as p2p multiaddr
+func parsePeerEntry(entry string) (peer.ID, error) {
+	if strings.HasPrefix(entry, "/") {
+		addrInfo, err := common.StringToAddrInfo(entry)
+		if err != nil {
+			return "", err
+		}
+
+		return addrInfo.ID, nil
+	}
+
+	return peer.Decode(entry)
+}
+
+// runAllowlistReload reloads the allowlist whenever it changes,
+// and disconnects from the peers which are no longer allowed
+func (s *Server) runAllowlistReload() {
+	for {
+		select {
+		case <-time.After(allowlistReloadInterval):
+		case <-s.closeCh:
+			return
+		}
+
+		reloaded, err := s.allowlist.reload()
+		if err != nil {
+			s.logger.Error("failed to reload the peer allowlist, keeping the previous one", "err", err)
+
+			continue
+		}
+
+		if !reloaded {
+			continue
+		}
+
+		s.logger.Info("peer allowlist reloaded")
+
+		for _, p := range s.Peers() {
+			if !s.IsPeerAllowed(p.Info.ID) {
+				s.DisconnectFromPeer(p.Info.ID, "peer removed from the allowlist")
+			}
+		}
+	}
+}
+
+// IsPeerAllowed checks if the peer is allowed to connect, which is the case of
+// every peer unless the allowlist mode is enabled. In this mode, only the peers of
+// the allowlist, and the static and trusted peers, are allowed [Thread safe]
+func (s *Server) IsPeerAllowed(peerID peer.ID) bool {
+	return s.allowlist == nil || s.IsTrustedPeer(peerID) || s.allowlist.contains(peerID)
+}
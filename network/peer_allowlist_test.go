@@ -0,0 +1,82 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	allowedPeer1 = "16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW"
+	allowedPeer2 = "16Uiu2HAmS9Nq4QAaEiogE4ieJFUYsoH28magT7wSvJPpfUGBj3Hq"
+)
+
+func TestParsePeerAllowlist(t *testing.T) {
+	t.Parallel()
+
+	peers, err := parsePeerAllowlist([]byte(
+		"# validators\n" +
+			allowedPeer1 + "\n" +
+			"\n" +
+			"  /ip4/127.0.0.1/tcp/10001/p2p/" + allowedPeer2 + "  \n",
+	))
+	require.NoError(t, err)
+
+	id1, _ := peer.Decode(allowedPeer1)
+	id2, _ := peer.Decode(allowedPeer2)
+
+	assert.Equal(t, map[peer.ID]struct{}{id1: {}, id2: {}}, peers)
+
+	_, err = parsePeerAllowlist([]byte(allowedPeer1 + "\ninvalid"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = parsePeerAllowlist([]byte("/ip4/127.0.0.1/tcp/10001"))
+	assert.Error(t, err)
+}
+
+func TestPeerAllowlist_Reload(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "allowlist")
+	require.NoError(t, os.WriteFile(path, []byte(allowedPeer1), 0600))
+
+	id1, _ := peer.Decode(allowedPeer1)
+	id2, _ := peer.Decode(allowedPeer2)
+
+	allowlist, err := newPeerAllowlist(path)
+	require.NoError(t, err)
+
+	assert.True(t, allowlist.contains(id1))
+	assert.False(t, allowlist.contains(id2))
+
+	// the file is not read again if it didn't change
+	reloaded, err := allowlist.reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	require.NoError(t, os.WriteFile(path, []byte(allowedPeer2), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+	reloaded, err = allowlist.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+
+	assert.False(t, allowlist.contains(id1))
+	assert.True(t, allowlist.contains(id2))
+
+	// an invalid file keeps the previous allowlist
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+
+	_, err = allowlist.reload()
+	assert.Error(t, err)
+	assert.True(t, allowlist.contains(id2))
+
+	_, err = newPeerAllowlist(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,264 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// banThreshold is the score at which a peer gets banned
+	banThreshold = -100
+
+	// scoreHalfLife is the time it takes for a peer to recover half of its score
+	scoreHalfLife = 10 * time.Minute
+
+	// minBanDuration is the duration of the first ban of a peer
+	minBanDuration = 10 * time.Minute
+
+	// maxBanDuration is the maximum duration of a ban, and the time after which
+	// a peer whose ban expired is forgotten
+	maxBanDuration = 24 * time.Hour
+
+	// maxTrackedScores is the number of scores above which the recovered ones are forgotten
+	maxTrackedScores = 1024
+
+	// bansFileName is the name of the file the bans are persisted to, in the data directory
+	bansFileName = "bans.json"
+)
+
+// peerScore is the score of a peer at the time of its last update
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+// peerBan is the ban of a misbehaving peer
+type peerBan struct {
+	Until time.Time `json:"until"` // the time the ban expires
+	Count uint64    `json:"count"` // the number of times the peer was banned
+}
+
+// reputation keeps track of the scores of the misbehaving peers, which recover exponentially
+// over time, and bans the peers whose score drops to the ban threshold.
+//
+// Every ban of a peer lasts twice as long as the previous one, until the peer is forgotten
+// after behaving for maxBanDuration since its last ban expired
+type reputation struct {
+	path string // the file the bans are persisted to, empty if they are not persisted
+
+	lock   sync.Mutex
+	scores map[peer.ID]*peerScore
+	bans   map[peer.ID]*peerBan
+
+	now func() time.Time
+}
+
+// newReputation creates the reputation tracker, loading the bans persisted to the given file
+func newReputation(path string) (*reputation, error) {
+	r := &reputation{
+		path:   path,
+		scores: map[peer.ID]*peerScore{},
+		bans:   map[peer.ID]*peerBan{},
+		now:    time.Now,
+	}
+
+	if path == "" {
+		return r, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read peer bans: %w", err)
+	}
+
+	// the bans are persisted by encoded peer ID
+	var bans map[string]*peerBan
+	if err := json.Unmarshal(raw, &bans); err != nil {
+		return nil, fmt.Errorf("unable to parse peer bans: %w", err)
+	}
+
+	for rawID, ban := range bans {
+		peerID, err := peer.Decode(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse peer bans: %w", err)
+		}
+
+		r.bans[peerID] = ban
+	}
+
+	return r, nil
+}
+
+// penalize lowers the score of the peer, and bans it if the score drops to the ban threshold
+// and the peer can be banned. It returns the time the ban expires, if the peer got banned [Thread safe]
+func (r *reputation) penalize(peerID peer.ID, penalty common.Penalty, bannable bool) (time.Time, bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	score := r.score(peerID, now) - float64(penalty)
+
+	// the score is rounded, to ban the peers whose score is at the threshold despite recovering a bit
+	if !bannable || math.Round(score) > banThreshold {
+		r.scores[peerID] = &peerScore{value: score, updated: now}
+		r.pruneScores(now)
+
+		return time.Time{}, false, nil
+	}
+
+	delete(r.scores, peerID)
+
+	ban, ok := r.bans[peerID]
+	if !ok || r.isForgotten(ban, now) {
+		ban = &peerBan{}
+		r.bans[peerID] = ban
+	}
+
+	ban.Count++
+	ban.Until = now.Add(banDuration(ban.Count))
+
+	return ban.Until, true, r.persist(now)
+}
+
+// status returns the score of the peer, and the time its ban expires if it is banned [Thread safe]
+func (r *reputation) status(peerID peer.ID) (int64, time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	score := int64(math.Round(r.score(peerID, now)))
+
+	if ban, ok := r.bans[peerID]; ok && now.Before(ban.Until) {
+		return score, ban.Until
+	}
+
+	return score, time.Time{}
+}
+
+// isBanned checks if the peer is currently banned [Thread safe]
+func (r *reputation) isBanned(peerID peer.ID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ban, ok := r.bans[peerID]
+
+	return ok && r.now().Before(ban.Until)
+}
+
+// score returns the score of the peer at the given time
+func (r *reputation) score(peerID peer.ID, now time.Time) float64 {
+	score, ok := r.scores[peerID]
+	if !ok {
+		return 0
+	}
+
+	return score.value * math.Pow(0.5, float64(now.Sub(score.updated))/float64(scoreHalfLife))
+}
+
+// pruneScores forgets the scores which almost fully recovered, once too many are tracked
+func (r *reputation) pruneScores(now time.Time) {
+	if len(r.scores) <= maxTrackedScores {
+		return
+	}
+
+	for peerID := range r.scores {
+		if r.score(peerID, now) > -1 {
+			delete(r.scores, peerID)
+		}
+	}
+}
+
+// isForgotten checks if the peer behaved long enough since its ban expired to be forgotten
+func (r *reputation) isForgotten(ban *peerBan, now time.Time) bool {
+	return now.After(ban.Until.Add(maxBanDuration))
+}
+
+// persist writes the bans which are not forgotten yet to the bans file
+func (r *reputation) persist(now time.Time) error {
+	if r.path == "" {
+		return nil
+	}
+
+	bans := make(map[string]*peerBan, len(r.bans))
+
+	for peerID, ban := range r.bans {
+		if r.isForgotten(ban, now) {
+			delete(r.bans, peerID)
+
+			continue
+		}
+
+		bans[peerID.String()] = ban
+	}
+
+	raw, err := json.Marshal(bans)
+	if err != nil {
+		return err
+	}
+
+	return helperCommon.SaveFileSafe(r.path, raw, 0660)
+}
+
+// banDuration returns the duration of the n-th ban of a peer
+func banDuration(count uint64) time.Duration {
+	duration := minBanDuration
+	for i := uint64(1); i < count && duration < maxBanDuration; i++ {
+		duration *= 2
+	}
+
+	if duration > maxBanDuration {
+		return maxBanDuration
+	}
+
+	return duration
+}
+
+// ReportPeer penalizes the peer for a misbehavior, and disconnects from it
+// if it gets banned. Trusted peers are penalized, but never banned [Thread safe]
+func (s *Server) ReportPeer(peerID peer.ID, penalty common.Penalty, reason string) {
+	if peerID == s.host.ID() {
+		return
+	}
+
+	metrics.IncrCounter([]string{networkMetrics, "peer_penalties"}, 1)
+
+	until, banned, err := s.reputation.penalize(peerID, penalty, !s.IsTrustedPeer(peerID))
+	if err != nil {
+		s.logger.Error("failed to persist the peer bans", "err", err)
+	}
+
+	if !banned {
+		s.logger.Debug("peer penalized", "id", peerID, "penalty", penalty, "reason", reason)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{networkMetrics, "peer_bans"}, 1)
+	s.logger.Warn("peer banned", "id", peerID, "until", until, "reason", reason)
+
+	s.DisconnectFromPeer(peerID, fmt.Sprintf("banned for %s", reason))
+}
+
+// IsPeerBanned checks if the peer is banned for misbehaving.
+// Trusted peers are never considered banned [Thread safe]
+func (s *Server) IsPeerBanned(peerID peer.ID) bool {
+	return !s.IsTrustedPeer(peerID) && s.reputation.isBanned(peerID)
+}
+
+// GetPeerReputation returns the score of the peer, and the time its ban expires
+// if it is banned [Thread safe]
+func (s *Server) GetPeerReputation(peerID peer.ID) (int64, time.Time) {
+	return s.reputation.status(peerID)
+}
@@ -0,0 +1,176 @@
+package network
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputation_ScoreDecay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	r, err := newReputation("")
+	require.NoError(t, err)
+
+	r.now = func() time.Time { return now }
+
+	_, banned, err := r.penalize("A", common.PenaltyInvalidBlock, true)
+	require.NoError(t, err)
+	assert.False(t, banned)
+
+	score, bannedUntil := r.status("A")
+	assert.Equal(t, int64(-50), score)
+	assert.True(t, bannedUntil.IsZero())
+
+	// half of the score is recovered after the half-life
+	now = now.Add(scoreHalfLife)
+
+	score, _ = r.status("A")
+	assert.Equal(t, int64(-25), score)
+
+	score, _ = r.status("B")
+	assert.Equal(t, int64(0), score)
+}
+
+func TestReputation_Ban(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	path := filepath.Join(t.TempDir(), bansFileName)
+
+	// the bans are persisted, so the peer IDs have to be valid
+	trustedPeer, err := peer.Decode(allowedPeer1)
+	require.NoError(t, err)
+
+	bannedPeer, err := peer.Decode(allowedPeer2)
+	require.NoError(t, err)
+
+	r, err := newReputation(path)
+	require.NoError(t, err)
+
+	r.now = func() time.Time { return now }
+
+	// peers which can't be banned only lose score
+	for i := 0; i < 3; i++ {
+		_, banned, err := r.penalize(trustedPeer, common.PenaltyInvalidBlock, false)
+		require.NoError(t, err)
+		assert.False(t, banned)
+	}
+
+	assert.False(t, r.isBanned(trustedPeer))
+
+	ban := func() time.Time {
+		t.Helper()
+
+		_, banned, err := r.penalize(bannedPeer, common.PenaltyInvalidBlock, true)
+		require.NoError(t, err)
+		assert.False(t, banned)
+
+		until, banned, err := r.penalize(bannedPeer, common.PenaltyInvalidBlock, true)
+		require.NoError(t, err)
+		assert.True(t, banned)
+
+		return until
+	}
+
+	assert.Equal(t, now.Add(minBanDuration), ban())
+	assert.True(t, r.isBanned(bannedPeer))
+
+	// the score is reset by the ban
+	score, bannedUntil := r.status(bannedPeer)
+	assert.Equal(t, int64(0), score)
+	assert.Equal(t, now.Add(minBanDuration), bannedUntil)
+
+	// the bans are persisted
+	persisted, err := newReputation(path)
+	require.NoError(t, err)
+
+	persisted.now = r.now
+	assert.True(t, persisted.isBanned(bannedPeer))
+	assert.False(t, persisted.isBanned(trustedPeer))
+
+	// the ban expires, and the following one lasts twice as long
+	now = now.Add(minBanDuration)
+	assert.False(t, r.isBanned(bannedPeer))
+
+	assert.Equal(t, now.Add(2*minBanDuration), ban())
+
+	// the peer is forgotten once it behaved long enough after its ban
+	now = now.Add(2*minBanDuration + maxBanDuration + time.Second)
+
+	assert.Equal(t, now.Add(minBanDuration), ban())
+}
+
+func TestBanDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, minBanDuration, banDuration(1))
+	assert.Equal(t, 4*minBanDuration, banDuration(3))
+	assert.Equal(t, maxBanDuration, banDuration(100))
+}
+
+func TestReportPeer(t *testing.T) {
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+			},
+		},
+		1: {
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+			},
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	peerID := servers[1].host.ID()
+
+	// Server 0 bans Server 1 for sending invalid blocks, and disconnects from it
+	servers[0].ReportPeer(peerID, common.PenaltyInvalidBlock, "invalid block")
+	assert.False(t, servers[0].IsPeerBanned(peerID))
+
+	servers[0].ReportPeer(peerID, common.PenaltyInvalidBlock, "invalid block")
+	assert.True(t, servers[0].IsPeerBanned(peerID))
+
+	disconnectCtx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	_, err := WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], peerID)
+	require.NoError(t, err)
+
+	// Server 1 has to notice the disconnection as well, before trying to connect again
+	_, err = WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[1], servers[0].host.ID())
+	require.NoError(t, err)
+
+	// Server 1 can't connect again while banned
+	smallTimeout := time.Second * 5
+	if joinErr := JoinAndWait(servers[1], servers[0], smallTimeout, smallTimeout); joinErr == nil {
+		t.Fatalf("Banned peer should not be able to connect")
+	}
+
+	score, bannedUntil := servers[0].GetPeerReputation(peerID)
+	assert.Equal(t, int64(0), score)
+	assert.False(t, bannedUntil.IsZero())
+
+	_, bannedUntil = servers[0].GetPeerReputation(peer.ID("unknown"))
+	assert.True(t, bannedUntil.IsZero())
+}
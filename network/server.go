@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/dial"
 	"github.com/0xPolygon/polygon-edge/network/discovery"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/armon/go-metrics"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
@@ -51,6 +54,9 @@ const (
 
 	MinimumBootNodes       int   = 1
 	MinimumPeerConnections int64 = 1
+
+	// staticPeerDialTimeout is the timeout of the connection attempts to the static peers
+	staticPeerDialTimeout = 30 * time.Second
 )
 
 var (
@@ -88,6 +94,16 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	trustedPeers sync.Map // set of the peers exempt from the connection limits; peerID -> struct{}
+
+	staticPeers []*peer.AddrInfo // the peers always (re)connected to
+
+	allowlist *peerAllowlist // the peers allowed to connect, nil if every peer is
+
+	reputation *reputation // the scores and bans of the misbehaving peers
+
+	traffic *traffic // the traffic exchanged with the peers, and their bandwidth limits
 }
 
 // NewServer returns a new instance of the networking server
@@ -105,23 +121,15 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 	}
 
 	addrsFactory := func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
-		if config.NatAddr != nil {
-			addr, _ := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", config.NatAddr.String(), config.Addr.Port))
-
-			if addr != nil {
-				addrs = []multiaddr.Multiaddr{addr}
-			}
-		} else if config.DNS != nil {
-			addrs = []multiaddr.Multiaddr{config.DNS}
-		}
-
-		return addrs
+		return advertisedAddrs(config, addrs)
 	}
 
 	host, err := libp2p.New(
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
-		libp2p.ListenAddrs(listenAddr),
+		// Listen on TCP, and on QUIC and WebSocket if additional addresses are set
+		transports,
+		libp2p.ListenAddrs(append([]multiaddr.Multiaddr{listenAddr}, config.ListenAddrs...)...),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
 	)
@@ -154,6 +162,44 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		traffic: newTraffic(host.ID(), int(config.MaxPeerBandwidth), func(peerID peer.ID) bool {
+			return host.Network().Connectedness(peerID) == network.Connected
+		}),
+	}
+
+	for _, peerID := range config.TrustedPeers {
+		srv.trustedPeers.Store(peerID, struct{}{})
+	}
+
+	// static peers are trusted, so that they don't compete for the connection slots
+	for _, peerInfo := range config.StaticPeers {
+		if peerInfo.ID == host.ID() {
+			continue
+		}
+
+		srv.staticPeers = append(srv.staticPeers, peerInfo)
+		srv.trustedPeers.Store(peerInfo.ID, struct{}{})
+	}
+
+	bansPath := ""
+	if config.DataDir != "" {
+		bansPath = filepath.Join(config.DataDir, bansFileName)
+	}
+
+	if srv.reputation, err = newReputation(bansPath); err != nil {
+		return nil, err
+	}
+
+	for _, tree := range config.DNSDiscovery {
+		if _, _, err := dnsdisc.ParseURL(tree); err != nil {
+			return nil, fmt.Errorf("failed to parse DNS tree %s: %w", tree, err)
+		}
+	}
+
+	if config.PeerAllowlist != "" {
+		if srv.allowlist, err = newPeerAllowlist(config.PeerAllowlist); err != nil {
+			return nil, err
+		}
 	}
 
 	// start gossip protocol
@@ -161,6 +207,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		context.Background(),
 		host, pubsub.WithPeerOutboundQueueSize(peerOutboundBufferSize),
 		pubsub.WithValidateQueueSize(validateBufferSize),
+		pubsub.WithRawTracer(&trafficTracer{traffic: srv.traffic}),
 	)
 	if err != nil {
 		return nil, err
@@ -182,6 +229,10 @@ type PeerConnInfo struct {
 
 	connDirections  map[network.Direction]bool
 	protocolStreams map[string]*rawGrpc.ClientConn
+
+	// trusted is set if the peer was trusted when connected,
+	// in which case its connections are not counted against the limits
+	trusted bool
 }
 
 // addProtocolStream adds a protocol stream
@@ -269,6 +320,10 @@ func (s *Server) Start() error {
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
 
+	if s.allowlist != nil {
+		go s.runAllowlistReload()
+	}
+
 	// watch for disconnected peers
 	s.host.Network().Notify(&network.NotifyBundle{
 		DisconnectedF: func(net network.Network, conn network.Conn) {
@@ -277,19 +332,24 @@ func (s *Server) Start() error {
 		},
 	})
 
+	s.dialStaticPeers()
+
 	return nil
 }
 
-// setupBootnodes sets up the node's bootnode connections
+// setupBootnodes sets up the node's bootnode connections.
+// The bootnodes are optional if the peers are discovered from DNS trees
 func (s *Server) setupBootnodes() error {
-	// Check the bootnode config is present
-	if s.config.Chain.Bootnodes == nil {
-		return ErrNoBootnodes
-	}
+	if len(s.config.DNSDiscovery) == 0 {
+		// Check the bootnode config is present
+		if s.config.Chain.Bootnodes == nil {
+			return ErrNoBootnodes
+		}
 
-	// Check if at least one bootnode is specified
-	if len(s.config.Chain.Bootnodes) < MinimumBootNodes {
-		return ErrMinBootnodes
+		// Check if at least one bootnode is specified
+		if len(s.config.Chain.Bootnodes) < MinimumBootNodes {
+			return ErrMinBootnodes
+		}
 	}
 
 	bootnodesArr := make([]*peer.AddrInfo, 0)
@@ -333,6 +393,8 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 			return
 		}
 
+		s.dialStaticPeers()
+
 		if s.numPeers() < MinimumPeerConnections {
 			if s.config.NoDiscover || !s.bootnodes.hasBootnodes() {
 				// dial unconnected peer
@@ -350,6 +412,26 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 	}
 }
 
+// dialStaticPeers connects to the static peers the node is not connected to.
+// As they are exempt from the connection limits, they are dialed
+// directly instead of waiting for a free slot in the dial queue
+func (s *Server) dialStaticPeers() {
+	for _, peerInfo := range s.staticPeers {
+		if s.IsConnected(peerInfo.ID) {
+			continue
+		}
+
+		go func(peerInfo peer.AddrInfo) {
+			ctx, cancel := context.WithTimeout(context.Background(), staticPeerDialTimeout)
+			defer cancel()
+
+			if err := s.host.Connect(ctx, peerInfo); err != nil {
+				s.logger.Debug("failed to dial static peer", "addr", peerInfo.String(), "err", err.Error())
+			}
+		}(*peerInfo)
+	}
+}
+
 // runDial starts the networking server's dial loop.
 // Essentially, the networking server monitors for any open connection slots
 // and attempts to fill them as soon as they open up
@@ -413,6 +495,12 @@ func (s *Server) runDial() {
 
 			s.logger.Debug(fmt.Sprintf("Dialing peer [%s] as local [%s]", peerInfo.String(), s.host.ID()))
 
+			if s.IsPeerBanned(peerInfo.ID) {
+				s.logger.Debug("skipping banned peer", "id", peerInfo.ID)
+
+				continue
+			}
+
 			if !s.IsConnected(peerInfo.ID) {
 				// the connection process is async because it involves connection (here) +
 				// the handshake done in the identity service.
@@ -466,6 +554,16 @@ func (s *Server) hasPeer(peerID peer.ID) bool {
 	return ok
 }
 
+// IsInboundPeer checks if the connection to the peer was initiated by the peer [Thread safe]
+func (s *Server) IsInboundPeer(peerID peer.ID) bool {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+
+	connectionInfo, ok := s.peers[peerID]
+
+	return ok && connectionInfo.connDirections[network.DirInbound]
+}
+
 // IsConnected checks if the networking server is connected to a peer
 func (s *Server) IsConnected(peerID peer.ID) bool {
 	return s.host.Network().Connectedness(peerID) == network.Connected
@@ -495,6 +593,8 @@ func (s *Server) removePeer(peerID peer.ID) {
 		return
 	}
 
+	s.traffic.forget(peerID)
+
 	// Emit the event alerting listeners
 	s.emitEvent(peerID, peerEvent.PeerDisconnected)
 }
@@ -522,8 +622,11 @@ func (s *Server) removePeerInfo(peerID peer.ID) *PeerConnInfo {
 	// Update connection counters
 	for connDirection, active := range connectionInfo.connDirections {
 		if active {
-			s.connectionCounts.UpdateConnCountByDirection(-1, connDirection)
-			s.updateConnCountMetrics(connDirection)
+			if !connectionInfo.trusted {
+				s.connectionCounts.UpdateConnCountByDirection(-1, connDirection)
+				s.updateConnCountMetrics(connDirection)
+			}
+
 			s.updateBootnodeConnCount(peerID, -1)
 		}
 	}
@@ -594,6 +697,35 @@ func (s *Server) joinPeer(peerInfo *peer.AddrInfo) {
 	s.addToDialQueue(peerInfo, common.PriorityRequestedDial)
 }
 
+// AddTrustedPeer marks the peer as trusted, which exempts its connections
+// from the connection limits, and attempts to connect to it
+func (s *Server) AddTrustedPeer(rawPeerMultiaddr string) error {
+	peerInfo, err := common.StringToAddrInfo(rawPeerMultiaddr)
+	if err != nil {
+		return err
+	}
+
+	s.trustedPeers.Store(peerInfo.ID, struct{}{})
+
+	if !s.IsConnected(peerInfo.ID) {
+		s.joinPeer(peerInfo)
+	}
+
+	return nil
+}
+
+// RemoveTrustedPeer removes the trusted mark of the peer, without disconnecting from it
+func (s *Server) RemoveTrustedPeer(peerID peer.ID) {
+	s.trustedPeers.Delete(peerID)
+}
+
+// IsTrustedPeer checks if the peer is trusted [Thread safe]
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	_, ok := s.trustedPeers.Load(peerID)
+
+	return ok
+}
+
 func (s *Server) Close() error {
 	err := s.host.Close()
 	s.dialQueue.Close()
@@ -633,12 +765,15 @@ func (s *Server) NewStream(proto string, id peer.ID) (network.Stream, error) {
 type Protocol interface {
 	Client(network.Stream) (*rawGrpc.ClientConn, error)
 	Handler() func(network.Stream)
+	SetTrafficRecorder(grpc.TrafficRecorder)
 }
 
 func (s *Server) RegisterProtocol(id string, p Protocol) {
 	s.protocolsLock.Lock()
 	defer s.protocolsLock.Unlock()
 
+	p.SetTrafficRecorder(s.traffic)
+
 	s.protocols[id] = p
 	s.wrapStream(id, p.Handler())
 }
@@ -131,7 +131,7 @@ func (s *Server) CloseProtocolStream(protocol string, peerID peer.ID) error {
 
 // AddToPeerStore adds peer information to the node's peer store
 func (s *Server) AddToPeerStore(peerInfo *peer.AddrInfo) {
-	s.host.Peerstore().AddAddr(peerInfo.ID, peerInfo.Addrs[0], peerstore.AddressTTL)
+	s.host.Peerstore().AddAddrs(peerInfo.ID, peerInfo.Addrs, peerstore.AddressTTL)
 }
 
 // RemoveFromPeerStore removes peer information from the node's peer store
@@ -230,6 +230,10 @@ func (s *Server) setupDiscovery() error {
 		return fmt.Errorf("unable to subscribe to network events, %w", subscribeErr)
 	}
 
+	if len(s.config.DNSDiscovery) > 0 {
+		discoveryService.EnableDNSDiscovery(s.host.ID(), s.config.DNSDiscovery, nil)
+	}
+
 	// Register the actual discovery service as a valid protocol
 	s.registerDiscoveryService(discoveryService)
 
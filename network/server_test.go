@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -75,6 +77,152 @@ func TestConnLimit_Inbound(t *testing.T) {
 	}
 }
 
+func TestConnLimit_TrustedPeer(t *testing.T) {
+	// trusted peers are accepted even if we are already connected to max peers
+	defaultConfig := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxInboundPeers = 1
+			c.MaxOutboundPeers = 1
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: defaultConfig,
+		1: defaultConfig,
+		2: defaultConfig,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	// Server 1 registers the peer once its side of the handshake is done
+	assert.Eventually(t, func() bool {
+		return servers[1].IsInboundPeer(servers[0].host.ID())
+	}, DefaultJoinTimeout, 10*time.Millisecond)
+	assert.False(t, servers[0].IsInboundPeer(servers[1].host.ID()))
+
+	// Server 1 is already connected to max inbound peers, but trusts Server 2
+	servers[1].trustedPeers.Store(servers[2].host.ID(), struct{}{})
+
+	if joinErr := JoinAndWait(servers[2], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	// the trusted peer doesn't take a connection slot
+	assert.Eventually(t, func() bool {
+		return servers[1].IsInboundPeer(servers[2].host.ID())
+	}, DefaultJoinTimeout, 10*time.Millisecond)
+	assert.Equal(t, int64(1), servers[1].connectionCounts.GetInboundConnCount())
+
+	servers[1].RemoveTrustedPeer(servers[2].host.ID())
+	assert.False(t, servers[1].IsTrustedPeer(servers[2].host.ID()))
+}
+
+func TestStaticPeers(t *testing.T) {
+	// static peers are dialed on start, even if there are no free outbound slots
+	staticPeer, err := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unable to create server, %v", err)
+	}
+
+	server, err := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxInboundPeers = 0
+			c.MaxOutboundPeers = 0
+			c.NoDiscover = true
+			c.StaticPeers = []*peer.AddrInfo{staticPeer.AddrInfo()}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unable to create server, %v", err)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, []*Server{server, staticPeer})
+	})
+
+	assert.True(t, server.IsTrustedPeer(staticPeer.host.ID()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	connected, err := WaitUntilPeerConnectsTo(ctx, server, staticPeer.host.ID())
+	assert.NoError(t, err)
+	assert.True(t, connected)
+}
+
+func TestPeerAllowlist(t *testing.T) {
+	key0, dir0 := GenerateTestLibp2pKey(t)
+	key1, dir1 := GenerateTestLibp2pKey(t)
+
+	peerID0, err := peer.IDFromPrivateKey(key0)
+	assert.NoError(t, err)
+
+	peerID1, err := peer.IDFromPrivateKey(key1)
+	assert.NoError(t, err)
+
+	allowlistPath := filepath.Join(t.TempDir(), "allowlist")
+	assert.NoError(t, os.WriteFile(allowlistPath, []byte(peerID0.String()+"\n"+peerID1.String()), 0600))
+
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+				c.DataDir = dir0
+				c.PeerAllowlist = allowlistPath
+			},
+		},
+		1: {
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+				c.DataDir = dir1
+				c.PeerAllowlist = allowlistPath
+			},
+		},
+		2: {
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+			},
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	assert.True(t, servers[1].IsPeerAllowed(peerID0))
+	assert.True(t, servers[0].IsPeerAllowed(peerID1))
+	assert.False(t, servers[1].IsPeerAllowed(servers[2].host.ID()))
+	assert.True(t, servers[2].IsPeerAllowed(peerID1))
+
+	// Server 1 rejects Server 2, which is not in its allowlist
+	smallTimeout := time.Second * 5
+	if joinErr := JoinAndWait(servers[2], servers[1], smallTimeout, smallTimeout); joinErr == nil {
+		t.Fatalf("Server 2 should not be able to connect to Server 1")
+	}
+
+	// Server 1 accepts Server 0, which is in its allowlist
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+}
+
 func TestConnLimit_Outbound(t *testing.T) {
 	// we should not try to make connections if we are already connected to max peers
 	defaultConfig := &CreateServerParams{
@@ -300,6 +448,35 @@ func TestAddrInfoToString(t *testing.T) {
 	}
 }
 
+func TestAddrInfoToStrings(t *testing.T) {
+	defaultPeerID := peer.ID("123")
+
+	multiAddrs, constructErr := constructMultiAddrs([]string{
+		"/ip4/127.0.0.1/tcp/5000",
+		"/ip4/192.168.1.1/tcp/5000",
+		"/ip4/192.168.1.1/udp/5000/quic-v1",
+	})
+	if constructErr != nil {
+		t.Fatalf("Unable to construct multiaddrs, %v", constructErr)
+	}
+
+	dialAddresses, err := common.AddrInfoToStrings(&peer.AddrInfo{
+		ID:    defaultPeerID,
+		Addrs: multiAddrs,
+	})
+
+	// All the addresses are listed, the loopback ones last
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		fmt.Sprintf("/ip4/192.168.1.1/tcp/5000/p2p/%s", defaultPeerID),
+		fmt.Sprintf("/ip4/192.168.1.1/udp/5000/quic-v1/p2p/%s", defaultPeerID),
+		fmt.Sprintf("/ip4/127.0.0.1/tcp/5000/p2p/%s", defaultPeerID),
+	}, dialAddresses)
+
+	_, err = common.AddrInfoToStrings(&peer.AddrInfo{ID: defaultPeerID})
+	assert.Error(t, err)
+}
+
 func TestJoinWhenAlreadyConnected(t *testing.T) {
 	// if we try to join an already connected node, the watcher
 	// should finish as well
@@ -824,6 +1001,7 @@ func TestMinimumBootNodeCount(t *testing.T) {
 	tests := []struct {
 		name          string
 		bootNodes     []string
+		dnsTrees      []string
 		expectedError error
 	}{
 		{
@@ -841,6 +1019,14 @@ func TestMinimumBootNodeCount(t *testing.T) {
 			bootNodes:     []string{tests.GenerateTestMultiAddr(t).String()},
 			expectedError: nil,
 		},
+		{
+			name:      "Server config with DNS trees and no bootnodes",
+			bootNodes: nil,
+			dnsTrees: []string{
+				"enrtree://ALXQ4NM3WMMLM7IX43NEBTJISAMRC6EPAV2ZVX6VCL66BXYPXWXPI@nodes.invalid",
+			},
+			expectedError: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -848,6 +1034,7 @@ func TestMinimumBootNodeCount(t *testing.T) {
 			_, createErr := CreateServer(&CreateServerParams{
 				ServerCallback: func(server *Server) {
 					server.config.Chain.Bootnodes = tt.bootNodes
+					server.config.DNSDiscovery = tt.dnsTrees
 				},
 			})
 
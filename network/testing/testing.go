@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -30,6 +31,10 @@ type MockNetworkingServer struct {
 	emitEventFn              emitEventDelegate
 	isTemporaryDialFn        isTemporaryDialDelegate
 	hasFreeConnectionSlotFn  hasFreeConnectionSlotDelegate
+	isTrustedPeerFn          isTrustedPeerDelegate
+	isPeerAllowedFn          isPeerAllowedDelegate
+	isPeerBannedFn           isPeerBannedDelegate
+	reportPeerFn             reportPeerDelegate
 
 	// Discovery Hooks
 	newDiscoveryClientFn       newDiscoveryClientDelegate
@@ -74,6 +79,10 @@ type updatePendingConnCountDelegate func(int64, network.Direction)
 type emitEventDelegate func(*event.PeerEvent)
 type isTemporaryDialDelegate func(peer.ID) bool
 type hasFreeConnectionSlotDelegate func(network.Direction) bool
+type isTrustedPeerDelegate func(peer.ID) bool
+type isPeerAllowedDelegate func(peer.ID) bool
+type isPeerBannedDelegate func(peer.ID) bool
+type reportPeerDelegate func(peer.ID, common.Penalty, string)
 
 // Required for Discovery
 type getRandomBootnodeDelegate func() *peer.AddrInfo
@@ -174,6 +183,52 @@ func (m *MockNetworkingServer) HookHasFreeConnectionSlot(fn hasFreeConnectionSlo
 	m.hasFreeConnectionSlotFn = fn
 }
 
+func (m *MockNetworkingServer) IsTrustedPeer(peerID peer.ID) bool {
+	if m.isTrustedPeerFn != nil {
+		return m.isTrustedPeerFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsTrustedPeer(fn isTrustedPeerDelegate) {
+	m.isTrustedPeerFn = fn
+}
+
+func (m *MockNetworkingServer) IsPeerAllowed(peerID peer.ID) bool {
+	if m.isPeerAllowedFn != nil {
+		return m.isPeerAllowedFn(peerID)
+	}
+
+	return true
+}
+
+func (m *MockNetworkingServer) HookIsPeerAllowed(fn isPeerAllowedDelegate) {
+	m.isPeerAllowedFn = fn
+}
+
+func (m *MockNetworkingServer) IsPeerBanned(peerID peer.ID) bool {
+	if m.isPeerBannedFn != nil {
+		return m.isPeerBannedFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsPeerBanned(fn isPeerBannedDelegate) {
+	m.isPeerBannedFn = fn
+}
+
+func (m *MockNetworkingServer) ReportPeer(peerID peer.ID, penalty common.Penalty, reason string) {
+	if m.reportPeerFn != nil {
+		m.reportPeerFn(peerID, penalty, reason)
+	}
+}
+
+func (m *MockNetworkingServer) HookReportPeer(fn reportPeerDelegate) {
+	m.reportPeerFn = fn
+}
+
 func (m *MockNetworkingServer) GetRandomBootnode() *peer.AddrInfo {
 	if m.getRandomBootnodeFn != nil {
 		return m.getRandomBootnodeFn()
@@ -0,0 +1,235 @@
+package network
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
+)
+
+// TrafficStats are the bytes and the messages exchanged with a peer over a protocol or a topic
+type TrafficStats struct {
+	Protocol    string // the gRPC protocol, or the gossip topic
+	BytesIn     uint64 // the bytes received from the peer
+	BytesOut    uint64 // the bytes sent to the peer
+	MessagesIn  uint64 // the messages received from the peer
+	MessagesOut uint64 // the messages sent to the peer
+}
+
+// peerTraffic is the traffic exchanged with a connected peer
+type peerTraffic struct {
+	protocols map[string]*TrafficStats
+	limiter   *rate.Limiter // the inbound bandwidth limiter, nil if the bandwidth is unlimited
+}
+
+// traffic keeps track of the traffic exchanged with the connected peers,
+// over the gRPC protocols and the gossip topics, and limits the inbound bandwidth of each peer.
+//
+// The per-peer statistics are dropped once the peer disconnects, and are not created again
+// for the messages still in flight, while the metrics are only labeled by protocol
+// and direction to keep their cardinality bounded
+type traffic struct {
+	bandwidth   int // the inbound bytes per second allowed to each peer, 0 if unlimited
+	localID     peer.ID
+	isConnected func(peer.ID) bool // checks if the peer is connected
+
+	lock  sync.Mutex
+	peers map[peer.ID]*peerTraffic
+}
+
+func newTraffic(localID peer.ID, bandwidth int, isConnected func(peer.ID) bool) *traffic {
+	return &traffic{
+		bandwidth:   bandwidth,
+		localID:     localID,
+		isConnected: isConnected,
+		peers:       map[peer.ID]*peerTraffic{},
+	}
+}
+
+// record adds a message exchanged with the peer to the statistics,
+// and returns the bandwidth limiter of the peer, nil if the peer is not connected [Thread safe]
+func (t *traffic) record(peerID peer.ID, protocol string, inbound bool, size int) *rate.Limiter {
+	direction := "out"
+	if inbound {
+		direction = "in"
+	}
+
+	labels := []metrics.Label{
+		{Name: "protocol", Value: protocol},
+		{Name: "direction", Value: direction},
+	}
+
+	metrics.IncrCounterWithLabels([]string{networkMetrics, "traffic_bytes"}, float32(size), labels)
+	metrics.IncrCounterWithLabels([]string{networkMetrics, "traffic_messages"}, 1, labels)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	peerStats := t.peerStats(peerID)
+	if peerStats == nil {
+		return nil
+	}
+
+	stats, ok := peerStats.protocols[protocol]
+	if !ok {
+		stats = &TrafficStats{Protocol: protocol}
+		peerStats.protocols[protocol] = stats
+	}
+
+	if inbound {
+		stats.BytesIn += uint64(size)
+		stats.MessagesIn++
+	} else {
+		stats.BytesOut += uint64(size)
+		stats.MessagesOut++
+	}
+
+	return peerStats.limiter
+}
+
+// peerStats returns the traffic of the peer, creating it if needed.
+// It returns nil if the peer is no longer connected, so the traffic of a forgotten peer
+// is not created again by its messages still in flight
+func (t *traffic) peerStats(peerID peer.ID) *peerTraffic {
+	peerStats, ok := t.peers[peerID]
+	if ok {
+		return peerStats
+	}
+
+	if !t.isConnected(peerID) {
+		return nil
+	}
+
+	peerStats = &peerTraffic{protocols: map[string]*TrafficStats{}}
+
+	if t.bandwidth > 0 {
+		// the peers can burst up to one second worth of bandwidth
+		peerStats.limiter = rate.NewLimiter(rate.Limit(t.bandwidth), t.bandwidth)
+	}
+
+	t.peers[peerID] = peerStats
+
+	return peerStats
+}
+
+// RecordInbound records a gRPC message received from the peer,
+// and blocks while the peer exceeds its bandwidth [Thread safe]
+func (t *traffic) RecordInbound(peerID peer.ID, protocol string, size int) {
+	limiter := t.record(peerID, protocol, true, size)
+	if limiter == nil {
+		return
+	}
+
+	// the messages larger than the burst are waited for in chunks
+	for size > 0 {
+		n := size
+		if n > limiter.Burst() {
+			n = limiter.Burst()
+		}
+
+		_ = limiter.WaitN(context.Background(), n)
+		size -= n
+	}
+}
+
+// RecordOutbound records a gRPC message sent to the peer [Thread safe]
+func (t *traffic) RecordOutbound(peerID peer.ID, protocol string, size int) {
+	t.record(peerID, protocol, false, size)
+}
+
+// allowGossip checks if a gossiped message of the given size is within the bandwidth of the peer
+// which relayed it. Contrary to the gRPC messages, the gossiped ones are dropped instead of delayed,
+// so the messages larger than the bandwidth of a peer are never accepted from it [Thread safe]
+func (t *traffic) allowGossip(peerID peer.ID, size int) bool {
+	if t.bandwidth == 0 || peerID == t.localID {
+		return true
+	}
+
+	t.lock.Lock()
+	peerStats := t.peerStats(peerID)
+	t.lock.Unlock()
+
+	// the messages of a disconnected peer are still validated, but no longer limited
+	if peerStats == nil {
+		return true
+	}
+
+	return peerStats.limiter.AllowN(time.Now(), size)
+}
+
+// stats returns the traffic exchanged with the peer, sorted by protocol [Thread safe]
+func (t *traffic) stats(peerID peer.ID) []TrafficStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	peerStats, ok := t.peers[peerID]
+	if !ok {
+		return nil
+	}
+
+	stats := make([]TrafficStats, 0, len(peerStats.protocols))
+	for _, protocolStats := range peerStats.protocols {
+		stats = append(stats, *protocolStats)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Protocol < stats[j].Protocol
+	})
+
+	return stats
+}
+
+// forget drops the traffic of the disconnected peer [Thread safe]
+func (t *traffic) forget(peerID peer.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.peers, peerID)
+}
+
+// trafficTracer is the pubsub tracer recording the gossiped messages exchanged with the peers.
+// The inbound messages are recorded once they enter validation, or are dropped as duplicates
+type trafficTracer struct {
+	traffic *traffic
+}
+
+var _ pubsub.RawTracer = (*trafficTracer)(nil)
+
+func (tt *trafficTracer) ValidateMessage(msg *pubsub.Message) {
+	tt.traffic.record(msg.ReceivedFrom, msg.GetTopic(), true, msg.Size())
+}
+
+func (tt *trafficTracer) DuplicateMessage(msg *pubsub.Message) {
+	tt.traffic.record(msg.ReceivedFrom, msg.GetTopic(), true, msg.Size())
+}
+
+func (tt *trafficTracer) SendRPC(rpc *pubsub.RPC, p peer.ID) {
+	for _, msg := range rpc.GetPublish() {
+		tt.traffic.record(p, msg.GetTopic(), false, msg.Size())
+	}
+}
+
+func (tt *trafficTracer) AddPeer(peer.ID, protocol.ID)          {}
+func (tt *trafficTracer) RemovePeer(peer.ID)                    {}
+func (tt *trafficTracer) Join(string)                           {}
+func (tt *trafficTracer) Leave(string)                          {}
+func (tt *trafficTracer) Graft(peer.ID, string)                 {}
+func (tt *trafficTracer) Prune(peer.ID, string)                 {}
+func (tt *trafficTracer) DeliverMessage(*pubsub.Message)        {}
+func (tt *trafficTracer) RejectMessage(*pubsub.Message, string) {}
+func (tt *trafficTracer) ThrottlePeer(peer.ID)                  {}
+func (tt *trafficTracer) RecvRPC(*pubsub.RPC)                   {}
+func (tt *trafficTracer) DropRPC(*pubsub.RPC, peer.ID)          {}
+func (tt *trafficTracer) UndeliverableMessage(*pubsub.Message)  {}
+
+// GetPeerTraffic returns the traffic exchanged with the connected peer
+// over each gRPC protocol and gossip topic [Thread safe]
+func (s *Server) GetPeerTraffic(peerID peer.ID) []TrafficStats {
+	return s.traffic.stats(peerID)
+}
@@ -0,0 +1,170 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	testproto "github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraffic_Stats(t *testing.T) {
+	t.Parallel()
+
+	connected := map[peer.ID]bool{"A": true, "B": true}
+	traffic := newTraffic("local", 0, func(peerID peer.ID) bool {
+		return connected[peerID]
+	})
+
+	traffic.RecordInbound("A", "/b/0.1", 10)
+	traffic.RecordInbound("A", "/b/0.1", 20)
+	traffic.RecordOutbound("A", "/b/0.1", 5)
+	traffic.RecordOutbound("A", "/a/0.1", 7)
+	traffic.RecordInbound("B", "/a/0.1", 1)
+
+	// the stats are per peer, sorted by protocol
+	assert.Equal(t, []TrafficStats{
+		{Protocol: "/a/0.1", BytesOut: 7, MessagesOut: 1},
+		{Protocol: "/b/0.1", BytesIn: 30, BytesOut: 5, MessagesIn: 2, MessagesOut: 1},
+	}, traffic.stats("A"))
+
+	assert.Empty(t, traffic.stats("C"))
+
+	// the stats of the disconnected peers are dropped
+	connected["A"] = false
+	traffic.forget("A")
+
+	assert.Empty(t, traffic.stats("A"))
+	assert.Len(t, traffic.stats("B"), 1)
+
+	// and are not created again by the messages in flight
+	traffic.RecordInbound("A", "/b/0.1", 10)
+	traffic.RecordOutbound("A", "/b/0.1", 10)
+
+	assert.Empty(t, traffic.stats("A"))
+	assert.NotContains(t, traffic.peers, peer.ID("A"))
+}
+
+func TestTraffic_Bandwidth(t *testing.T) {
+	t.Parallel()
+
+	connected := func(peerID peer.ID) bool {
+		return peerID != "E"
+	}
+
+	traffic := newTraffic("local", 100, connected)
+
+	// the gossiped messages over the bandwidth of the peer are dropped
+	assert.True(t, traffic.allowGossip("A", 60))
+	assert.False(t, traffic.allowGossip("A", 60))
+	assert.True(t, traffic.allowGossip("B", 60))
+	assert.False(t, traffic.allowGossip("C", 101))
+
+	// the local messages are not limited
+	assert.True(t, traffic.allowGossip("local", 1000))
+
+	// the gRPC messages over the bandwidth of the peer are delayed
+	start := time.Now()
+
+	traffic.RecordInbound("D", "/a/0.1", 150)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// the messages of the disconnected peers are not limited
+	assert.True(t, traffic.allowGossip("E", 1000))
+	assert.NotContains(t, traffic.peers, peer.ID("E"))
+
+	unlimited := newTraffic("local", 0, connected)
+
+	assert.True(t, unlimited.allowGossip("A", 1000))
+}
+
+func TestPeerTraffic(t *testing.T) {
+	servers, createErr := createServers(2, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+			},
+		},
+		1: {
+			ConfigCallback: func(c *Config) {
+				c.NoDiscover = true
+			},
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	topicName := "traffic"
+	messageCh := make(chan struct{}, 1)
+
+	publisherTopic, err := servers[0].NewTopic(topicName, &testproto.GenericMessage{}, nil)
+	require.NoError(t, err)
+
+	subscriberTopic, err := servers[1].NewTopic(topicName, &testproto.GenericMessage{}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, subscriberTopic.Subscribe(func(_ interface{}, _ peer.ID) {
+		select {
+		case messageCh <- struct{}{}:
+		default:
+		}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, WaitForSubscribers(ctx, servers[0], topicName, 1))
+	require.NoError(t, publisherTopic.Publish(&testproto.GenericMessage{Message: "traffic"}))
+
+	select {
+	case <-messageCh:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Gossiped message not received before timeout")
+	}
+
+	findStats := func(server *Server, peerID peer.ID, protocol string) TrafficStats {
+		for _, stats := range server.GetPeerTraffic(peerID) {
+			if stats.Protocol == protocol {
+				return stats
+			}
+		}
+
+		return TrafficStats{}
+	}
+
+	publisherID, subscriberID := servers[0].host.ID(), servers[1].host.ID()
+
+	// the identity handshake is made over gRPC in both directions
+	for _, server := range servers {
+		peerID := publisherID
+		if server == servers[0] {
+			peerID = subscriberID
+		}
+
+		stats := findStats(server, peerID, common.IdentityProto)
+		assert.NotZero(t, stats.MessagesIn)
+		assert.NotZero(t, stats.BytesIn)
+		assert.NotZero(t, stats.MessagesOut)
+		assert.NotZero(t, stats.BytesOut)
+	}
+
+	// the gossiped message is recorded by topic
+	assert.Equal(t, uint64(1), findStats(servers[0], subscriberID, topicName).MessagesOut)
+
+	stats := findStats(servers[1], publisherID, topicName)
+	assert.Equal(t, uint64(1), stats.MessagesIn)
+	assert.NotZero(t, stats.BytesIn)
+}
@@ -0,0 +1,82 @@
+package network
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/libp2p/go-libp2p"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	ws "github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// transports are the libp2p transports the node listens and dials on.
+// Besides the base TCP address, the node can listen on QUIC and WebSocket addresses,
+// for the peers which can't reach it over TCP
+var transports = libp2p.ChainOptions(
+	libp2p.Transport(tcp.NewTCPTransport),
+	libp2p.Transport(quic.NewTransport),
+	libp2p.Transport(ws.New),
+)
+
+// advertisedAddrs returns the addresses advertised to the peers, out of the listened ones.
+//
+// If a NAT address is set, it replaces the IP of the listened addresses.
+// If a DNS address is set, it replaces the base TCP address, and its host replaces
+// the IP of the additional listened addresses
+func advertisedAddrs(config *Config, addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	var host multiaddr.Multiaddr
+
+	switch {
+	case config.NatAddr != nil:
+		natHost, err := manet.FromIP(config.NatAddr)
+		if err != nil {
+			return addrs
+		}
+
+		host = natHost
+	case config.DNS != nil:
+		host, _ = multiaddr.SplitFirst(config.DNS)
+	default:
+		return addrs
+	}
+
+	advertised := make([]multiaddr.Multiaddr, 0, len(addrs))
+	seen := make(map[string]struct{}, len(addrs))
+
+	for _, addr := range addrs {
+		_, rest := multiaddr.SplitFirst(addr)
+		if rest == nil {
+			continue
+		}
+
+		advertisedAddr := host.Encapsulate(rest)
+		if config.DNS != nil && config.NatAddr == nil && isBaseAddr(config.Addr, addr) {
+			advertisedAddr = config.DNS
+		}
+
+		// the addresses of the different interfaces collapse to the same advertised address
+		if _, ok := seen[advertisedAddr.String()]; ok {
+			continue
+		}
+
+		seen[advertisedAddr.String()] = struct{}{}
+		advertised = append(advertised, advertisedAddr)
+	}
+
+	return advertised
+}
+
+// isBaseAddr checks if the listened address is the base TCP address of the node
+func isBaseAddr(base *net.TCPAddr, addr multiaddr.Multiaddr) bool {
+	components := multiaddr.Split(addr)
+	if len(components) != 2 {
+		return false
+	}
+
+	port, err := components[1].ValueForProtocol(multiaddr.P_TCP)
+
+	return err == nil && port == strconv.Itoa(base.Port)
+}
@@ -0,0 +1,101 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransports(t *testing.T) {
+	const joinAttempts = 3
+
+	testTable := []struct {
+		name       string
+		listenAddr string
+		protocol   int
+	}{
+		{
+			"QUIC",
+			"/ip4/127.0.0.1/udp/0/quic-v1",
+			multiaddr.P_QUIC_V1,
+		},
+		{
+			"WebSocket",
+			"/ip4/127.0.0.1/tcp/0/ws",
+			multiaddr.P_WS,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			listenAddr, err := multiaddr.NewMultiaddr(testCase.listenAddr)
+			require.NoError(t, err)
+
+			servers, createErr := createServers(2, map[int]*CreateServerParams{
+				0: {
+					ConfigCallback: func(c *Config) {
+						c.ListenAddrs = []multiaddr.Multiaddr{listenAddr}
+					},
+				},
+			})
+			require.NoError(t, createErr)
+
+			t.Cleanup(func() {
+				closeTestServers(t, servers)
+			})
+
+			// Server 0 advertises the additional address along with the TCP one
+			transportAddrs := make([]multiaddr.Multiaddr, 0, 1)
+
+			for _, addr := range servers[0].AddrInfo().Addrs {
+				if _, err := addr.ValueForProtocol(testCase.protocol); err == nil {
+					transportAddrs = append(transportAddrs, addr)
+				}
+			}
+
+			require.Len(t, transportAddrs, 1)
+			require.Greater(t, len(servers[0].AddrInfo().Addrs), 1)
+
+			// Server 1 -> Server 0, knowing only the additional address.
+			// A failed dial is not retried by the dial queue, so the join is repeated
+			var connectErr error
+
+			for i := 0; i < joinAttempts; i++ {
+				servers[1].joinPeer(&peer.AddrInfo{
+					ID:    servers[0].host.ID(),
+					Addrs: transportAddrs,
+				})
+
+				connectCtx, connectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout/joinAttempts)
+				_, connectErr = WaitUntilPeerConnectsTo(connectCtx, servers[1], servers[0].host.ID())
+
+				connectFn()
+
+				if connectErr == nil {
+					break
+				}
+			}
+
+			require.NoError(t, connectErr)
+
+			// The connection is established over the additional transport
+			conns := servers[1].host.Network().ConnsToPeer(servers[0].host.ID())
+			require.NotEmpty(t, conns)
+
+			_, err = conns[0].RemoteMultiaddr().ValueForProtocol(testCase.protocol)
+			assert.NoError(t, err)
+
+			// The identity protocol shares all the addresses of Server 0
+			assert.Eventually(t, func() bool {
+				return len(servers[1].host.Peerstore().Addrs(servers[0].host.ID())) ==
+					len(servers[0].AddrInfo().Addrs)
+			}, DefaultBufferTimeout, DefaultBufferTimeout/20)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvertisedAddrs(t *testing.T) {
+	t.Parallel()
+
+	listenAddrs, err := constructMultiAddrs([]string{
+		"/ip4/127.0.0.1/tcp/1478",
+		"/ip4/192.168.1.1/tcp/1478",
+		"/ip4/127.0.0.1/udp/1478/quic-v1",
+		"/ip4/127.0.0.1/tcp/1479/ws",
+	})
+	require.NoError(t, err)
+
+	dnsAddr, err := multiaddr.NewMultiaddr("/dns4/example.org/tcp/1478")
+	require.NoError(t, err)
+
+	testTable := []struct {
+		name     string
+		config   *Config
+		expected []string
+	}{
+		{
+			"Listened addresses advertised as is",
+			&Config{},
+			[]string{
+				"/ip4/127.0.0.1/tcp/1478",
+				"/ip4/192.168.1.1/tcp/1478",
+				"/ip4/127.0.0.1/udp/1478/quic-v1",
+				"/ip4/127.0.0.1/tcp/1479/ws",
+			},
+		},
+		{
+			"NAT address replaces the listened IPs",
+			&Config{NatAddr: net.ParseIP("192.0.2.1")},
+			[]string{
+				"/ip4/192.0.2.1/tcp/1478",
+				"/ip4/192.0.2.1/udp/1478/quic-v1",
+				"/ip4/192.0.2.1/tcp/1479/ws",
+			},
+		},
+		{
+			"DNS address replaces the base address and the listened IPs",
+			&Config{DNS: dnsAddr},
+			[]string{
+				"/dns4/example.org/tcp/1478",
+				"/dns4/example.org/udp/1478/quic-v1",
+				"/dns4/example.org/tcp/1479/ws",
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCase.config.Addr = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1478}
+
+			advertised := make([]string, 0, len(testCase.expected))
+			for _, addr := range advertisedAddrs(testCase.config, listenAddrs) {
+				advertised = append(advertised, addr.String())
+			}
+
+			assert.Equal(t, testCase.expected, advertised)
+		})
+	}
+}
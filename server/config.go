@@ -2,12 +2,17 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 const DefaultGRPCPort int = 9632
@@ -21,9 +26,23 @@ type Config struct {
 	GRPCAddr   *net.TCPAddr
 	LibP2PAddr *net.TCPAddr
 
+	// JSONRPCListeners are the additional JSON-RPC listeners
+	JSONRPCListeners []*JSONRPC
+
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	TxLifetime         time.Duration
+
+	TxOrderingPolicy  txpool.OrderingPolicyType
+	MaxTxsPerSender   uint64
+	PriorityAddresses []types.Address
+
+	PrivateTxExpiry uint64
+	PrivateTxPeers  []peer.ID
+
+	TxRateLimit uint64
+	TxRateBurst uint64
 
 	Telemetry *Telemetry
 	Network   *network.Config
@@ -44,6 +63,9 @@ type Config struct {
 	Relayer bool
 
 	NumBlockConfirmations uint64
+
+	SnapSync       bool
+	CheckpointSync *syncer.Checkpoint
 }
 
 // Telemetry holds the config details for metric services
@@ -51,10 +73,22 @@ type Telemetry struct {
 	PrometheusAddr *net.TCPAddr
 }
 
-// JSONRPC holds the config details for the JSON-RPC server
+// JSONRPC holds the config details for a JSON-RPC listener
 type JSONRPC struct {
+	Transport                string
 	JSONRPCAddr              *net.TCPAddr
+	IPCPath                  string
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	JWTSecret                []byte
+	APIKeys                  []string
+	Namespaces               []string
+	RateLimit                uint64
+	RateBurst                uint64
+	MaxExpensiveRequests     uint64
+	SlowRequestThreshold     time.Duration
+	CompressionThreshold     uint64
+	MaxRequestSize           uint64
+	MaxWSMessageSize         uint64
 }
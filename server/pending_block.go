@@ -0,0 +1,258 @@
+package server
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// pendingBlockRefreshInterval is the interval at which the pending block
+	// is rebuilt, if the chain head or the promoted transactions have changed
+	pendingBlockRefreshInterval = 2 * time.Second
+)
+
+// pendingBlock is a block built on top of the chain head out of the
+// top transactions of the pool, which is never written to the chain
+type pendingBlock struct {
+	block    *types.Block
+	receipts []*types.Receipt
+
+	// executor runs on top of the in-memory state of the pending block,
+	// which is never persisted to the state storage
+	executor *state.Executor
+}
+
+// pendingBlockchain is the chain on top of which the pending block is built
+type pendingBlockchain interface {
+	Header() *types.Header
+	CalculateGasLimit(number uint64) (uint64, error)
+	CalculateBaseFee(parent *types.Header) uint64
+}
+
+// pendingTxPool provides the transactions of the pending block
+type pendingTxPool interface {
+	SubscribePoolEvents(eventTypes ...txpoolProto.EventType) (<-chan *txpoolProto.TxPoolEvent, func())
+	GetPendingTxs(baseFee uint64) []*types.Transaction
+}
+
+// pendingBlockBuilder periodically builds the pending block by executing
+// the top transactions of the pool over the state of the chain head
+type pendingBlockBuilder struct {
+	logger       hclog.Logger
+	blockchain   pendingBlockchain
+	executor     *state.Executor
+	stateStorage itrie.Storage
+	txpool       pendingTxPool
+
+	lock    sync.RWMutex
+	current *pendingBlock
+
+	// dirty is set when new transactions got promoted since the last build
+	dirty   atomic.Bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+
+	// cancelPromoted cancels the subscription to the promoted transactions
+	cancelPromoted func()
+}
+
+func newPendingBlockBuilder(
+	logger hclog.Logger,
+	blockchain pendingBlockchain,
+	executor *state.Executor,
+	stateStorage itrie.Storage,
+	txpool pendingTxPool,
+) *pendingBlockBuilder {
+	return &pendingBlockBuilder{
+		logger:       logger.Named("pending_block"),
+		blockchain:   blockchain,
+		executor:     executor,
+		stateStorage: stateStorage,
+		txpool:       txpool,
+		closeCh:      make(chan struct{}),
+	}
+}
+
+// start runs the refresh loop of the pending block
+func (b *pendingBlockBuilder) start() {
+	var promotedCh <-chan *txpoolProto.TxPoolEvent
+
+	promotedCh, b.cancelPromoted = b.txpool.SubscribePoolEvents(txpoolProto.EventType_PROMOTED)
+
+	b.wg.Add(2)
+
+	go func() {
+		defer b.wg.Done()
+
+		for {
+			select {
+			case <-b.closeCh:
+				return
+			case _, ok := <-promotedCh:
+				if !ok {
+					return
+				}
+
+				b.dirty.Store(true)
+			}
+		}
+	}()
+
+	go func() {
+		defer b.wg.Done()
+
+		ticker := time.NewTicker(pendingBlockRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-b.closeCh:
+				return
+			case <-ticker.C:
+				if err := b.refresh(); err != nil {
+					b.logger.Error("failed to build the pending block", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// close stops the refresh loop, and waits for a running build to complete,
+// so that no executor runs once the storages are closed. The subscription is
+// canceled right away, as the pool closes it on its own once closed
+func (b *pendingBlockBuilder) close() {
+	close(b.closeCh)
+
+	if b.cancelPromoted != nil {
+		b.cancelPromoted()
+	}
+
+	b.wg.Wait()
+}
+
+// get returns the latest pending block and its receipts, if already built
+// on top of the current chain head
+func (b *pendingBlockBuilder) get() (*types.Block, []*types.Receipt, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.current == nil || b.current.block.ParentHash() != b.blockchain.Header().Hash {
+		return nil, nil, false
+	}
+
+	return b.current.block, b.current.receipts, true
+}
+
+// executorAt returns the executor running on top of the state of the pending block,
+// if the given root is the one of the latest pending block, still built on top of
+// the current chain head
+func (b *pendingBlockBuilder) executorAt(root types.Hash) (*state.Executor, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.current == nil || b.current.block.ParentHash() != b.blockchain.Header().Hash ||
+		b.current.block.Header.StateRoot != root {
+		return nil, false
+	}
+
+	return b.current.executor, true
+}
+
+// refresh rebuilds the pending block if the chain head
+// or the promoted transactions have changed since the last build
+func (b *pendingBlockBuilder) refresh() error {
+	parent := b.blockchain.Header()
+
+	b.lock.RLock()
+	upToDate := b.current != nil && b.current.block.ParentHash() == parent.Hash
+	b.lock.RUnlock()
+
+	if upToDate && !b.dirty.Swap(false) {
+		return nil
+	}
+
+	pending, err := b.build(parent)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	b.current = pending
+	b.lock.Unlock()
+
+	return nil
+}
+
+// build executes the top transactions of the pool on top of the given parent
+func (b *pendingBlockBuilder) build(parent *types.Header) (*pendingBlock, error) {
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Number:     parent.Number + 1,
+		Timestamp:  uint64(time.Now().UTC().Unix()),
+	}
+
+	gasLimit, err := b.blockchain.CalculateGasLimit(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	header.GasLimit = gasLimit
+	header.BaseFee = b.blockchain.CalculateBaseFee(parent)
+
+	// the pending state is written to memory only, as it's rebuilt over and over
+	executor := b.executor.WithState(itrie.NewState(itrie.NewOverlayStorage(b.stateStorage)))
+
+	transition, err := executor.BeginTxn(parent.StateRoot, header, types.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		txs    []*types.Transaction
+		failed = make(map[types.Address]struct{})
+	)
+
+	for _, tx := range b.txpool.GetPendingTxs(header.BaseFee) {
+		// the next transactions of a failed account can not be executed either
+		if _, ok := failed[tx.From]; ok {
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
+				break
+			}
+
+			failed[tx.From] = struct{}{}
+
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	_, root := transition.Commit()
+
+	header.StateRoot = root
+	header.GasUsed = transition.TotalGas()
+
+	block := consensus.BuildBlock(consensus.BuildBlockParams{
+		Header:   header,
+		Txns:     txs,
+		Receipts: transition.Receipts(),
+	})
+
+	return &pendingBlock{
+		block:    block,
+		receipts: transition.Receipts(),
+		executor: executor,
+	}, nil
+}
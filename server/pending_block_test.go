@@ -0,0 +1,244 @@
+package server
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPendingBlockchain struct {
+	header   *types.Header
+	gasLimit uint64
+}
+
+func (m *mockPendingBlockchain) Header() *types.Header {
+	return m.header
+}
+
+func (m *mockPendingBlockchain) CalculateGasLimit(number uint64) (uint64, error) {
+	if m.gasLimit == 0 {
+		return 0, errors.New("gas limit not set")
+	}
+
+	return m.gasLimit, nil
+}
+
+func (m *mockPendingBlockchain) CalculateBaseFee(parent *types.Header) uint64 {
+	return 0
+}
+
+type mockPendingTxPool struct {
+	txs []*types.Transaction
+}
+
+func (m *mockPendingTxPool) SubscribePoolEvents(
+	eventTypes ...txpoolProto.EventType,
+) (<-chan *txpoolProto.TxPoolEvent, func()) {
+	return make(chan *txpoolProto.TxPoolEvent), func() {}
+}
+
+func (m *mockPendingTxPool) GetPendingTxs(baseFee uint64) []*types.Transaction {
+	return m.txs
+}
+
+var (
+	pendingSender1  = types.StringToAddress("a1")
+	pendingSender2  = types.StringToAddress("a2")
+	pendingReceiver = types.StringToAddress("a3")
+)
+
+// newTestPendingBlockBuilder returns a builder on top of a genesis header
+// whose state funds the test senders
+func newTestPendingBlockBuilder(t *testing.T, gasLimit uint64) (*pendingBlockBuilder, *mockPendingTxPool) {
+	t.Helper()
+
+	params := &chain.Params{Forks: &chain.Forks{}}
+	storage := itrie.NewMemoryStorage()
+	executor := state.NewExecutor(params, itrie.NewState(storage), hclog.NewNullLogger())
+
+	executor.GetHash = func(*types.Header) func(uint64) types.Hash {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	root, err := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		pendingSender1: {Balance: big.NewInt(1_000_000_000)},
+		pendingSender2: {Balance: big.NewInt(1_000_000_000)},
+	}, types.ZeroHash)
+	require.NoError(t, err)
+
+	genesis := &types.Header{StateRoot: root, GasLimit: gasLimit}
+	genesis.ComputeHash()
+
+	pool := &mockPendingTxPool{}
+	builder := newPendingBlockBuilder(
+		hclog.NewNullLogger(),
+		&mockPendingBlockchain{header: genesis, gasLimit: gasLimit},
+		executor,
+		storage,
+		pool,
+	)
+
+	return builder, pool
+}
+
+func newPendingTestTx(from types.Address, nonce uint64, value int64) *types.Transaction {
+	tx := &types.Transaction{
+		From:     from,
+		To:       &pendingReceiver,
+		Nonce:    nonce,
+		Value:    big.NewInt(value),
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+		V:        big.NewInt(1),
+	}
+	tx.ComputeHash()
+
+	return tx
+}
+
+func TestPendingBlockBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	t.Run("skips the next txs of a failed sender", func(t *testing.T) {
+		t.Parallel()
+
+		builder, pool := newTestPendingBlockBuilder(t, 1_000_000)
+
+		// the second tx of the second sender would be valid on its own
+		pool.txs = []*types.Transaction{
+			newPendingTestTx(pendingSender1, 0, 1),
+			newPendingTestTx(pendingSender2, 1, 2),
+			newPendingTestTx(pendingSender1, 1, 3),
+			newPendingTestTx(pendingSender2, 0, 4),
+		}
+
+		pending, err := builder.build(builder.blockchain.Header())
+		require.NoError(t, err)
+
+		assert.Equal(t, []*types.Transaction{pool.txs[0], pool.txs[2]}, pending.block.Transactions)
+	})
+
+	t.Run("stops at the block gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		builder, pool := newTestPendingBlockBuilder(t, 2*21000)
+
+		pool.txs = []*types.Transaction{
+			newPendingTestTx(pendingSender1, 0, 1),
+			newPendingTestTx(pendingSender2, 0, 2),
+			newPendingTestTx(pendingSender1, 1, 3),
+		}
+
+		pending, err := builder.build(builder.blockchain.Header())
+		require.NoError(t, err)
+
+		assert.Equal(t, pool.txs[:2], pending.block.Transactions)
+		assert.Equal(t, uint64(2*21000), pending.block.Header.GasUsed)
+	})
+
+	t.Run("sets the state root and the receipts", func(t *testing.T) {
+		t.Parallel()
+
+		builder, pool := newTestPendingBlockBuilder(t, 1_000_000)
+		parent := builder.blockchain.Header()
+
+		pool.txs = []*types.Transaction{
+			newPendingTestTx(pendingSender1, 0, 10),
+			newPendingTestTx(pendingSender2, 0, 20),
+		}
+
+		pending, err := builder.build(parent)
+		require.NoError(t, err)
+
+		header := pending.block.Header
+		assert.Equal(t, parent.Hash, header.ParentHash)
+		assert.Equal(t, parent.Number+1, header.Number)
+		assert.NotEqual(t, parent.StateRoot, header.StateRoot)
+
+		require.Len(t, pending.receipts, 2)
+
+		for i, receipt := range pending.receipts {
+			assert.Equal(t, pool.txs[i].Hash, receipt.TxHash)
+			assert.Equal(t, uint64(21000*(i+1)), receipt.CumulativeGasUsed)
+		}
+
+		// the state of the pending block is served by its executor only
+		transition, err := pending.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(30), transition.GetBalance(pendingReceiver))
+
+		_, err = builder.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+		assert.Error(t, err)
+	})
+}
+
+func TestPendingBlockBuilder_Refresh(t *testing.T) {
+	t.Parallel()
+
+	builder, pool := newTestPendingBlockBuilder(t, 1_000_000)
+	mockChain := builder.blockchain.(*mockPendingBlockchain) //nolint:forcetypeassert
+
+	pool.txs = []*types.Transaction{newPendingTestTx(pendingSender1, 0, 1)}
+
+	require.NoError(t, builder.refresh())
+
+	block, _, ok := builder.get()
+	require.True(t, ok)
+	assert.Len(t, block.Transactions, 1)
+
+	// nothing changed, the pending block is not rebuilt
+	pool.txs = append(pool.txs, newPendingTestTx(pendingSender1, 1, 2))
+
+	require.NoError(t, builder.refresh())
+
+	current, _, _ := builder.get()
+	assert.Same(t, block, current)
+
+	// new txs got promoted
+	builder.dirty.Store(true)
+
+	require.NoError(t, builder.refresh())
+
+	current, _, _ = builder.get()
+	assert.Len(t, current.Transactions, 2)
+	assert.False(t, builder.dirty.Load())
+
+	// the chain head moved on, the pending block is outdated until rebuilt
+	head := &types.Header{
+		Number:     1,
+		ParentHash: mockChain.header.Hash,
+		StateRoot:  mockChain.header.StateRoot,
+		GasLimit:   mockChain.header.GasLimit,
+	}
+	head.ComputeHash()
+
+	previous := current
+	mockChain.header = head
+
+	_, _, ok = builder.get()
+	assert.False(t, ok)
+
+	_, ok = builder.executorAt(previous.Header.StateRoot)
+	assert.False(t, ok)
+
+	require.NoError(t, builder.refresh())
+
+	current, _, ok = builder.get()
+	require.True(t, ok)
+	assert.Equal(t, head.Hash, current.ParentHash())
+	assert.Equal(t, uint64(2), current.Number())
+
+	_, ok = builder.executorAt(current.Header.StateRoot)
+	assert.True(t, ok)
+}
@@ -360,6 +360,44 @@ func (m *Peer) validate(all bool) error {
 
 	// no validation rules for Id
 
+	// no validation rules for Score
+
+	// no validation rules for BannedUntil
+
+	for idx, item := range m.GetTraffic() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PeerValidationError{
+						field:  fmt.Sprintf("Traffic[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PeerValidationError{
+						field:  fmt.Sprintf("Traffic[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PeerValidationError{
+					field:  fmt.Sprintf("Traffic[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return PeerMultiError(errors)
 	}
@@ -437,6 +475,115 @@ var _ interface {
 	ErrorName() string
 } = PeerValidationError{}
 
+// Validate checks the field values on PeerTraffic with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PeerTraffic) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeerTraffic with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PeerTrafficMultiError, or
+// nil if none found.
+func (m *PeerTraffic) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeerTraffic) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Protocol
+
+	// no validation rules for BytesIn
+
+	// no validation rules for BytesOut
+
+	// no validation rules for MessagesIn
+
+	// no validation rules for MessagesOut
+
+	if len(errors) > 0 {
+		return PeerTrafficMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeerTrafficMultiError is an error wrapping multiple validation errors
+// returned by PeerTraffic.ValidateAll() if the designated constraints aren't met.
+type PeerTrafficMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeerTrafficMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeerTrafficMultiError) AllErrors() []error { return m }
+
+// PeerTrafficValidationError is the validation error returned by
+// PeerTraffic.Validate if the designated constraints aren't met.
+type PeerTrafficValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeerTrafficValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeerTrafficValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeerTrafficValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeerTrafficValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeerTrafficValidationError) ErrorName() string { return "PeerTrafficValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PeerTrafficValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeerTraffic.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeerTrafficValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeerTrafficValidationError{}
+
 // Validate checks the field values on PeersAddRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -30,6 +30,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
+	networkCommon "github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -38,9 +39,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/txpool"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validate"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/umbracle/ethgo"
@@ -65,11 +68,17 @@ type Server struct {
 	blockchain *blockchain.Blockchain
 	chain      *chain.Chain
 
+	// logIndex indexes the logs blooms of the chain for the logs queries
+	logIndex *blockchain.LogIndex
+
 	// state executor
 	executor *state.Executor
 
 	// jsonrpc stack
-	jsonrpcServer *jsonrpc.JSONRPC
+	jsonrpcServers []*jsonrpc.JSONRPC
+
+	// pendingBlock builds the pending block served by the jsonrpc stack
+	pendingBlock *pendingBlockBuilder
 
 	// system grpc server
 	grpcServer *grpc.Server
@@ -336,6 +345,13 @@ func NewServer(config *Config) (*Server, error) {
 			Blockchain: m.blockchain,
 		}
 
+		var txValidators []txpool.TxValidator
+
+		if m.config.TxRateLimit > 0 {
+			txValidators = append(txValidators,
+				txpool.NewRateLimitValidator(float64(m.config.TxRateLimit), m.config.TxRateBurst))
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
@@ -347,6 +363,14 @@ func NewServer(config *Config) (*Server, error) {
 				MaxSlots:           m.config.MaxSlots,
 				PriceLimit:         m.config.PriceLimit,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
+				TxLifetime:         m.config.TxLifetime,
+				OrderingPolicy:     m.config.TxOrderingPolicy,
+				MaxTxsPerSender:    m.config.MaxTxsPerSender,
+				PriorityAddresses:  m.config.PriorityAddresses,
+				PrivateTxExpiry:    m.config.PrivateTxExpiry,
+				PrivateTxPeers:     m.config.PrivateTxPeers,
+				Validators:         txValidators,
+				ChainID:            uint64(m.config.Chain.Params.ChainID),
 			},
 		)
 		if err != nil {
@@ -371,6 +395,9 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	m.logIndex = blockchain.NewLogIndex(logger, m.blockchain)
+	m.logIndex.Start()
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -570,11 +597,14 @@ func (s *Server) setupConsensus() error {
 			Network:               s.network,
 			Blockchain:            s.blockchain,
 			Executor:              s.executor,
+			StateStorage:          s.stateStorage,
 			Grpc:                  s.grpcServer,
 			Logger:                s.logger,
 			SecretsManager:        s.secretsManager,
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
+			SnapSync:              s.config.SnapSync,
+			CheckpointSync:        s.config.CheckpointSync,
 		},
 	)
 
@@ -648,7 +678,6 @@ func (s *Server) setupRelayer() error {
 }
 
 type jsonRPCHub struct {
-	state              state.State
 	restoreProgression *progress.ProgressionWrapper
 
 	*blockchain.Blockchain
@@ -657,14 +686,167 @@ type jsonRPCHub struct {
 	*network.Server
 	consensus.Consensus
 	consensus.BridgeDataProvider
+	consensus.FinalityProvider
+
+	pendingBlock *pendingBlockBuilder
+	logIndex     *blockchain.LogIndex
+}
+
+func (j *jsonRPCHub) GetPendingBlock() (*types.Block, []*types.Receipt, bool) {
+	return j.pendingBlock.get()
+}
+
+// executorAt returns the executor holding the state at the given root,
+// which is only kept in memory for the pending block
+func (j *jsonRPCHub) executorAt(root types.Hash) *state.Executor {
+	if executor, ok := j.pendingBlock.executorAt(root); ok {
+		return executor
+	}
+
+	return j.Executor
+}
+
+func (j *jsonRPCHub) FilterBlocks(from, to uint64, criteria [][][]byte, maxUnindexed uint64) ([]uint64, error) {
+	if j.logIndex == nil {
+		return nil, errors.New("log index is not running")
+	}
+
+	return j.logIndex.FilterBlocks(from, to, criteria, maxUnindexed)
+}
+
+func (j *jsonRPCHub) SubscribePromotedTxs() (<-chan types.Hash, func()) {
+	eventCh, cancel := j.TxPool.SubscribePoolEvents(txpoolProto.EventType_PROMOTED)
+
+	hashCh := make(chan types.Hash)
+	doneCh := make(chan struct{})
+
+	go func() {
+		defer close(hashCh)
+
+		// the pool doesn't signal the events of the private transactions
+		for event := range eventCh {
+			select {
+			case hashCh <- types.StringToHash(event.TxHash):
+			case <-doneCh:
+				return
+			}
+		}
+	}()
+
+	return hashCh, func() {
+		close(doneCh)
+		cancel()
+	}
 }
 
 func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
 
+func (j *jsonRPCHub) PeersInfo() []*jsonrpc.PeerInfo {
+	peers := j.Server.Peers()
+	infos := make([]*jsonrpc.PeerInfo, 0, len(peers))
+
+	for _, p := range peers {
+		protocols, err := j.Server.GetProtocols(p.Info.ID)
+		if err != nil {
+			protocols = []string{}
+		}
+
+		addrs := []string{}
+		for _, addr := range j.Server.GetPeerInfo(p.Info.ID).Addrs {
+			addrs = append(addrs, addr.String())
+		}
+
+		infos = append(infos, &jsonrpc.PeerInfo{
+			ID:        p.Info.ID.String(),
+			Addrs:     addrs,
+			Protocols: protocols,
+			Network: jsonrpc.PeerNetworkInfo{
+				Inbound: j.Server.IsInboundPeer(p.Info.ID),
+				Trusted: j.Server.IsTrustedPeer(p.Info.ID),
+			},
+		})
+	}
+
+	return infos
+}
+
+func (j *jsonRPCHub) NodeInfo() (*jsonrpc.NodeInfo, error) {
+	addrInfo := j.Server.AddrInfo()
+
+	enode, err := networkCommon.AddrInfoToString(addrInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	listenAddrs := make([]string, len(addrInfo.Addrs))
+	for i, addr := range addrInfo.Addrs {
+		listenAddrs[i] = addr.String()
+	}
+
+	return &jsonrpc.NodeInfo{
+		ID:          addrInfo.ID.String(),
+		Enode:       enode,
+		ListenAddrs: listenAddrs,
+	}, nil
+}
+
+func (j *jsonRPCHub) RemovePeer(rawPeer string) (bool, error) {
+	peerID, err := parsePeerID(rawPeer)
+	if err != nil {
+		return false, err
+	}
+
+	if !j.Server.IsConnected(peerID) {
+		return false, nil
+	}
+
+	j.Server.DisconnectFromPeer(peerID, "removed by admin")
+
+	return true, nil
+}
+
+func (j *jsonRPCHub) RemoveTrustedPeer(rawPeer string) error {
+	peerID, err := parsePeerID(rawPeer)
+	if err != nil {
+		return err
+	}
+
+	j.Server.RemoveTrustedPeer(peerID)
+
+	return nil
+}
+
+// parsePeerID returns the ID of the peer, given either as ID or as p2p multiaddr
+func parsePeerID(rawPeer string) (peer.ID, error) {
+	if peerID, err := peer.Decode(rawPeer); err == nil {
+		return peerID, nil
+	}
+
+	addrInfo, err := networkCommon.StringToAddrInfo(rawPeer)
+	if err != nil {
+		return "", fmt.Errorf("invalid peer ID or multiaddr '%s': %w", rawPeer, err)
+	}
+
+	return addrInfo.ID, nil
+}
+
+func (j *jsonRPCHub) GetPoolTx(txHash types.Hash) (*jsonrpc.PoolTx, bool) {
+	lookup, ok := j.TxPool.LookupTx(txHash)
+	if !ok {
+		return nil, false
+	}
+
+	return &jsonrpc.PoolTx{
+		Tx:       lookup.Tx,
+		Status:   lookup.Status.String(),
+		Position: lookup.Position,
+	}, true
+}
+
 func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.Account, error) {
-	acct, err := getAccountImpl(j.state, root, addr)
+	acct, err := getAccountImpl(j.executorAt(root).State(), root, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -683,12 +865,14 @@ func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 }
 
 func (j *jsonRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot types.Hash) ([]byte, error) {
-	account, err := getAccountImpl(j.state, stateRoot, addr)
+	st := j.executorAt(stateRoot).State()
+
+	account, err := getAccountImpl(st, stateRoot, addr)
 	if err != nil {
 		return nil, err
 	}
 
-	snap, err := j.state.NewSnapshotAt(stateRoot)
+	snap, err := st.NewSnapshotAt(stateRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -699,12 +883,14 @@ func (j *jsonRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot t
 }
 
 func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
-	account, err := getAccountImpl(j.state, root, addr)
+	st := j.executorAt(root).State()
+
+	account, err := getAccountImpl(st, root, addr)
 	if err != nil {
 		return nil, err
 	}
 
-	code, ok := j.state.GetCode(types.BytesToHash(account.CodeHash))
+	code, ok := st.GetCode(types.BytesToHash(account.CodeHash))
 	if !ok {
 		return nil, fmt.Errorf("unable to fetch code")
 	}
@@ -722,7 +908,7 @@ func (j *jsonRPCHub) ApplyTxn(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	transition, err := j.executorAt(header.StateRoot).BeginTxn(header.StateRoot, header, blockCreator)
 	if err != nil {
 		return
 	}
@@ -844,7 +1030,9 @@ func (j *jsonRPCHub) TraceCall(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, parentHeader, blockCreator)
+	executor := j.executorAt(parentHeader.StateRoot)
+
+	transition, err := executor.BeginTxn(parentHeader.StateRoot, parentHeader, blockCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -874,10 +1062,11 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 
 // SETUP //
 
-// setupJSONRCP sets up the JSONRPC server, using the set configuration
+// setupJSONRCP sets up the JSONRPC listeners, using the set configuration
 func (s *Server) setupJSONRPC() error {
+	s.pendingBlock = newPendingBlockBuilder(s.logger, s.blockchain, s.executor, s.stateStorage, s.txpool)
+
 	hub := &jsonRPCHub{
-		state:              s.state,
 		restoreProgression: s.restoreProgression,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
@@ -885,25 +1074,56 @@ func (s *Server) setupJSONRPC() error {
 		Consensus:          s.consensus,
 		Server:             s.network,
 		BridgeDataProvider: s.consensus.GetBridgeProvider(),
-	}
+		FinalityProvider:   s.consensus.GetFinalityProvider(),
+		pendingBlock:       s.pendingBlock,
+		logIndex:           s.logIndex,
+	}
+
+	listeners := append([]*JSONRPC{s.config.JSONRPC}, s.config.JSONRPCListeners...)
+
+	for _, listener := range listeners {
+		conf := &jsonrpc.Config{
+			Store:                    hub,
+			Transport:                listener.Transport,
+			Addr:                     listener.JSONRPCAddr,
+			IPCPath:                  listener.IPCPath,
+			ChainID:                  uint64(s.config.Chain.Params.ChainID),
+			ChainName:                s.chain.Name,
+			AccessControlAllowOrigin: listener.AccessControlAllowOrigin,
+			PriceLimit:               s.config.PriceLimit,
+			BatchLengthLimit:         listener.BatchLengthLimit,
+			BlockRangeLimit:          listener.BlockRangeLimit,
+			Namespaces:               listener.Namespaces,
+			SlowRequestThreshold:     listener.SlowRequestThreshold,
+			CompressionThreshold:     int(listener.CompressionThreshold),
+			MaxRequestSize:           int64(listener.MaxRequestSize),
+			MaxWSMessageSize:         int64(listener.MaxWSMessageSize),
+		}
 
-	conf := &jsonrpc.Config{
-		Store:                    hub,
-		Addr:                     s.config.JSONRPC.JSONRPCAddr,
-		ChainID:                  uint64(s.config.Chain.Params.ChainID),
-		ChainName:                s.chain.Name,
-		AccessControlAllowOrigin: s.config.JSONRPC.AccessControlAllowOrigin,
-		PriceLimit:               s.config.PriceLimit,
-		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
-		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
-	}
+		if listener.RateLimit != 0 || listener.MaxExpensiveRequests != 0 {
+			conf.RateLimit = &jsonrpc.RateLimitConfig{
+				CreditsPerSecond:       float64(listener.RateLimit),
+				Burst:                  listener.RateBurst,
+				MaxConcurrentExpensive: listener.MaxExpensiveRequests,
+			}
+		}
 
-	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
-	if err != nil {
-		return err
+		if len(listener.JWTSecret) != 0 || len(listener.APIKeys) != 0 {
+			conf.Auth = &jsonrpc.AuthConfig{
+				JWTSecret: listener.JWTSecret,
+				APIKeys:   listener.APIKeys,
+			}
+		}
+
+		srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
+		if err != nil {
+			return err
+		}
+
+		s.jsonrpcServers = append(s.jsonrpcServers, srv)
 	}
 
-	s.jsonrpcServer = srv
+	s.pendingBlock.start()
 
 	return nil
 }
@@ -941,6 +1161,16 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Stop indexing the logs, before the blockchain storage gets closed
+	if s.logIndex != nil {
+		s.logIndex.Close()
+	}
+
+	// Stop building the pending block, before the blockchain and the state storages get closed
+	if s.pendingBlock != nil {
+		s.pendingBlock.close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -130,10 +130,27 @@ func (s *systemService) getPeer(id peer.ID) (*proto.Peer, error) {
 		addrs = append(addrs, addr.String())
 	}
 
+	score, bannedUntil := s.server.network.GetPeerReputation(id)
+
 	peer := &proto.Peer{
 		Id:        id.String(),
 		Protocols: protocols,
 		Addrs:     addrs,
+		Score:     score,
+	}
+
+	if !bannedUntil.IsZero() {
+		peer.BannedUntil = bannedUntil.Unix()
+	}
+
+	for _, stats := range s.server.network.GetPeerTraffic(id) {
+		peer.Traffic = append(peer.Traffic, &proto.PeerTraffic{
+			Protocol:    stats.Protocol,
+			BytesIn:     stats.BytesIn,
+			BytesOut:    stats.BytesOut,
+			MessagesIn:  stats.MessagesIn,
+			MessagesOut: stats.MessagesOut,
+		})
 	}
 
 	return peer, nil
@@ -158,6 +158,14 @@ func (e *Executor) StateAt(root types.Hash) (Snapshot, error) {
 	return e.state.NewSnapshotAt(root)
 }
 
+// WithState returns a copy of the executor running on top of the given state
+func (e *Executor) WithState(s State) *Executor {
+	executor := *e
+	executor.state = s
+
+	return &executor
+}
+
 // GetForksInTime returns the active forks at the given block height
 func (e *Executor) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return e.config.Forks.At(blockNumber)
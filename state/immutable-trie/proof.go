@@ -0,0 +1,566 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrMissingProofNode is returned when the proof lacks a node on the edges of the range
+	ErrMissingProofNode = errors.New("missing proof node")
+	// ErrInvalidRange is returned when the keys of the range are not ordered, or precede the origin
+	ErrInvalidRange = errors.New("invalid range")
+	// ErrRangeRootMismatch is returned when the range and its proof don't match the trie root
+	ErrRangeRootMismatch = errors.New("range doesn't match the trie root")
+)
+
+// Prove returns the proof of the key in the trie of the root, which are the encoded nodes
+// on the path to the key. The proof of a missing key proves its absence
+func Prove(root types.Hash, key []byte, storage Storage) ([][]byte, error) {
+	proof := [][]byte{}
+
+	if root == types.EmptyRootHash {
+		return proof, nil
+	}
+
+	hash, path := root.Bytes(), bytesToHexNibbles(key)
+
+	for hash != nil {
+		node, data, err := getCustomNode(hash, storage)
+		if err != nil {
+			return nil, err
+		}
+
+		if node == nil {
+			return nil, fmt.Errorf("trie node %s not found", types.BytesToHash(hash))
+		}
+
+		proof = append(proof, data)
+
+		hash, path = nextProofNode(node, path)
+	}
+
+	return proof, nil
+}
+
+// nextProofNode follows the path through the node and its embedded nodes,
+// and returns the hash of the next stored node along with the rest of the path
+func nextProofNode(node Node, path []byte) ([]byte, []byte) {
+	for {
+		switch n := node.(type) {
+		case *ValueNode:
+			if n.hash {
+				return n.buf, path
+			}
+
+			return nil, nil
+
+		case *ShortNode:
+			if len(path) < len(n.key) || !bytes.Equal(path[:len(n.key)], n.key) {
+				return nil, nil
+			}
+
+			node, path = n.child, path[len(n.key):]
+
+		case *FullNode:
+			if len(path) == 0 {
+				return nil, nil
+			}
+
+			node, path = n.getEdge(path[0]), path[1:]
+
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// ReadRange returns the entries of the trie of the root ordered by key, starting from the origin.
+// The entries are read until their size reaches maxBytes
+func ReadRange(root types.Hash, origin []byte, maxBytes int, storage Storage) ([][]byte, [][]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil, nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, fmt.Errorf("state not found at hash %s", root)
+	}
+
+	reader := &rangeReader{
+		origin:   keyNibbles(origin),
+		maxBytes: maxBytes,
+		storage:  storage,
+	}
+
+	if _, err := reader.read(node, nil); err != nil {
+		return nil, nil, err
+	}
+
+	return reader.keys, reader.values, nil
+}
+
+// rangeReader walks a trie in key order, collecting the entries from the origin
+type rangeReader struct {
+	origin   []byte
+	maxBytes int
+	storage  Storage
+
+	size   int
+	keys   [][]byte
+	values [][]byte
+}
+
+// read collects the entries of the node at the path, and returns true once the range is full
+func (r *rangeReader) read(node Node, path []byte) (bool, error) {
+	switch n := node.(type) {
+	case nil:
+		return false, nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, r.storage)
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				return false, fmt.Errorf("trie node %s not found", types.BytesToHash(n.buf))
+			}
+
+			return r.read(nc, path)
+		}
+
+		if comparePrefix(path, r.origin) < 0 {
+			return false, nil
+		}
+
+		key := hexNibblesToBytes(path)
+		value := make([]byte, len(n.buf))
+		copy(value, n.buf)
+
+		r.keys = append(r.keys, key)
+		r.values = append(r.values, value)
+		r.size += len(key) + len(value)
+
+		return r.size >= r.maxBytes, nil
+
+	case *ShortNode:
+		childPath := concat(path, n.key)
+		if comparePrefix(childPath, r.origin) < 0 {
+			return false, nil
+		}
+
+		return r.read(n.child, childPath)
+
+	case *FullNode:
+		// the value terminates at the path, so it precedes the children
+		if n.value != nil {
+			if done, err := r.read(n.value, concat(path, []byte{16})); done || err != nil {
+				return done, err
+			}
+		}
+
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			childPath := concat(path, []byte{byte(i)})
+			if comparePrefix(childPath, r.origin) < 0 {
+				continue
+			}
+
+			if done, err := r.read(child, childPath); done || err != nil {
+				return done, err
+			}
+		}
+
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unknown node type %T", node)
+	}
+}
+
+// VerifyRangeProof verifies that the keys and values are all the entries of the trie of the root
+// from the origin up to the last key, given the proofs of the origin and of the last key.
+// An empty range proves that there are no entries from the origin on.
+// It returns whether the trie has entries after the range, and writes the trie nodes
+// rebuilt from the range to the batch, if set
+func VerifyRangeProof(
+	root types.Hash,
+	origin []byte,
+	keys, values [][]byte,
+	proof [][]byte,
+	batch Putter,
+) (bool, error) {
+	if len(keys) != len(values) {
+		return false, fmt.Errorf("%w: %d keys and %d values", ErrInvalidRange, len(keys), len(values))
+	}
+
+	for i, key := range keys {
+		if len(key) != len(origin) || len(values[i]) == 0 {
+			return false, fmt.Errorf("%w: invalid entry %d", ErrInvalidRange, i)
+		}
+
+		if (i == 0 && bytes.Compare(key, origin) < 0) || (i > 0 && bytes.Compare(key, keys[i-1]) <= 0) {
+			return false, fmt.Errorf("%w: unordered key %d", ErrInvalidRange, i)
+		}
+	}
+
+	if root == types.EmptyRootHash {
+		if len(keys) != 0 {
+			return false, ErrRangeRootMismatch
+		}
+
+		return false, nil
+	}
+
+	proofDB := NewMemoryStorage()
+	for _, node := range proof {
+		proofDB.Put(crypto.Keccak256(node), node)
+	}
+
+	verifier := &rangeVerifier{
+		left:    keyNibbles(origin),
+		proofDB: proofDB,
+	}
+
+	if len(keys) > 0 {
+		verifier.right = keyNibbles(keys[len(keys)-1])
+	} else {
+		// the empty range covers all the keys from the origin
+		verifier.right = bytes.Repeat([]byte{15}, len(verifier.left))
+	}
+
+	// the trie is rebuilt from the proven nodes out of the range, and the entries of the range
+	pruned, err := verifier.prune(&ValueNode{hash: true, buf: root.Bytes()}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	txn := &Txn{root: pruned, epoch: 1, storage: proofDB}
+	for i, key := range keys {
+		txn.Insert(key, values[i])
+	}
+
+	nodes := nodeSet{}
+	txn.batch = nodes
+
+	hash, err := txn.Hash()
+	if err != nil {
+		return false, err
+	}
+
+	if !bytes.Equal(hash, root.Bytes()) {
+		return false, ErrRangeRootMismatch
+	}
+
+	if batch != nil {
+		for k, v := range nodes {
+			batch.Put([]byte(k), v)
+		}
+	}
+
+	return verifier.more, nil
+}
+
+// rangePosition is the position of a subtrie relative to a range of keys
+type rangePosition int
+
+const (
+	rangeBefore rangePosition = iota // all the keys of the subtrie precede the range
+	rangeAfter                       // all the keys of the subtrie follow the range
+	rangeInside                      // all the keys of the subtrie are in the range
+	rangeEdge                        // the subtrie holds the edge of the range
+)
+
+// rangeVerifier prunes the entries of a range from the trie built from its proof
+type rangeVerifier struct {
+	left    []byte // the nibbles of the first key of the range
+	right   []byte // the nibbles of the last key of the range
+	proofDB Storage
+
+	more bool // whether the trie has entries after the range
+}
+
+// position returns the position of the subtrie at the path, relative to the range
+func (v *rangeVerifier) position(path []byte) rangePosition {
+	left, right := comparePrefix(path, v.left), comparePrefix(path, v.right)
+
+	switch {
+	case left < 0:
+		return rangeBefore
+	case right > 0:
+		return rangeAfter
+	}
+
+	path = trimTerminator(path)
+
+	if (left > 0 || isRepeated(v.left, len(path), 0)) && (right < 0 || isRepeated(v.right, len(path), 15)) {
+		return rangeInside
+	}
+
+	return rangeEdge
+}
+
+// prune removes the subtries inside the range from the node at the path, resolving the nodes
+// on the edges of the range from the proof. The subtries out of the range are kept as they are
+func (v *rangeVerifier) prune(node Node, path []byte) (Node, error) {
+	switch v.position(path) {
+	case rangeBefore:
+		return node, nil
+	case rangeAfter:
+		v.more = true
+
+		return node, nil
+	case rangeInside:
+		return nil, nil
+	}
+
+	switch n := node.(type) {
+	case *ValueNode:
+		if !n.hash {
+			// the value is at a complete key, which is never on the edge
+			return n, nil
+		}
+
+		nc, ok, err := GetNode(n.buf, v.proofDB)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingProofNode, types.BytesToHash(n.buf))
+		}
+
+		return v.prune(nc, path)
+
+	case *ShortNode:
+		child, err := v.prune(n.child, concat(path, n.key))
+		if err != nil || child == nil {
+			return nil, err
+		}
+
+		return &ShortNode{key: n.key, child: child}, nil
+
+	case *FullNode:
+		var (
+			nc    = &FullNode{}
+			empty = true
+			err   error
+		)
+
+		for i := byte(0); i <= 16; i++ {
+			edge := n.getEdge(i)
+			if edge == nil {
+				continue
+			}
+
+			if edge, err = v.prune(edge, concat(path, []byte{i})); err != nil {
+				return nil, err
+			}
+
+			if edge != nil {
+				nc.setEdge(i, edge)
+
+				empty = false
+			}
+		}
+
+		if empty {
+			return nil, nil
+		}
+
+		return nc, nil
+
+	default:
+		return nil, fmt.Errorf("unknown node type %T", node)
+	}
+}
+
+// nodeSet collects the trie nodes written while hashing a trie
+type nodeSet map[string][]byte
+
+func (s nodeSet) Put(k, v []byte) {
+	buf := make([]byte, len(v))
+	copy(buf, v)
+
+	s[string(k)] = buf
+}
+
+// FindMissingNodes walks the state trie of the root, along with the storage tries and the code
+// of its accounts, and returns up to limit hashes of the trie nodes and the code missing in the storage
+func FindMissingNodes(stateRoot types.Hash, storage Storage, limit int) ([]types.Hash, []types.Hash, error) {
+	finder := &missingNodesFinder{
+		storage: storage,
+		limit:   limit,
+		visited: map[types.Hash]struct{}{},
+	}
+
+	if stateRoot != types.EmptyRootHash {
+		if err := finder.walkHash(stateRoot.Bytes(), false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return finder.nodes, finder.codes, nil
+}
+
+// missingNodesFinder collects the nodes and the code missing in the storage
+type missingNodesFinder struct {
+	storage Storage
+	limit   int
+	visited map[types.Hash]struct{} // the storage roots and the code already walked
+
+	nodes []types.Hash
+	codes []types.Hash
+}
+
+func (f *missingNodesFinder) full() bool {
+	return len(f.nodes)+len(f.codes) >= f.limit
+}
+
+// walkHash walks the stored node of the hash
+func (f *missingNodesFinder) walkHash(hash []byte, isStorage bool) error {
+	node, ok, err := GetNode(hash, f.storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		f.nodes = append(f.nodes, types.BytesToHash(hash))
+
+		return nil
+	}
+
+	return f.walk(node, isStorage)
+}
+
+func (f *missingNodesFinder) walk(node Node, isStorage bool) error {
+	if f.full() {
+		return nil
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			return f.walkHash(n.buf, isStorage)
+		}
+
+		if isStorage {
+			return nil
+		}
+
+		return f.walkAccount(n.buf)
+
+	case *ShortNode:
+		return f.walk(n.child, isStorage)
+
+	case *FullNode:
+		for i := byte(0); i <= 16; i++ {
+			if err := f.walk(n.getEdge(i), isStorage); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+}
+
+// walkAccount walks the storage trie and the code of the account
+func (f *missingNodesFinder) walkAccount(data []byte) error {
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return fmt.Errorf("cant parse account: %w", err)
+	}
+
+	if codeHash := types.BytesToHash(account.CodeHash); len(account.CodeHash) != 0 && codeHash != types.EmptyCodeHash {
+		if _, ok := f.visited[codeHash]; !ok {
+			f.visited[codeHash] = struct{}{}
+
+			if _, ok := f.storage.GetCode(codeHash); !ok {
+				f.codes = append(f.codes, codeHash)
+			}
+		}
+	}
+
+	if account.Root == types.EmptyRootHash {
+		return nil
+	}
+
+	if _, ok := f.visited[account.Root]; ok {
+		return nil
+	}
+
+	f.visited[account.Root] = struct{}{}
+
+	return f.walkHash(account.Root.Bytes(), true)
+}
+
+// keyNibbles returns the nibbles of the key, without the terminator
+func keyNibbles(key []byte) []byte {
+	return trimTerminator(bytesToHexNibbles(key))
+}
+
+// hexNibblesToBytes packs the nibbles of a complete key into bytes
+func hexNibblesToBytes(nibbles []byte) []byte {
+	nibbles = trimTerminator(nibbles)
+
+	key := make([]byte, len(nibbles)/2)
+	for i := range key {
+		key[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return key
+}
+
+func trimTerminator(nibbles []byte) []byte {
+	if hasTerminator(nibbles) {
+		return nibbles[:len(nibbles)-1]
+	}
+
+	return nibbles
+}
+
+// comparePrefix compares the path with the prefix of the key nibbles of the same length
+func comparePrefix(path, key []byte) int {
+	path = trimTerminator(path)
+
+	if len(path) > len(key) {
+		if cmp := bytes.Compare(path[:len(key)], key); cmp != 0 {
+			return cmp
+		}
+
+		return 1
+	}
+
+	return bytes.Compare(path, key[:len(path)])
+}
+
+// isRepeated checks if the nibbles from the offset on are all the same nibble
+func isRepeated(nibbles []byte, offset int, nibble byte) bool {
+	for i := offset; i < len(nibbles); i++ {
+		if nibbles[i] != nibble {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,256 @@
+package itrie
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTrie builds a trie of count random entries, and returns its root along with the sorted keys
+func buildTrie(t *testing.T, storage Storage, count int) (types.Hash, [][]byte, [][]byte) {
+	t.Helper()
+
+	keys := make([][]byte, count)
+	values := make([][]byte, count)
+
+	txn := NewTrie().Txn(storage)
+	txn.batch = storage.Batch()
+
+	for i := range keys {
+		keys[i] = crypto.Keccak256(big.NewInt(int64(i)).Bytes())
+		values[i] = bytes.Repeat([]byte{byte(i + 1)}, i%40+1)
+
+		txn.Insert(keys[i], values[i])
+	}
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+
+	sort.Sort(&sortedEntries{keys, values})
+
+	return types.BytesToHash(root), keys, values
+}
+
+type sortedEntries struct {
+	keys   [][]byte
+	values [][]byte
+}
+
+func (s *sortedEntries) Len() int { return len(s.keys) }
+
+func (s *sortedEntries) Less(i, j int) bool { return bytes.Compare(s.keys[i], s.keys[j]) < 0 }
+
+func (s *sortedEntries) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// proveRange returns the proof of the range, as served to the peers
+func proveRange(t *testing.T, root types.Hash, origin []byte, keys [][]byte, storage Storage) [][]byte {
+	t.Helper()
+
+	proof, err := Prove(root, origin, storage)
+	require.NoError(t, err)
+
+	if len(keys) > 0 {
+		lastProof, err := Prove(root, keys[len(keys)-1], storage)
+		require.NoError(t, err)
+
+		proof = append(proof, lastProof...)
+	}
+
+	return proof
+}
+
+func TestRangeProof_SyncTrie(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys, values := buildTrie(t, storage, 500)
+
+	// the trie is synced range by range into an empty storage
+	syncedStorage := NewMemoryStorage()
+	synced := 0
+	origin := make([]byte, types.HashLength)
+
+	for {
+		rangeKeys, rangeValues, err := ReadRange(root, origin, 1024, storage)
+		require.NoError(t, err)
+
+		assert.Equal(t, keys[synced:synced+len(rangeKeys)], rangeKeys)
+		assert.Equal(t, values[synced:synced+len(rangeValues)], rangeValues)
+
+		more, err := VerifyRangeProof(
+			root,
+			origin,
+			rangeKeys,
+			rangeValues,
+			proveRange(t, root, origin, rangeKeys, storage),
+			syncedStorage,
+		)
+		require.NoError(t, err)
+
+		synced += len(rangeKeys)
+
+		if !more {
+			break
+		}
+
+		origin = new(big.Int).Add(new(big.Int).SetBytes(rangeKeys[len(rangeKeys)-1]), big.NewInt(1)).
+			FillBytes(make([]byte, types.HashLength))
+	}
+
+	assert.Equal(t, len(keys), synced)
+
+	// all the trie nodes are synced
+	trie, err := NewState(syncedStorage).newTrieAt(root)
+	require.NoError(t, err)
+
+	for i, key := range keys {
+		value, ok := trie.Get(key, syncedStorage)
+		require.True(t, ok)
+		assert.Equal(t, values[i], value)
+	}
+}
+
+func TestRangeProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys, values := buildTrie(t, storage, 100)
+
+	origin := keys[10]
+	rangeKeys, rangeValues := keys[10:20], values[10:20]
+	proof := proveRange(t, root, origin, rangeKeys, storage)
+
+	more, err := VerifyRangeProof(root, origin, rangeKeys, rangeValues, proof, nil)
+	require.NoError(t, err)
+	assert.True(t, more)
+
+	// the last range has no entries after it
+	lastProof := proveRange(t, root, keys[90], keys[90:], storage)
+
+	more, err = VerifyRangeProof(root, keys[90], keys[90:], values[90:], lastProof, nil)
+	require.NoError(t, err)
+	assert.False(t, more)
+
+	// no entries follow the last key
+	lastKey := keys[len(keys)-1]
+	afterLast := new(big.Int).Add(new(big.Int).SetBytes(lastKey), big.NewInt(1)).FillBytes(make([]byte, types.HashLength))
+
+	more, err = VerifyRangeProof(root, afterLast, nil, nil, proveRange(t, root, afterLast, nil, storage), nil)
+	require.NoError(t, err)
+	assert.False(t, more)
+
+	// an omitted entry
+	omittedKeys := append(append([][]byte{}, rangeKeys[:4]...), rangeKeys[5:]...)
+	omittedValues := append(append([][]byte{}, rangeValues[:4]...), rangeValues[5:]...)
+
+	_, err = VerifyRangeProof(root, origin, omittedKeys, omittedValues, proof, nil)
+	assert.ErrorIs(t, err, ErrRangeRootMismatch)
+
+	// a modified value
+	modifiedValues := append([][]byte{}, rangeValues...)
+	modifiedValues[3] = []byte{0xff}
+
+	_, err = VerifyRangeProof(root, origin, rangeKeys, modifiedValues, proof, nil)
+	assert.ErrorIs(t, err, ErrRangeRootMismatch)
+
+	// an entry before the origin
+	_, err = VerifyRangeProof(root, origin, keys[9:20], values[9:20], proof, nil)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	// a missing proof of the last key
+	originProof, err := Prove(root, origin, storage)
+	require.NoError(t, err)
+
+	_, err = VerifyRangeProof(root, origin, rangeKeys, rangeValues, originProof, nil)
+	assert.ErrorIs(t, err, ErrMissingProofNode)
+
+	// a range claimed to be the last one
+	_, err = VerifyRangeProof(root, keys[90], keys[90:99], values[90:99], originProof, nil)
+	assert.Error(t, err)
+}
+
+func TestFindMissingNodes(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	snapshot := NewState(storage).NewSnapshot()
+
+	objs := make([]*state.Object, 0, 20)
+
+	for i := 0; i < 20; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i)),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%2 == 0 {
+			obj.Code = []byte{byte(i), 0x60, 0x00}
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(obj.Code))
+			obj.DirtyCode = true
+
+			for j := 0; j < 20; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j)).Bytes()).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(i*j + 1)).Bytes()).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, rootBytes := snapshot.Commit(objs)
+	root := types.BytesToHash(rootBytes)
+
+	nodes, codes, err := FindMissingNodes(root, storage, 100)
+	require.NoError(t, err)
+	assert.Empty(t, nodes)
+	assert.Empty(t, codes)
+
+	// an empty storage misses the root
+	emptyStorage := NewMemoryStorage()
+
+	nodes, codes, err = FindMissingNodes(root, emptyStorage, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Hash{root}, nodes)
+	assert.Empty(t, codes)
+
+	// the nodes are healed level by level
+	for len(nodes) > 0 || len(codes) > 0 {
+		for _, hash := range nodes {
+			data, ok := storage.Get(hash.Bytes())
+			require.True(t, ok)
+
+			emptyStorage.Put(hash.Bytes(), data)
+		}
+
+		for _, hash := range codes {
+			code, ok := storage.GetCode(hash)
+			require.True(t, ok)
+
+			emptyStorage.SetCode(hash, code)
+		}
+
+		nodes, codes, err = FindMissingNodes(root, emptyStorage, 100)
+		require.NoError(t, err)
+	}
+
+	healed, err := NewState(emptyStorage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err := healed.GetAccount(objs[2].Address)
+	require.NoError(t, err)
+	assert.Equal(t, objs[2].Balance, account.Balance)
+}
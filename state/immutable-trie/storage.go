@@ -168,6 +168,38 @@ func (m *memBatch) Put(p, v []byte) {
 func (m *memBatch) Write() {
 }
 
+// overlayStorage is an in-memory storage on top of a base storage, which is only read from.
+// It holds the states which are computed but never persisted
+type overlayStorage struct {
+	Storage
+
+	base Storage
+}
+
+// NewOverlayStorage creates an in-memory trie storage reading through to the base storage
+func NewOverlayStorage(base Storage) Storage {
+	return &overlayStorage{
+		Storage: NewMemoryStorage(),
+		base:    base,
+	}
+}
+
+func (o *overlayStorage) Get(p []byte) ([]byte, bool) {
+	if v, ok := o.Storage.Get(p); ok {
+		return v, true
+	}
+
+	return o.base.Get(p)
+}
+
+func (o *overlayStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	if code, ok := o.Storage.GetCode(hash); ok {
+		return code, true
+	}
+
+	return o.base.GetCode(hash)
+}
+
 // GetNode retrieves a node from storage
 func GetNode(root []byte, storage Storage) (Node, bool, error) {
 	data, ok := storage.Get(root)
@@ -0,0 +1,50 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlayStorage(t *testing.T) {
+	t.Parallel()
+
+	base := NewMemoryStorage()
+	base.Put([]byte{0x1}, []byte{0x1})
+	base.SetCode(types.Hash{0x1}, []byte{0x1})
+
+	overlay := NewOverlayStorage(base)
+
+	// the base storage is read through
+	v, ok := overlay.Get([]byte{0x1})
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x1}, v)
+
+	code, ok := overlay.GetCode(types.Hash{0x1})
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x1}, code)
+
+	// the writes are kept in the overlay only
+	overlay.Put([]byte{0x2}, []byte{0x2})
+	overlay.SetCode(types.Hash{0x2}, []byte{0x2})
+
+	batch := overlay.Batch()
+	batch.Put([]byte{0x3}, []byte{0x3})
+	batch.Write()
+
+	for _, k := range [][]byte{{0x2}, {0x3}} {
+		v, ok := overlay.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, k, v)
+
+		_, ok = base.Get(k)
+		assert.False(t, ok)
+	}
+
+	_, ok = overlay.GetCode(types.Hash{0x2})
+	assert.True(t, ok)
+
+	_, ok = base.GetCode(types.Hash{0x2})
+	assert.False(t, ok)
+}
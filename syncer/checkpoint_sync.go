@@ -0,0 +1,196 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var (
+	errInvalidCheckpoint       = errors.New("checkpoint must be in the form <number>:<hash>")
+	errCheckpointMismatch      = errors.New("block doesn't match the checkpoint hash")
+	errCheckpointNotDescendant = errors.New("checkpoint block is not a descendant of the local chain")
+)
+
+// Checkpoint is a block trusted to be part of the chain,
+// from which a new node is synced instead of validating all the blocks before it
+type Checkpoint struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// ParseCheckpoint parses a checkpoint in the form <number>:<hash>
+func ParseCheckpoint(value string) (*Checkpoint, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return nil, errInvalidCheckpoint
+	}
+
+	number, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || number == 0 {
+		return nil, fmt.Errorf("%w: invalid number %s", errInvalidCheckpoint, parts[0])
+	}
+
+	hash, err := hex.DecodeHex(parts[1])
+	if err != nil || len(hash) != types.HashLength {
+		return nil, fmt.Errorf("%w: invalid hash %s", errInvalidCheckpoint, parts[1])
+	}
+
+	return &Checkpoint{
+		Number: number,
+		Hash:   types.BytesToHash(hash),
+	}, nil
+}
+
+func (c *Checkpoint) String() string {
+	return fmt.Sprintf("%d:%s", c.Number, c.Hash)
+}
+
+// checkpointSyncWithPeer bootstraps the node from the checkpoint block of the peer and the state at its root,
+// instead of validating all the blocks before it.
+//
+// The checkpoint block is trusted by its hash, and so are the headers of its ancestors, linked to it by their hashes.
+// They are written without their bodies, for the consensus layer to verify the checkpoint block,
+// such as its committed seals against its validator set. The older blocks are not synced,
+// so their receipts are never handed over to the consensus layer (polybft runs such a node as a non-validator).
+// It does nothing once the local chain reaches the checkpoint
+func (s *syncer) checkpointSyncWithPeer(
+	bestPeer *NoForkPeer,
+	newBlockCallback func(*types.FullBlock) bool,
+) (bool, error) {
+	if s.blockchain.Header().Number >= s.checkpoint.Number {
+		return false, nil
+	}
+
+	if bestPeer.Number < s.checkpoint.Number {
+		return false, fmt.Errorf("peer doesn't have the checkpoint block %d yet", s.checkpoint.Number)
+	}
+
+	s.logger.Info("syncing checkpoint", "checkpoint", s.checkpoint, "peer", bestPeer.ID)
+
+	block, err := s.getCheckpointBlock(bestPeer.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.writeCheckpointAncestors(bestPeer.ID, block.Header); err != nil {
+		return false, err
+	}
+
+	if err := s.blockchain.VerifyCheckpointBlock(block); err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+		return false, fmt.Errorf("unable to verify checkpoint block, %w", err)
+	}
+
+	if !s.hasState(block.Header.StateRoot) {
+		s.logger.Info("syncing state", "number", block.Number(), "root", block.Header.StateRoot, "peer", bestPeer.ID)
+
+		if err := s.syncState(bestPeer.ID, block.Header.StateRoot); err != nil {
+			return false, fmt.Errorf("failed to sync state at checkpoint block: %w", err)
+		}
+	}
+
+	if err := s.blockchain.WriteCheckpointBlock(block, syncerName); err != nil {
+		return false, fmt.Errorf("failed to write checkpoint block: %w", err)
+	}
+
+	fullBlock := &types.FullBlock{Block: block}
+
+	updateMetrics(fullBlock)
+
+	s.logger.Info("checkpoint synced", "number", block.Number(), "hash", block.Hash())
+
+	return newBlockCallback(fullBlock), nil
+}
+
+// getCheckpointBlock downloads the checkpoint block from the peer, and checks it matches the checkpoint hash
+func (s *syncer) getCheckpointBlock(peerID peer.ID) (*types.Block, error) {
+	headers, err := s.syncPeerClient.GetHeaders(peerID, s.checkpoint.Number, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint header: %w", err)
+	}
+
+	if len(headers) != 1 || headers[0].Hash != s.checkpoint.Hash {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+		s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidBlock, "invalid checkpoint block while syncing")
+
+		return nil, errCheckpointMismatch
+	}
+
+	header := headers[0]
+
+	bodies, err := s.syncPeerClient.GetBodies(peerID, []types.Hash{header.Hash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint body: %w", err)
+	}
+
+	if len(bodies) != 1 {
+		return nil, fmt.Errorf("peer doesn't have the body of checkpoint block %d", header.Number)
+	}
+
+	if !bodyMatchesHeader(bodies[0], header) {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+		s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidBlock, "invalid block body while syncing")
+
+		return nil, fmt.Errorf("%w: checkpoint block %d", errInvalidBody, header.Number)
+	}
+
+	return &types.Block{
+		Header:       header,
+		Transactions: bodies[0].Transactions,
+		Uncles:       bodies[0].Uncles,
+	}, nil
+}
+
+// writeCheckpointAncestors downloads the headers of the ancestors of the checkpoint block from the peer
+// down to the local head, and writes them. They are downloaded from the newest to the oldest,
+// each one being trusted by the hash its child refers to
+func (s *syncer) writeCheckpointAncestors(peerID peer.ID, checkpoint *types.Header) error {
+	local := s.blockchain.Header()
+	child := checkpoint
+
+	for child.Number > local.Number+1 {
+		from := local.Number + 1
+		if child.Number-from > maxHeadersRequest {
+			from = child.Number - maxHeadersRequest
+		}
+
+		headers, err := s.syncPeerClient.GetHeaders(peerID, from, child.Number-from)
+		if err != nil {
+			return fmt.Errorf("failed to get headers: %w", err)
+		}
+
+		if uint64(len(headers)) != child.Number-from {
+			return fmt.Errorf("peer returned %d headers out of %d", len(headers), child.Number-from)
+		}
+
+		for i := len(headers) - 1; i >= 0; i-- {
+			if headers[i].Number != child.Number-1 || headers[i].Hash != child.ParentHash {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+				s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidBlock, "invalid headers while syncing")
+
+				return fmt.Errorf("%w: invalid header %d", errInvalidHeaderChain, headers[i].Number)
+			}
+
+			child = headers[i]
+		}
+
+		if err := s.blockchain.WriteCheckpointAncestors(headers); err != nil {
+			return fmt.Errorf("failed to write checkpoint ancestors: %w", err)
+		}
+	}
+
+	if child.ParentHash != local.Hash {
+		return errCheckpointNotDescendant
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package syncer
+
+import (
+	"testing"
+
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	hash := types.StringToHash("0x1234")
+
+	tests := []struct {
+		name       string
+		value      string
+		checkpoint *Checkpoint
+	}{
+		{
+			name:       "should parse the number and hash",
+			value:      "100:" + hash.String(),
+			checkpoint: &Checkpoint{Number: 100, Hash: hash},
+		},
+		{
+			name:  "should fail without a hash",
+			value: "100",
+		},
+		{
+			name:  "should fail with an invalid number",
+			value: "a:" + hash.String(),
+		},
+		{
+			name:  "should fail with the genesis block",
+			value: "0:" + hash.String(),
+		},
+		{
+			name:  "should fail with a short hash",
+			value: "100:0x1234",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkpoint, err := ParseCheckpoint(test.value)
+			if test.checkpoint == nil {
+				assert.ErrorIs(t, err, errInvalidCheckpoint)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.checkpoint, checkpoint)
+			assert.Equal(t, test.value, checkpoint.String())
+		})
+	}
+}
+
+func Test_checkpointSyncWithPeer(t *testing.T) {
+	t.Parallel()
+
+	sourceStorage, root, _ := newTestState(t, 20)
+
+	genesis := (&types.Header{Number: 0}).ComputeHash()
+	blocks := createChainedBlocks(genesis, 2*maxHeadersRequest)
+
+	// the checkpoint block is far enough to download its ancestors in several requests
+	checkpointNumber := uint64(maxHeadersRequest + 100)
+	blocks[checkpointNumber-1].Header.StateRoot = root
+
+	for _, block := range blocks[checkpointNumber-1:] {
+		block.Header.ParentHash = blocks[block.Number()-2].Hash()
+		block.Header.ComputeHash()
+	}
+
+	checkpoint := &Checkpoint{Number: checkpointNumber, Hash: blocks[checkpointNumber-1].Hash()}
+	bestPeer := &NoForkPeer{ID: peer.ID("A"), Number: uint64(len(blocks))}
+
+	newClient := func() *mockSyncPeerClient {
+		client := newStateServingClient(blocks, sourceStorage)
+		blockClient, _ := newBlockServingClient(blocks, 0, nil)
+		client.getHeadersHandler = blockClient.getHeadersHandler
+		client.getBodiesHandler = blockClient.getBodiesHandler
+
+		return client
+	}
+
+	t.Run("should write the checkpoint block with its state", func(t *testing.T) {
+		t.Parallel()
+
+		stateStorage := itrie.NewMemoryStorage()
+		syncer, chain := newChainTestSyncer(genesis, newClient())
+		syncer.stateStorage = stateStorage
+		syncer.checkpoint = checkpoint
+
+		var inserted []*types.FullBlock
+
+		shouldTerminate, err := syncer.checkpointSyncWithPeer(bestPeer, func(b *types.FullBlock) bool {
+			inserted = append(inserted, b)
+
+			return false
+		})
+		require.NoError(t, err)
+		assert.False(t, shouldTerminate)
+
+		assert.Equal(t, checkpoint.Hash, chain.latest.Hash)
+		assert.True(t, syncer.hasState(root))
+		assert.Len(t, chain.ancestors, int(checkpointNumber-1))
+
+		require.Len(t, inserted, 1)
+		assert.Equal(t, checkpoint.Hash, inserted[0].Block.Hash())
+		assert.Len(t, inserted[0].Block.Transactions, 1)
+
+		// nothing is done once the checkpoint is reached
+		_, err = syncer.checkpointSyncWithPeer(bestPeer, func(*types.FullBlock) bool {
+			t.Fatal("the checkpoint block should not be inserted again")
+
+			return false
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("should report the peer having another checkpoint block", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient()
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.checkpoint = &Checkpoint{
+			Number: checkpointNumber,
+			Hash:   types.StringToHash("1"),
+		}
+
+		_, err := syncer.checkpointSyncWithPeer(bestPeer, func(*types.FullBlock) bool {
+			return false
+		})
+		assert.ErrorIs(t, err, errCheckpointMismatch)
+		assert.Equal(t, []peer.ID{bestPeer.ID}, client.reportedPeers)
+		assert.Equal(t, genesis.Hash, chain.latest.Hash)
+	})
+
+	t.Run("should report the peer sending ancestors not chained", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient()
+		getHeaders := client.getHeadersHandler
+		client.getHeadersHandler = func(id peer.ID, from, count uint64) ([]*types.Header, error) {
+			headers, err := getHeaders(id, from, count)
+			if err != nil || count == 1 {
+				return headers, err
+			}
+
+			// the oldest header is replaced
+			headers[0] = blocks[len(blocks)-1].Header
+
+			return headers, nil
+		}
+
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.checkpoint = checkpoint
+
+		_, err := syncer.checkpointSyncWithPeer(bestPeer, func(*types.FullBlock) bool {
+			return false
+		})
+		assert.ErrorIs(t, err, errInvalidHeaderChain)
+		assert.Equal(t, []peer.ID{bestPeer.ID}, client.reportedPeers)
+		assert.Equal(t, genesis.Hash, chain.latest.Hash)
+	})
+
+	t.Run("should fail if the checkpoint is not a descendant of the local chain", func(t *testing.T) {
+		t.Parallel()
+
+		otherGenesis := (&types.Header{Number: 0, ExtraData: []byte{1}}).ComputeHash()
+		syncer, chain := newChainTestSyncer(otherGenesis, newClient())
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.checkpoint = checkpoint
+
+		_, err := syncer.checkpointSyncWithPeer(bestPeer, func(*types.FullBlock) bool {
+			return false
+		})
+		assert.ErrorIs(t, err, errCheckpointNotDescendant)
+		assert.Equal(t, otherGenesis.Hash, chain.latest.Hash)
+	})
+
+	t.Run("should fail if the peer doesn't have the checkpoint block", func(t *testing.T) {
+		t.Parallel()
+
+		syncer, _ := newChainTestSyncer(genesis, &mockSyncPeerClient{})
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.checkpoint = checkpoint
+
+		_, err := syncer.checkpointSyncWithPeer(&NoForkPeer{ID: peer.ID("B"), Number: checkpointNumber - 1},
+			func(*types.FullBlock) bool {
+				return false
+			})
+		assert.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -24,6 +25,7 @@ const (
 	SyncPeerClientLoggerName = "sync-peer-client"
 	statusTopicName          = "syncer/status/0.1"
 	defaultTimeoutForStatus  = 10 * time.Second
+	defaultTimeoutForRequest = 30 * time.Second
 )
 
 type syncPeerClient struct {
@@ -188,7 +190,7 @@ func (m *syncPeerClient) GetPeerConnectionUpdateEventCh() <-chan *event.PeerEven
 
 // startGossip creates new topic and starts subscribing
 func (m *syncPeerClient) startGossip() error {
-	topic, err := m.network.NewTopic(statusTopicName, &proto.SyncPeerStatus{})
+	topic, err := m.network.NewTopic(statusTopicName, &proto.SyncPeerStatus{}, nil)
 	if err != nil {
 		return err
 	}
@@ -290,6 +292,11 @@ func (m *syncPeerClient) CloseStream(peerID peer.ID) error {
 	return m.network.CloseProtocolStream(syncerProto, peerID)
 }
 
+// ReportPeer penalizes the peer for a misbehavior
+func (m *syncPeerClient) ReportPeer(peerID peer.ID, penalty common.Penalty, reason string) {
+	m.network.ReportPeer(peerID, penalty, reason)
+}
+
 // GetBlocks returns a stream of blocks from given height to peer's latest
 func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
@@ -345,6 +352,144 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetHeaders returns up to count headers of the consecutive blocks from the given height
+func (m *syncPeerClient) GetHeaders(peerID peer.ID, from, count uint64) ([]*types.Header, error) {
+	var protoHeaders *proto.Headers
+
+	err := m.sendRequest(peerID, func(ctx context.Context, clt proto.SyncPeerClient) (err error) {
+		protoHeaders, err = clt.GetHeaders(ctx, &proto.GetHeadersRequest{
+			From:  from,
+			Count: count,
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*types.Header, len(protoHeaders.Headers))
+
+	for i, data := range protoHeaders.Headers {
+		headers[i] = &types.Header{}
+		if err := headers[i].UnmarshalRLP(data); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_message"}, 1)
+
+			return nil, err
+		}
+	}
+
+	return headers, nil
+}
+
+// GetBodies returns the bodies of the blocks of the given hashes, up to the first block the peer doesn't have
+func (m *syncPeerClient) GetBodies(peerID peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+	var protoBodies *proto.BlockBodies
+
+	err := m.sendRequest(peerID, func(ctx context.Context, clt proto.SyncPeerClient) (err error) {
+		req := &proto.GetBodiesRequest{
+			Hashes: make([][]byte, len(hashes)),
+		}
+
+		for i, hash := range hashes {
+			req.Hashes[i] = hash.Bytes()
+		}
+
+		protoBodies, err = clt.GetBodies(ctx, req)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([]*types.Body, len(protoBodies.Bodies))
+
+	for i, protoBody := range protoBodies.Bodies {
+		if bodies[i], err = fromProtoBody(protoBody); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_message"}, 1)
+
+			return nil, err
+		}
+	}
+
+	return bodies, nil
+}
+
+// GetStateRange returns the entries of a state or storage trie from the origin, along with their proof
+func (m *syncPeerClient) GetStateRange(peerID peer.ID, root types.Hash, origin []byte) (*StateRange, error) {
+	var stateRange *proto.StateRange
+
+	err := m.sendRequest(peerID, func(ctx context.Context, clt proto.SyncPeerClient) (err error) {
+		stateRange, err = clt.GetStateRange(ctx, &proto.GetStateRangeRequest{
+			Root:   root.Bytes(),
+			Origin: origin,
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateRange{
+		Keys:   stateRange.Keys,
+		Values: stateRange.Values,
+		Proof:  stateRange.Proof,
+	}, nil
+}
+
+// GetTrieNodes returns the trie nodes of the given hashes, empty if the peer doesn't have them
+func (m *syncPeerClient) GetTrieNodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	var items *proto.StateItems
+
+	err := m.sendRequest(peerID, func(ctx context.Context, clt proto.SyncPeerClient) (err error) {
+		items, err = clt.GetTrieNodes(ctx, toProtoStateItemsRequest(hashes))
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items.Items, nil
+}
+
+// GetByteCodes returns the contract codes of the given hashes, empty if the peer doesn't have them
+func (m *syncPeerClient) GetByteCodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	var items *proto.StateItems
+
+	err := m.sendRequest(peerID, func(ctx context.Context, clt proto.SyncPeerClient) (err error) {
+		items, err = clt.GetByteCodes(ctx, toProtoStateItemsRequest(hashes))
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items.Items, nil
+}
+
+// sendRequest sends a request to the peer on a dedicated stream, which is closed
+// once the request is done, as the headers, bodies and state are synced with many requests
+func (m *syncPeerClient) sendRequest(
+	peerID peer.ID,
+	request func(context.Context, proto.SyncPeerClient) error,
+) error {
+	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
+	if err != nil {
+		return fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForRequest)
+	defer cancel()
+
+	return request(ctx, proto.NewSyncPeerClient(conn))
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -367,6 +512,45 @@ func fromProto(protoBlock *proto.Block) (*types.Block, error) {
 	return block, nil
 }
 
+// fromProtoBody gets block body from gRPC response data
+func fromProtoBody(protoBody *proto.BlockBody) (*types.Body, error) {
+	body := &types.Body{
+		Transactions: make([]*types.Transaction, len(protoBody.Transactions)),
+		Uncles:       make([]*types.Header, len(protoBody.Uncles)),
+	}
+
+	for i, data := range protoBody.Transactions {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(data); err != nil {
+			return nil, err
+		}
+
+		body.Transactions[i] = tx.ComputeHash()
+	}
+
+	for i, data := range protoBody.Uncles {
+		body.Uncles[i] = &types.Header{}
+		if err := body.Uncles[i].UnmarshalRLP(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
+
+// toProtoStateItemsRequest converts the hashes of the requested state items to the request
+func toProtoStateItemsRequest(hashes []types.Hash) *proto.GetStateItemsRequest {
+	req := &proto.GetStateItemsRequest{
+		Hashes: make([][]byte, len(hashes)),
+	}
+
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	return req
+}
+
 func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.Block, <-chan error) {
 	blockCh := make(chan *types.Block)
 	errorCh := make(chan error, 1)
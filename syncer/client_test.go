@@ -292,7 +292,7 @@ func TestPeerConnectionUpdateEventCh(t *testing.T) {
 	assert.NoError(t, peerClient2.startGossip())
 
 	// create topic
-	topic, err := peerSrv3.NewTopic(statusTopicName, &proto.SyncPeerStatus{})
+	topic, err := peerSrv3.NewTopic(statusTopicName, &proto.SyncPeerStatus{}, nil)
 	assert.NoError(t, err)
 
 	var wgForGossip sync.WaitGroup
@@ -434,7 +434,7 @@ func Test_shouldEmitBlocks(t *testing.T) {
 	}
 
 	// create topic & subscribe in peer
-	topic, err := peerSrv.NewTopic(statusTopicName, &proto.SyncPeerStatus{})
+	topic, err := peerSrv.NewTopic(statusTopicName, &proto.SyncPeerStatus{}, nil)
 	assert.NoError(t, err)
 
 	testGossip := func(t *testing.T, shouldEmit bool) {
@@ -591,7 +591,7 @@ func Test_EmitMultipleBlocks(t *testing.T) {
 	}
 
 	// create topic & subscribe in peer
-	topic, err := peerSrv.NewTopic(statusTopicName, &proto.SyncPeerStatus{})
+	topic, err := peerSrv.NewTopic(statusTopicName, &proto.SyncPeerStatus{}, nil)
 	assert.NoError(t, err)
 
 	testGossip := func(t *testing.T, blocksNum int) {
@@ -0,0 +1,365 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// parallelSyncThreshold is the number of blocks behind the best peer
+	// from which the blocks are downloaded from multiple peers in parallel
+	parallelSyncThreshold = 128
+	// maxHeadersRequest is the maximum number of headers requested at once
+	maxHeadersRequest = 512
+	// maxBodiesRequest is the maximum number of bodies requested at once,
+	// which is the size of the ranges downloaded in parallel
+	maxBodiesRequest = 64
+	// maxDownloadPeers is the maximum number of peers the bodies are downloaded from in parallel
+	maxDownloadPeers = 8
+	// throughputImpact is the weight of a new measurement in the throughput of a peer
+	throughputImpact = 0.2
+)
+
+var (
+	errInvalidHeaderChain = errors.New("headers are not a chain of the local blocks")
+	errInvalidBody        = errors.New("block body doesn't match its header")
+	errBodiesNotFound     = errors.New("failed to download the block bodies from all the peers")
+)
+
+// downloadBlocks downloads the blocks up to the latest block of the best peer, and writes them in order.
+//
+// The headers are downloaded first from the best peer, then the bodies are downloaded
+// in parallel ranges from the peers having the blocks, starting with the fastest ones.
+// The download stops once the callback returns true
+func (s *syncer) downloadBlocks(
+	bestPeer *NoForkPeer,
+	newBlockCallback func(*types.FullBlock) bool,
+) (uint64, bool, error) {
+	parent := s.blockchain.Header()
+	// the peers failing to download the bodies are not used for the remaining blocks
+	failedPeers := make(map[peer.ID]bool)
+
+	for parent.Number < bestPeer.Number {
+		headers, err := s.downloadHeaders(bestPeer.ID, parent, bestPeer.Number-parent.Number)
+		if err != nil {
+			return parent.Number, false, err
+		}
+
+		bodies, bodyPeers, err := s.downloadBodies(headers, failedPeers)
+		if err != nil {
+			return parent.Number, false, err
+		}
+
+		shouldTerminate := false
+
+		for i, header := range headers {
+			block := &types.Block{
+				Header:       header,
+				Transactions: bodies[i].Transactions,
+				Uncles:       bodies[i].Uncles,
+			}
+
+			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
+			if err != nil {
+				// the headers are served by the best peer, the bodies by the download peers
+				s.reportInvalidBlock(err, bestPeer.ID, bodyPeers[i])
+
+				return parent.Number, false, fmt.Errorf("unable to verify block, %w", err)
+			}
+
+			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				return parent.Number, false, fmt.Errorf("failed to write block while downloading: %w", err)
+			}
+
+			updateMetrics(fullBlock)
+
+			if newBlockCallback(fullBlock) {
+				shouldTerminate = true
+			}
+
+			parent = header
+		}
+
+		if shouldTerminate {
+			return parent.Number, true, nil
+		}
+	}
+
+	return parent.Number, false, nil
+}
+
+// downloadHeaders downloads the headers following the parent from the peer, and checks they are chained
+func (s *syncer) downloadHeaders(peerID peer.ID, parent *types.Header, remaining uint64) ([]*types.Header, error) {
+	count := remaining
+	if count > maxHeadersRequest {
+		count = maxHeadersRequest
+	}
+
+	headers, err := s.syncPeerClient.GetHeaders(peerID, parent.Number+1, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get headers: %w", err)
+	}
+
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("peer has no headers from block %d", parent.Number+1)
+	}
+
+	for i, header := range headers {
+		if i > 0 {
+			parent = headers[i-1]
+		}
+
+		if uint64(i) >= count || header.Number != parent.Number+1 || header.ParentHash != parent.Hash {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+			s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidBlock, "invalid headers while syncing")
+
+			return nil, fmt.Errorf("%w: invalid header %d", errInvalidHeaderChain, header.Number)
+		}
+	}
+
+	return headers, nil
+}
+
+// downloadBodies downloads the bodies of the headers in parallel ranges from the peers having them.
+// Each peer downloads one range at a time, so the faster peers download more ranges.
+// A range failing with a peer is downloaded again from another one, and the peer is added to the failed peers.
+// It returns the bodies along with the peers which served them
+func (s *syncer) downloadBodies(
+	headers []*types.Header,
+	failedPeers map[peer.ID]bool,
+) ([]*types.Body, []peer.ID, error) {
+	peers := s.downloadPeers(headers[len(headers)-1].Number, failedPeers)
+	if len(peers) == 0 {
+		return nil, nil, errBodiesNotFound
+	}
+
+	type bodyRange struct {
+		start, end int
+	}
+
+	// the channel is large enough for all the ranges, so the failed ranges are queued back without blocking
+	ranges := make(chan bodyRange, (len(headers)+maxBodiesRequest-1)/maxBodiesRequest)
+
+	for start := 0; start < len(headers); start += maxBodiesRequest {
+		end := start + maxBodiesRequest
+		if end > len(headers) {
+			end = len(headers)
+		}
+
+		ranges <- bodyRange{start: start, end: end}
+	}
+
+	var (
+		bodies    = make([]*types.Body, len(headers))
+		bodyPeers = make([]peer.ID, len(headers))
+		pending   = int64(len(ranges))
+		doneCh    = make(chan struct{})
+		wg        sync.WaitGroup
+		lock      sync.Mutex
+	)
+
+	for _, peerID := range peers {
+		peerID := peerID
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-doneCh:
+					return
+				case r := <-ranges:
+					if err := s.downloadBodyRange(peerID, headers[r.start:r.end], bodies[r.start:r.end]); err != nil {
+						s.logger.Debug("failed to download block bodies, try with another peer",
+							"peer", peerID, "from", headers[r.start].Number, "err", err)
+
+						ranges <- r
+
+						lock.Lock()
+						failedPeers[peerID] = true
+						lock.Unlock()
+
+						return
+					}
+
+					for i := r.start; i < r.end; i++ {
+						bodyPeers[i] = peerID
+					}
+
+					if atomic.AddInt64(&pending, -1) == 0 {
+						close(doneCh)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if atomic.LoadInt64(&pending) != 0 {
+		return nil, nil, errBodiesNotFound
+	}
+
+	return bodies, bodyPeers, nil
+}
+
+// downloadBodyRange downloads the bodies of the headers from the peer, and checks they match the headers
+func (s *syncer) downloadBodyRange(peerID peer.ID, headers []*types.Header, bodies []*types.Body) error {
+	hashes := make([]types.Hash, len(headers))
+	for i, header := range headers {
+		hashes[i] = header.Hash
+	}
+
+	start := time.Now()
+
+	downloaded, err := s.syncPeerClient.GetBodies(peerID, hashes)
+	if err != nil {
+		s.throughput.update(peerID, 0, time.Since(start))
+
+		return err
+	}
+
+	s.throughput.update(peerID, len(downloaded), time.Since(start))
+
+	if len(downloaded) != len(headers) {
+		return fmt.Errorf("peer returned %d bodies out of %d", len(downloaded), len(headers))
+	}
+
+	for i, body := range downloaded {
+		if !bodyMatchesHeader(body, headers[i]) {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+			s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidBlock, "invalid block body while syncing")
+
+			return fmt.Errorf("%w: block %d", errInvalidBody, headers[i].Number)
+		}
+	}
+
+	copy(bodies, downloaded)
+
+	return nil
+}
+
+// bodyMatchesHeader checks the transactions and uncles of the body match the roots of the header
+func bodyMatchesHeader(body *types.Body, header *types.Header) bool {
+	return buildroot.CalculateTransactionsRoot(body.Transactions) == header.TxRoot &&
+		buildroot.CalculateUncleRoot(body.Uncles) == header.Sha3Uncles
+}
+
+// downloadPeers returns the peers the blocks up to the number are downloaded from, the fastest ones first
+func (s *syncer) downloadPeers(number uint64, skipMap map[peer.ID]bool) []peer.ID {
+	peers := make([]*NoForkPeer, 0)
+
+	for _, p := range s.peerMap.PeersWithBlock(number) {
+		if !skipMap[p.ID] {
+			peers = append(peers, p)
+		}
+	}
+
+	s.throughput.sort(peers)
+
+	if len(peers) > maxDownloadPeers {
+		peers = peers[:maxDownloadPeers]
+	}
+
+	ids := make([]peer.ID, len(peers))
+	for i, p := range peers {
+		ids[i] = p.ID
+	}
+
+	return ids
+}
+
+// peerThroughput tracks the download throughput of the peers, in blocks per second.
+// Only the overall throughput of the tracked peers is exported as a metric,
+// to keep its cardinality bounded
+type peerThroughput struct {
+	lock  sync.RWMutex
+	rates map[peer.ID]float64
+}
+
+func newPeerThroughput() *peerThroughput {
+	return &peerThroughput{
+		rates: make(map[peer.ID]float64),
+	}
+}
+
+// update records the number of blocks downloaded from the peer in the elapsed time
+func (t *peerThroughput) update(peerID peer.ID, blocks int, elapsed time.Duration) {
+	if elapsed <= 0 {
+		elapsed = time.Millisecond
+	}
+
+	rate := float64(blocks) / elapsed.Seconds()
+
+	t.lock.Lock()
+
+	if prevRate, ok := t.rates[peerID]; ok {
+		rate = (1-throughputImpact)*prevRate + throughputImpact*rate
+	}
+
+	t.rates[peerID] = rate
+	t.updateMetrics()
+
+	t.lock.Unlock()
+}
+
+// get returns the throughput of the peer, if it's measured
+func (t *peerThroughput) get(peerID peer.ID) (float64, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	rate, ok := t.rates[peerID]
+
+	return rate, ok
+}
+
+// remove removes the throughput of the peer
+func (t *peerThroughput) remove(peerID peer.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.rates, peerID)
+	t.updateMetrics()
+}
+
+// updateMetrics exports the overall throughput of the tracked peers (lock must be held)
+func (t *peerThroughput) updateMetrics() {
+	total := float64(0)
+	for _, rate := range t.rates {
+		total += rate
+	}
+
+	metrics.SetGauge([]string{syncerMetrics, "throughput"}, float32(total))
+}
+
+// sort sorts the peers by descending throughput.
+// The peers whose throughput is not measured yet come first, so that it gets measured
+func (t *peerThroughput) sort(peers []*NoForkPeer) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		rateI, measuredI := t.rates[peers[i].ID]
+		rateJ, measuredJ := t.rates[peers[j].ID]
+
+		if measuredI != measuredJ {
+			return !measuredI
+		}
+
+		return rateI > rateJ
+	})
+}
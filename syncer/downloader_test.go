@@ -0,0 +1,297 @@
+package syncer
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createChainedBlocks creates the blocks following the genesis, with a transaction each
+func createChainedBlocks(genesis *types.Header, num int) []*types.Block {
+	blocks := make([]*types.Block, num)
+	parent := genesis
+
+	for i := 0; i < num; i++ {
+		txs := []*types.Transaction{
+			(&types.Transaction{Nonce: uint64(i), Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(1)}).ComputeHash(),
+		}
+
+		header := &types.Header{
+			Number:     parent.Number + 1,
+			ParentHash: parent.Hash,
+			TxRoot:     buildroot.CalculateTransactionsRoot(txs),
+			Sha3Uncles: types.EmptyUncleHash,
+		}
+		header.ComputeHash()
+
+		blocks[i] = &types.Block{Header: header, Transactions: txs}
+		parent = header
+	}
+
+	return blocks
+}
+
+// newBlockServingClient returns a client serving the headers and bodies of the blocks.
+// The bodies served by the invalid peers don't match the headers
+func newBlockServingClient(
+	blocks []*types.Block,
+	delay time.Duration,
+	invalidPeers map[peer.ID]bool,
+) (*mockSyncPeerClient, func() map[peer.ID]int) {
+	var (
+		lock   sync.Mutex
+		served = make(map[peer.ID]int)
+		byHash = make(map[types.Hash]*types.Block)
+	)
+
+	for _, block := range blocks {
+		byHash[block.Hash()] = block
+	}
+
+	client := &mockSyncPeerClient{
+		getHeadersHandler: func(_ peer.ID, from, count uint64) ([]*types.Header, error) {
+			headers := make([]*types.Header, 0, count)
+			for i := from; i <= uint64(len(blocks)) && uint64(len(headers)) < count; i++ {
+				headers = append(headers, blocks[i-1].Header)
+			}
+
+			return headers, nil
+		},
+		getBodiesHandler: func(id peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+			time.Sleep(delay)
+
+			bodies := make([]*types.Body, len(hashes))
+			for i, hash := range hashes {
+				bodies[i] = byHash[hash].Body()
+				if invalidPeers[id] {
+					bodies[i] = &types.Body{}
+				}
+			}
+
+			lock.Lock()
+			served[id] += len(bodies)
+			lock.Unlock()
+
+			return bodies, nil
+		},
+	}
+
+	return client, func() map[peer.ID]int {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return served
+	}
+}
+
+func newDownloadPeers(number uint64, ids ...peer.ID) []*NoForkPeer {
+	peers := make([]*NoForkPeer, len(ids))
+	for i, id := range ids {
+		peers[i] = &NoForkPeer{ID: id, Number: number, Distance: big.NewInt(int64(i))}
+	}
+
+	return peers
+}
+
+func assertWrittenInOrder(t *testing.T, written []uint64, num int) {
+	t.Helper()
+
+	require.Len(t, written, num)
+
+	for i, number := range written {
+		assert.Equal(t, uint64(i+1), number)
+	}
+}
+
+func Test_downloadBlocks(t *testing.T) {
+	t.Parallel()
+
+	genesis := (&types.Header{Number: 0}).ComputeHash()
+	blocks := createChainedBlocks(genesis, maxHeadersRequest+100)
+	latest := uint64(len(blocks))
+
+	t.Run("should download the bodies from multiple peers", func(t *testing.T) {
+		t.Parallel()
+
+		client, served := newBlockServingClient(blocks, 10*time.Millisecond, nil)
+		peers := newDownloadPeers(latest, "A", "B", "C")
+		// D doesn't have the blocks yet
+		peers = append(peers, &NoForkPeer{ID: "D", Number: 10, Distance: big.NewInt(0)})
+
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.peerMap.Put(peers...)
+
+		lastNumber, shouldTerminate, err := syncer.downloadBlocks(peers[0], func(*types.FullBlock) bool {
+			return false
+		})
+		require.NoError(t, err)
+		assert.Equal(t, latest, lastNumber)
+		assert.False(t, shouldTerminate)
+
+		assertWrittenInOrder(t, chain.written, len(blocks))
+
+		assert.Greater(t, len(served()), 1)
+		assert.NotContains(t, served(), peer.ID("D"))
+
+		for id := range served() {
+			_, measured := syncer.throughput.get(id)
+			assert.True(t, measured)
+		}
+	})
+
+	t.Run("should download the invalid bodies again from another peer", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newBlockServingClient(blocks, 10*time.Millisecond, map[peer.ID]bool{"B": true})
+		peers := newDownloadPeers(latest, "A", "B")
+
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.peerMap.Put(peers...)
+
+		lastNumber, _, err := syncer.downloadBlocks(peers[0], func(*types.FullBlock) bool {
+			return false
+		})
+		require.NoError(t, err)
+		assert.Equal(t, latest, lastNumber)
+
+		assertWrittenInOrder(t, chain.written, len(blocks))
+		assert.Equal(t, []peer.ID{"B"}, client.reportedPeers)
+	})
+
+	t.Run("should fail if no peer serves valid bodies", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newBlockServingClient(blocks, 0, map[peer.ID]bool{"A": true, "B": true})
+		peers := newDownloadPeers(latest, "A", "B")
+
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.peerMap.Put(peers...)
+
+		lastNumber, _, err := syncer.downloadBlocks(peers[0], func(*types.FullBlock) bool {
+			return false
+		})
+		assert.ErrorIs(t, err, errBodiesNotFound)
+		assert.Equal(t, uint64(0), lastNumber)
+		assert.Empty(t, chain.written)
+	})
+
+	t.Run("should report the peer sending headers not chained", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newBlockServingClient(blocks, 0, nil)
+		client.getHeadersHandler = func(_ peer.ID, from, count uint64) ([]*types.Header, error) {
+			return []*types.Header{blocks[0].Header, blocks[2].Header}, nil
+		}
+
+		peers := newDownloadPeers(latest, "A")
+
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.peerMap.Put(peers...)
+
+		_, _, err := syncer.downloadBlocks(peers[0], func(*types.FullBlock) bool {
+			return false
+		})
+		assert.ErrorIs(t, err, errInvalidHeaderChain)
+		assert.Equal(t, []peer.ID{"A"}, client.reportedPeers)
+		assert.Empty(t, chain.written)
+	})
+
+	t.Run("should report the peer serving the invalid part of the block", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name     string
+			err      error
+			reported []peer.ID
+		}{
+			{"invalid header", blockchain.ErrInvalidStateRoot, []peer.ID{"A"}},
+			{"header rejected by consensus", blockchain.ErrInvalidHeader, []peer.ID{"A"}},
+			{"invalid body", blockchain.ErrInvalidTxRoot, []peer.ID{"B"}},
+			{"local failure", blockchain.ErrParentNotFound, nil},
+		}
+
+		for _, test := range tests {
+			test := test
+
+			t.Run(test.name, func(t *testing.T) {
+				t.Parallel()
+
+				client, _ := newBlockServingClient(blocks, 0, nil)
+				// A serves the headers and B the bodies
+				peers := newDownloadPeers(latest, "A", "B")
+
+				syncer, chain := newChainTestSyncer(genesis, client)
+				syncer.peerMap.Put(peers...)
+				syncer.peerMap.Remove("A")
+
+				syncer.blockchain.(*mockBlockchain).verifyFinalizedBlockHandler = func(*types.Block) (*types.FullBlock, error) {
+					return nil, test.err
+				}
+
+				_, _, err := syncer.downloadBlocks(peers[0], func(*types.FullBlock) bool {
+					return false
+				})
+				assert.ErrorIs(t, err, test.err)
+				assert.Equal(t, test.reported, client.reportedPeers)
+				assert.Empty(t, chain.written)
+			})
+		}
+	})
+
+	t.Run("should stop after the batch once the callback returns true", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newBlockServingClient(blocks, 0, nil)
+		peers := newDownloadPeers(latest, "A")
+
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.peerMap.Put(peers...)
+
+		lastNumber, shouldTerminate, err := syncer.downloadBlocks(peers[0], func(b *types.FullBlock) bool {
+			return b.Block.Number() == 10
+		})
+		require.NoError(t, err)
+		assert.True(t, shouldTerminate)
+		assert.Equal(t, uint64(maxHeadersRequest), lastNumber)
+
+		assertWrittenInOrder(t, chain.written, maxHeadersRequest)
+	})
+}
+
+func Test_peerThroughput(t *testing.T) {
+	t.Parallel()
+
+	throughput := newPeerThroughput()
+
+	throughput.update("A", 10, time.Second)
+	throughput.update("B", 20, time.Second)
+
+	rate, measured := throughput.get("A")
+	assert.True(t, measured)
+	assert.Equal(t, float64(10), rate)
+
+	// a failed request lowers the throughput
+	throughput.update("A", 0, time.Second)
+
+	rate, _ = throughput.get("A")
+	assert.InDelta(t, 10*(1-throughputImpact), rate, 1e-9)
+
+	// the peers not measured yet come first, then the fastest ones
+	peers := newDownloadPeers(100, "A", "B", "C")
+	throughput.sort(peers)
+
+	assert.Equal(t, []peer.ID{"C", "B", "A"}, []peer.ID{peers[0].ID, peers[1].ID, peers[2].ID})
+
+	throughput.remove("B")
+
+	_, measured = throughput.get("B")
+	assert.False(t, measured)
+}
@@ -67,3 +67,18 @@ func (m *PeerMap) BestPeer(skipMap map[peer.ID]bool) *NoForkPeer {
 
 	return bestPeer
 }
+
+// PeersWithBlock returns the peers whose latest block is at least the given number
+func (m *PeerMap) PeersWithBlock(number uint64) []*NoForkPeer {
+	peers := make([]*NoForkPeer, 0)
+
+	m.Range(func(key, value interface{}) bool {
+		if peer, _ := value.(*NoForkPeer); peer.Number >= number {
+			peers = append(peers, peer)
+		}
+
+		return true
+	})
+
+	return peers
+}
@@ -160,3 +160,13 @@ func TestBestPeer(t *testing.T) {
 		})
 	}
 }
+
+func TestPeersWithBlock(t *testing.T) {
+	t.Parallel()
+
+	peerMap := NewPeerMap(peers)
+
+	assert.ElementsMatch(t, peers, peerMap.PeersWithBlock(10))
+	assert.ElementsMatch(t, peers[1:], peerMap.PeersWithBlock(20))
+	assert.Empty(t, peerMap.PeersWithBlock(21))
+}
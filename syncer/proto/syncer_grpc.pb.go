@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.7
+// source: syncer/proto/syncer.proto
 
 package proto
 
@@ -12,6 +16,7 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
 // SyncPeerClient is the client API for SyncPeer service.
@@ -22,6 +27,16 @@ type SyncPeerClient interface {
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns the headers of the consecutive blocks beginning from specified height
+	GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (*Headers, error)
+	// Returns the bodies of the blocks of the given hashes
+	GetBodies(ctx context.Context, in *GetBodiesRequest, opts ...grpc.CallOption) (*BlockBodies, error)
+	// Returns the entries of a state or storage trie from the origin, along with their proof
+	GetStateRange(ctx context.Context, in *GetStateRangeRequest, opts ...grpc.CallOption) (*StateRange, error)
+	// Returns the trie nodes of the given hashes
+	GetTrieNodes(ctx context.Context, in *GetStateItemsRequest, opts ...grpc.CallOption) (*StateItems, error)
+	// Returns the contract codes of the given hashes
+	GetByteCodes(ctx context.Context, in *GetStateItemsRequest, opts ...grpc.CallOption) (*StateItems, error)
 }
 
 type syncPeerClient struct {
@@ -33,7 +48,7 @@ func NewSyncPeerClient(cc grpc.ClientConnInterface) SyncPeerClient {
 }
 
 func (c *syncPeerClient) GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[0], "/v1.SyncPeer/GetBlocks", opts...)
+	stream, err := c.cc.NewStream(ctx, &SyncPeer_ServiceDesc.Streams[0], "/v1.SyncPeer/GetBlocks", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +88,51 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (*Headers, error) {
+	out := new(Headers)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetHeaders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetBodies(ctx context.Context, in *GetBodiesRequest, opts ...grpc.CallOption) (*BlockBodies, error) {
+	out := new(BlockBodies)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetBodies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetStateRange(ctx context.Context, in *GetStateRangeRequest, opts ...grpc.CallOption) (*StateRange, error) {
+	out := new(StateRange)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetStateRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetTrieNodes(ctx context.Context, in *GetStateItemsRequest, opts ...grpc.CallOption) (*StateItems, error) {
+	out := new(StateItems)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetTrieNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetByteCodes(ctx context.Context, in *GetStateItemsRequest, opts ...grpc.CallOption) (*StateItems, error) {
+	out := new(StateItems)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetByteCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
@@ -81,6 +141,16 @@ type SyncPeerServer interface {
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns the headers of the consecutive blocks beginning from specified height
+	GetHeaders(context.Context, *GetHeadersRequest) (*Headers, error)
+	// Returns the bodies of the blocks of the given hashes
+	GetBodies(context.Context, *GetBodiesRequest) (*BlockBodies, error)
+	// Returns the entries of a state or storage trie from the origin, along with their proof
+	GetStateRange(context.Context, *GetStateRangeRequest) (*StateRange, error)
+	// Returns the trie nodes of the given hashes
+	GetTrieNodes(context.Context, *GetStateItemsRequest) (*StateItems, error)
+	// Returns the contract codes of the given hashes
+	GetByteCodes(context.Context, *GetStateItemsRequest) (*StateItems, error)
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +164,21 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetHeaders(context.Context, *GetHeadersRequest) (*Headers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (UnimplementedSyncPeerServer) GetBodies(context.Context, *GetBodiesRequest) (*BlockBodies, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBodies not implemented")
+}
+func (UnimplementedSyncPeerServer) GetStateRange(context.Context, *GetStateRangeRequest) (*StateRange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateRange not implemented")
+}
+func (UnimplementedSyncPeerServer) GetTrieNodes(context.Context, *GetStateItemsRequest) (*StateItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrieNodes not implemented")
+}
+func (UnimplementedSyncPeerServer) GetByteCodes(context.Context, *GetStateItemsRequest) (*StateItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByteCodes not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -104,7 +189,7 @@ type UnsafeSyncPeerServer interface {
 }
 
 func RegisterSyncPeerServer(s grpc.ServiceRegistrar, srv SyncPeerServer) {
-	s.RegisterService(&_SyncPeer_serviceDesc, srv)
+	s.RegisterService(&SyncPeer_ServiceDesc, srv)
 }
 
 func _SyncPeer_GetBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
@@ -146,7 +231,100 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
-var _SyncPeer_serviceDesc = grpc.ServiceDesc{
+func _SyncPeer_GetHeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetHeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetHeaders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetHeaders(ctx, req.(*GetHeadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetBodies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBodiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetBodies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetBodies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetBodies(ctx, req.(*GetBodiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetStateRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetStateRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetStateRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetStateRange(ctx, req.(*GetStateRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetTrieNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetTrieNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetTrieNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetTrieNodes(ctx, req.(*GetStateItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetByteCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetByteCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetByteCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetByteCodes(ctx, req.(*GetStateItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SyncPeer_ServiceDesc is the grpc.ServiceDesc for SyncPeer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SyncPeer_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -154,6 +332,26 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _SyncPeer_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetHeaders",
+			Handler:    _SyncPeer_GetHeaders_Handler,
+		},
+		{
+			MethodName: "GetBodies",
+			Handler:    _SyncPeer_GetBodies_Handler,
+		},
+		{
+			MethodName: "GetStateRange",
+			Handler:    _SyncPeer_GetStateRange_Handler,
+		},
+		{
+			MethodName: "GetTrieNodes",
+			Handler:    _SyncPeer_GetTrieNodes_Handler,
+		},
+		{
+			MethodName: "GetByteCodes",
+			Handler:    _SyncPeer_GetByteCodes_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/0xPolygon/polygon-edge/network/grpc"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
@@ -12,24 +13,31 @@ import (
 )
 
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound      = errors.New("block not found")
+	ErrTooManyBlocks      = errors.New("too many blocks requested")
+	ErrStateNotAvailable  = errors.New("state not available")
+	ErrInvalidStateOrigin = errors.New("invalid state range origin")
+	ErrTooManyStateItems  = errors.New("too many state items requested")
 )
 
 type syncPeerService struct {
 	proto.UnimplementedSyncPeerServer
 
-	blockchain Blockchain       // reference to the blockchain module
-	network    Network          // reference to the network module
-	stream     *grpc.GrpcStream // reference to the grpc stream
+	blockchain   Blockchain       // reference to the blockchain module
+	network      Network          // reference to the network module
+	stateStorage itrie.Storage    // reference to the state storage, served to the syncing peers
+	stream       *grpc.GrpcStream // reference to the grpc stream
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 ) SyncPeerService {
 	return &syncPeerService{
-		blockchain: blockchain,
-		network:    network,
+		blockchain:   blockchain,
+		network:      network,
+		stateStorage: stateStorage,
 	}
 }
 
@@ -91,6 +99,173 @@ func (s *syncPeerService) GetStatus(
 	}, nil
 }
 
+// GetHeaders is a gRPC endpoint to return the headers of the consecutive blocks from the specific height
+func (s *syncPeerService) GetHeaders(
+	ctx context.Context,
+	req *proto.GetHeadersRequest,
+) (*proto.Headers, error) {
+	count := req.Count
+	if count > maxHeadersRequest {
+		count = maxHeadersRequest
+	}
+
+	headers := make([][]byte, 0, count)
+
+	for i := req.From; i <= s.blockchain.Header().Number && uint64(len(headers)) < count; i++ {
+		block, ok := s.blockchain.GetBlockByNumber(i, false)
+		if !ok {
+			break
+		}
+
+		headers = append(headers, block.Header.MarshalRLP())
+	}
+
+	return &proto.Headers{
+		Headers: headers,
+	}, nil
+}
+
+// GetBodies is a gRPC endpoint to return the bodies of the blocks of the given hashes,
+// up to the first missing block
+func (s *syncPeerService) GetBodies(
+	ctx context.Context,
+	req *proto.GetBodiesRequest,
+) (*proto.BlockBodies, error) {
+	if len(req.Hashes) > maxBodiesRequest {
+		return nil, ErrTooManyBlocks
+	}
+
+	bodies := make([]*proto.BlockBody, 0, len(req.Hashes))
+
+	for _, hash := range req.Hashes {
+		body, ok := s.blockchain.GetBodyByHash(types.BytesToHash(hash))
+		if !ok {
+			break
+		}
+
+		bodies = append(bodies, toProtoBody(body))
+	}
+
+	return &proto.BlockBodies{
+		Bodies: bodies,
+	}, nil
+}
+
+// GetStateRange is a gRPC endpoint to return the entries of a state or storage trie from the origin,
+// along with the proofs of the origin and of the last entry
+func (s *syncPeerService) GetStateRange(
+	ctx context.Context,
+	req *proto.GetStateRangeRequest,
+) (*proto.StateRange, error) {
+	if s.stateStorage == nil {
+		return nil, ErrStateNotAvailable
+	}
+
+	if len(req.Origin) != types.HashLength {
+		return nil, ErrInvalidStateOrigin
+	}
+
+	root := types.BytesToHash(req.Root)
+
+	keys, values, err := itrie.ReadRange(root, req.Origin, maxStateRangeBytes, s.stateStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := itrie.Prove(root, req.Origin, s.stateStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) > 0 {
+		lastProof, err := itrie.Prove(root, keys[len(keys)-1], s.stateStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		proof = append(proof, lastProof...)
+	}
+
+	return &proto.StateRange{
+		Keys:   keys,
+		Values: values,
+		Proof:  proof,
+	}, nil
+}
+
+// GetTrieNodes is a gRPC endpoint to return the trie nodes of the given hashes
+func (s *syncPeerService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.GetStateItemsRequest,
+) (*proto.StateItems, error) {
+	return s.getStateItems(req, func(hash types.Hash) ([]byte, bool) {
+		return s.stateStorage.Get(hash.Bytes())
+	})
+}
+
+// GetByteCodes is a gRPC endpoint to return the contract codes of the given hashes
+func (s *syncPeerService) GetByteCodes(
+	ctx context.Context,
+	req *proto.GetStateItemsRequest,
+) (*proto.StateItems, error) {
+	return s.getStateItems(req, func(hash types.Hash) ([]byte, bool) {
+		return s.stateStorage.GetCode(hash)
+	})
+}
+
+// getStateItems returns the state items of the requested hashes, empty if not found.
+// Only the first items are returned once their size reaches the limit
+func (s *syncPeerService) getStateItems(
+	req *proto.GetStateItemsRequest,
+	getItem func(types.Hash) ([]byte, bool),
+) (*proto.StateItems, error) {
+	if s.stateStorage == nil {
+		return nil, ErrStateNotAvailable
+	}
+
+	if len(req.Hashes) > maxStateItems {
+		return nil, ErrTooManyStateItems
+	}
+
+	var (
+		items = make([][]byte, 0, len(req.Hashes))
+		size  = 0
+	)
+
+	for _, hash := range req.Hashes {
+		if size >= maxStateItemsBytes {
+			break
+		}
+
+		item, _ := getItem(types.BytesToHash(hash))
+
+		items = append(items, item)
+		size += len(item)
+	}
+
+	return &proto.StateItems{
+		Items: items,
+	}, nil
+}
+
+// toProtoBody converts type.Body -> proto.BlockBody
+func toProtoBody(body *types.Body) *proto.BlockBody {
+	protoBody := &proto.BlockBody{
+		Transactions: make([][]byte, len(body.Transactions)),
+		Uncles:       make([][]byte, len(body.Uncles)),
+	}
+
+	for i, tx := range body.Transactions {
+		protoBody.Transactions[i] = tx.MarshalRLP()
+	}
+
+	for i, uncle := range body.Uncles {
+		protoBody.Uncles[i] = uncle.MarshalRLP()
+	}
+
+	return protoBody
+}
+
 // toProtoBlock converts type.Block -> proto.Block
 func toProtoBlock(block *types.Block) *proto.Block {
 	return &proto.Block{
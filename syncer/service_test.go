@@ -4,12 +4,17 @@ import (
 	"context"
 	"io"
 	"log"
+	"math/big"
 	"net"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
@@ -136,6 +141,106 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 	}
 }
 
+func TestGetHeaders(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(10)
+
+	client := newMockGrpcClient(t, &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(10),
+			getBlockByNumberHandler: func(u uint64, _ bool) (*types.Block, bool) {
+				if u == 0 || u > uint64(len(blocks)) {
+					return nil, false
+				}
+
+				return blocks[u-1], true
+			},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		from     uint64
+		count    uint64
+		expected []*types.Block
+	}{
+		{
+			name:     "should return the requested headers",
+			from:     3,
+			count:    4,
+			expected: blocks[2:6],
+		},
+		{
+			name:     "should return the headers up to the latest block",
+			from:     8,
+			count:    10,
+			expected: blocks[7:],
+		},
+		{
+			name:     "should return no header above the latest block",
+			from:     11,
+			count:    10,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			headers, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{
+				From:  test.from,
+				Count: test.count,
+			})
+			require.NoError(t, err)
+			require.Len(t, headers.Headers, len(test.expected))
+
+			for i, block := range test.expected {
+				assert.Equal(t, block.Header.MarshalRLP(), headers.Headers[i])
+			}
+		})
+	}
+}
+
+func TestGetBodies(t *testing.T) {
+	t.Parallel()
+
+	body := &types.Body{
+		Transactions: []*types.Transaction{
+			(&types.Transaction{Nonce: 1, Value: big.NewInt(10), Gas: 21000, GasPrice: big.NewInt(1)}).ComputeHash(),
+		},
+	}
+	known := types.StringToHash("1")
+
+	client := newMockGrpcClient(t, &syncPeerService{
+		blockchain: &mockBlockchain{
+			getBodyByHashHandler: func(hash types.Hash) (*types.Body, bool) {
+				return body, hash == known
+			},
+		},
+	})
+
+	// the bodies are returned up to the first missing block
+	bodies, err := client.GetBodies(context.Background(), &proto.GetBodiesRequest{
+		Hashes: [][]byte{known.Bytes(), known.Bytes(), types.StringToHash("2").Bytes(), known.Bytes()},
+	})
+	require.NoError(t, err)
+	require.Len(t, bodies.Bodies, 2)
+
+	received, err := fromProtoBody(bodies.Bodies[0])
+	require.NoError(t, err)
+	require.Len(t, received.Transactions, 1)
+	assert.Equal(t, body.Transactions[0].Hash, received.Transactions[0].Hash)
+
+	_, err = client.GetBodies(context.Background(), &proto.GetBodiesRequest{
+		Hashes: make([][]byte, maxBodiesRequest+1),
+	})
+	assert.ErrorContains(t, err, ErrTooManyBlocks.Error())
+}
+
 func TestGetStatus(t *testing.T) {
 	t.Parallel()
 
@@ -154,3 +259,109 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+// newTestState commits accounts, some of them with code and storage, to a new state storage
+// and returns it along with the state root
+func newTestState(t *testing.T, accounts int) (itrie.Storage, types.Hash, []*state.Object) {
+	t.Helper()
+
+	objs := make([]*state.Object, 0, accounts)
+
+	for i := 0; i < accounts; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i + 1)),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%3 == 0 {
+			obj.Code = []byte{0x60, byte(i), 0x00}
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(obj.Code))
+			obj.DirtyCode = true
+
+			for j := 0; j < 10; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j)).Bytes()).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(i*j + 1)).Bytes()).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	storage := itrie.NewMemoryStorage()
+	_, root := itrie.NewState(storage).NewSnapshot().Commit(objs)
+
+	return storage, types.BytesToHash(root), objs
+}
+
+func TestGetStateRange(t *testing.T) {
+	t.Parallel()
+
+	storage, root, objs := newTestState(t, 20)
+	origin := make([]byte, types.HashLength)
+
+	client := newMockGrpcClient(t, &syncPeerService{
+		stateStorage: storage,
+	})
+
+	stateRange, err := client.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin,
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, stateRange.Keys, len(objs))
+
+	more, err := itrie.VerifyRangeProof(root, origin, stateRange.Keys, stateRange.Values, stateRange.Proof, nil)
+	require.NoError(t, err)
+	assert.False(t, more)
+
+	_, err = client.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root:   root.Bytes(),
+		Origin: []byte{0x1},
+	})
+	assert.ErrorContains(t, err, ErrInvalidStateOrigin.Error())
+
+	// the state is not served without a state storage
+	client = newMockGrpcClient(t, &syncPeerService{})
+
+	_, err = client.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin,
+	})
+	assert.ErrorContains(t, err, ErrStateNotAvailable.Error())
+}
+
+func TestGetStateItems(t *testing.T) {
+	t.Parallel()
+
+	storage, root, objs := newTestState(t, 20)
+
+	client := newMockGrpcClient(t, &syncPeerService{
+		stateStorage: storage,
+	})
+
+	rootNode, ok := storage.Get(root.Bytes())
+	require.True(t, ok)
+
+	nodes, err := client.GetTrieNodes(context.Background(), toProtoStateItemsRequest([]types.Hash{
+		root,
+		types.StringToHash("1"),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{rootNode, {}}, nodes.Items)
+
+	codes, err := client.GetByteCodes(context.Background(), toProtoStateItemsRequest([]types.Hash{
+		objs[0].CodeHash,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{objs[0].Code}, codes.Items)
+
+	_, err = client.GetTrieNodes(context.Background(), toProtoStateItemsRequest(
+		make([]types.Hash, maxStateItems+1),
+	))
+	assert.ErrorContains(t, err, ErrTooManyStateItems.Error())
+}
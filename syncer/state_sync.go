@@ -0,0 +1,404 @@
+package syncer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// pivotDistance is the distance of the pivot block, whose state is synced,
+	// to the latest block of the peer
+	pivotDistance = 64
+	// maxStateRangeBytes is the maximum size of the entries of a state range served to a peer
+	maxStateRangeBytes = 512 * 1024
+	// maxStateItems is the maximum number of trie nodes or codes requested at once
+	maxStateItems = 384
+	// maxStateItemsBytes is the maximum size of the trie nodes or codes served to a peer at once,
+	// the peer requests the remaining ones again
+	maxStateItemsBytes = 1024 * 1024
+)
+
+var (
+	errMissingStateItem = errors.New("peer doesn't have the requested state item")
+	errInvalidStateItem = errors.New("state item doesn't match its hash")
+	errEndOfKeySpace    = errors.New("state range can't continue past the last key")
+)
+
+// StateRange contains the consecutive entries of a trie from an origin,
+// along with the proof of the origin and of the last entry
+type StateRange struct {
+	Keys   [][]byte
+	Values [][]byte
+	Proof  [][]byte
+}
+
+// snapSyncWithPeer bootstraps the node from the state of a recent block of the peer,
+// instead of executing all the blocks from the genesis.
+//
+// The blocks up to the pivot block are verified and written without being executed,
+// then the state at the pivot block is synced, after which the blocks are synced as usual.
+// It does nothing if the state of the latest block is already present
+func (s *syncer) snapSyncWithPeer(bestPeer *NoForkPeer) error {
+	header := s.blockchain.Header()
+
+	// the blocks are written without execution only while the state is missing,
+	// which is also the case after an interrupted snap sync
+	if (header.Number == 0 || !s.hasState(header.StateRoot)) && bestPeer.Number > header.Number+pivotDistance {
+		if err := s.writeBlocksToPivot(bestPeer.ID, bestPeer.Number-pivotDistance); err != nil {
+			return err
+		}
+
+		header = s.blockchain.Header()
+	}
+
+	if s.hasState(header.StateRoot) {
+		return nil
+	}
+
+	s.logger.Info("syncing state", "number", header.Number, "root", header.StateRoot, "peer", bestPeer.ID)
+
+	if err := s.syncState(bestPeer.ID, header.StateRoot); err != nil {
+		return fmt.Errorf("failed to sync state at block %d: %w", header.Number, err)
+	}
+
+	s.logger.Info("state synced", "number", header.Number, "root", header.StateRoot)
+
+	return nil
+}
+
+// hasState checks if the state of the root is present
+func (s *syncer) hasState(root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok := s.stateStorage.Get(root.Bytes())
+
+	return ok
+}
+
+// writeBlocksToPivot verifies and writes the blocks of the peer up to the pivot block,
+// without executing them. Their receipts are not available
+func (s *syncer) writeBlocksToPivot(peerID peer.ID, pivot uint64) error {
+	localLatest := s.blockchain.Header().Number
+
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, localLatest+1, s.blockTimeout)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	for {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				return fmt.Errorf("peer stopped sending blocks before the pivot block %d", pivot)
+			}
+
+			// safe check
+			if block.Number() == 0 {
+				continue
+			}
+
+			fullBlock, err := s.blockchain.VerifyFinalizedBlockWithoutExecution(block)
+			if err != nil {
+				s.reportInvalidBlock(err, peerID, peerID)
+
+				return fmt.Errorf("unable to verify block, %w", err)
+			}
+
+			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+				return fmt.Errorf("failed to write block while snap syncing: %w", err)
+			}
+
+			if block.Number() >= pivot {
+				return nil
+			}
+		case <-time.After(s.blockTimeout):
+			return errTimeout
+		}
+	}
+}
+
+// syncState syncs the state of the root from the peer.
+// The accounts, along with their storage and code, are synced range by range,
+// then the nodes missed by the ranges are healed
+func (s *syncer) syncState(peerID peer.ID, root types.Hash) error {
+	storage := &snapStorage{Storage: s.stateStorage, root: root}
+	codes := make(map[types.Hash]struct{})
+
+	err := s.syncTrie(peerID, root, storage, func(value []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("cant parse account: %w", err)
+		}
+
+		// storage tries shared by several accounts are synced once
+		if account.Root != types.EmptyRootHash {
+			if _, ok := storage.Get(account.Root.Bytes()); !ok {
+				if err := s.syncTrie(peerID, account.Root, storage, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		codeHash := types.BytesToHash(account.CodeHash)
+		if len(account.CodeHash) == 0 || codeHash == types.EmptyCodeHash {
+			return nil
+		}
+
+		if _, ok := storage.GetCode(codeHash); !ok {
+			codes[codeHash] = struct{}{}
+		}
+
+		if len(codes) < maxStateItems {
+			return nil
+		}
+
+		err := s.syncCodes(peerID, codeHashes(codes), storage)
+		codes = make(map[types.Hash]struct{})
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.syncCodes(peerID, codeHashes(codes), storage); err != nil {
+		return err
+	}
+
+	if err := s.healState(peerID, storage); err != nil {
+		return err
+	}
+
+	return storage.commit()
+}
+
+// syncTrie syncs the trie of the root from the peer range by range,
+// calling onValue for every value of the trie, if set
+func (s *syncer) syncTrie(
+	peerID peer.ID,
+	root types.Hash,
+	storage *snapStorage,
+	onValue func([]byte) error,
+) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	origin := make([]byte, types.HashLength)
+
+	for {
+		stateRange, err := s.syncPeerClient.GetStateRange(peerID, root, origin)
+		if err != nil {
+			return fmt.Errorf("failed to get state range: %w", err)
+		}
+
+		more, err := itrie.VerifyRangeProof(
+			root,
+			origin,
+			stateRange.Keys,
+			stateRange.Values,
+			stateRange.Proof,
+			storage,
+		)
+		if err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_state"}, 1)
+			s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidState, "invalid state range while syncing")
+
+			return fmt.Errorf("unable to verify state range of root %s: %w", root, err)
+		}
+
+		if onValue != nil {
+			for _, value := range stateRange.Values {
+				if err := onValue(value); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !more {
+			return nil
+		}
+
+		if len(stateRange.Keys) == 0 {
+			return fmt.Errorf("peer returned an empty state range of root %s", root)
+		}
+
+		if origin, err = nextKey(stateRange.Keys[len(stateRange.Keys)-1]); err != nil {
+			return err
+		}
+	}
+}
+
+// syncCodes syncs the contract codes of the hashes from the peer
+func (s *syncer) syncCodes(peerID peer.ID, hashes []types.Hash, storage *snapStorage) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	codes, err := s.getStateItems(peerID, hashes, s.syncPeerClient.GetByteCodes)
+	if err != nil {
+		return fmt.Errorf("failed to get codes: %w", err)
+	}
+
+	for i, code := range codes {
+		storage.SetCode(hashes[i], code)
+	}
+
+	return nil
+}
+
+// healState syncs the trie nodes and codes which are still missing in the state of the storage,
+// such as the nodes of the storage tries partially synced by an interrupted sync
+func (s *syncer) healState(peerID peer.ID, storage *snapStorage) error {
+	for {
+		missingNodes, missingCodes, err := itrie.FindMissingNodes(storage.root, storage, maxStateItems)
+		if err != nil {
+			return err
+		}
+
+		if len(missingNodes) == 0 && len(missingCodes) == 0 {
+			return nil
+		}
+
+		s.logger.Debug("healing state", "nodes", len(missingNodes), "codes", len(missingCodes))
+
+		if len(missingNodes) > 0 {
+			nodes, err := s.getStateItems(peerID, missingNodes, s.syncPeerClient.GetTrieNodes)
+			if err != nil {
+				return fmt.Errorf("failed to get trie nodes: %w", err)
+			}
+
+			for i, node := range nodes {
+				storage.Put(missingNodes[i].Bytes(), node)
+			}
+		}
+
+		if err := s.syncCodes(peerID, missingCodes, storage); err != nil {
+			return err
+		}
+	}
+}
+
+// getStateItems requests the state items of the hashes from the peer, and verifies them.
+// The items not served at once because of their size are requested again
+func (s *syncer) getStateItems(
+	peerID peer.ID,
+	hashes []types.Hash,
+	request func(peer.ID, []types.Hash) ([][]byte, error),
+) ([][]byte, error) {
+	items := make([][]byte, 0, len(hashes))
+
+	for len(items) < len(hashes) {
+		remaining := hashes[len(items):]
+
+		served, err := request(peerID, remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(served) == 0 || len(served) > len(remaining) {
+			s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidState, "invalid state items while syncing")
+
+			return nil, fmt.Errorf("%w: %d items for %d hashes", errInvalidStateItem, len(served), len(remaining))
+		}
+
+		for i, item := range served {
+			if len(item) == 0 {
+				return nil, fmt.Errorf("%w: %s", errMissingStateItem, remaining[i])
+			}
+
+			if types.BytesToHash(crypto.Keccak256(item)) != remaining[i] {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_state"}, 1)
+				s.syncPeerClient.ReportPeer(peerID, common.PenaltyInvalidState, "invalid state items while syncing")
+
+				return nil, fmt.Errorf("%w: %s", errInvalidStateItem, remaining[i])
+			}
+		}
+
+		items = append(items, served...)
+	}
+
+	return items, nil
+}
+
+// snapStorage is the state storage while the state of the root is synced.
+// The root node is held back until all the other nodes are synced,
+// so that the state is only found in the storage once it is complete
+type snapStorage struct {
+	itrie.Storage
+
+	root     types.Hash
+	rootNode []byte
+}
+
+func (s *snapStorage) Put(k, v []byte) {
+	if bytes.Equal(k, s.root.Bytes()) {
+		s.rootNode = append([]byte{}, v...)
+
+		return
+	}
+
+	s.Storage.Put(k, v)
+}
+
+func (s *snapStorage) Get(k []byte) ([]byte, bool) {
+	if s.rootNode != nil && bytes.Equal(k, s.root.Bytes()) {
+		return s.rootNode, true
+	}
+
+	return s.Storage.Get(k)
+}
+
+// commit writes the root node, which completes the synced state
+func (s *snapStorage) commit() error {
+	if s.rootNode == nil {
+		return fmt.Errorf("root node of state %s not synced", s.root)
+	}
+
+	s.Storage.Put(s.root.Bytes(), s.rootNode)
+
+	return nil
+}
+
+// nextKey returns the key following the given one
+func nextKey(key []byte) ([]byte, error) {
+	next := append([]byte{}, key...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+
+		if next[i] != 0 {
+			return next, nil
+		}
+	}
+
+	return nil, errEndOfKeySpace
+}
+
+// codeHashes returns the hashes of the set
+func codeHashes(set map[types.Hash]struct{}) []types.Hash {
+	hashes := make([]types.Hash, 0, len(set))
+	for hash := range set {
+		hashes = append(hashes, hash)
+	}
+
+	return hashes
+}
@@ -0,0 +1,214 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+)
+
+// newStateServingClient returns a sync peer client serving the blocks and the state of the storage
+func newStateServingClient(blocks []*types.Block, storage itrie.Storage) *mockSyncPeerClient {
+	service := &syncPeerService{stateStorage: storage}
+
+	return &mockSyncPeerClient{
+		getBlocksHandler: func(_ peer.ID, from uint64, _ time.Duration) (<-chan *types.Block, error) {
+			return blocksToCh(blocks[from-1:], 0), nil
+		},
+		getStateRangeHandler: func(_ peer.ID, root types.Hash, origin []byte) (*StateRange, error) {
+			stateRange, err := service.GetStateRange(context.Background(), &proto.GetStateRangeRequest{
+				Root:   root.Bytes(),
+				Origin: origin,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &StateRange{Keys: stateRange.Keys, Values: stateRange.Values, Proof: stateRange.Proof}, nil
+		},
+		getTrieNodesHandler: func(_ peer.ID, hashes []types.Hash) ([][]byte, error) {
+			items, err := service.GetTrieNodes(context.Background(), toProtoStateItemsRequest(hashes))
+			if err != nil {
+				return nil, err
+			}
+
+			return items.Items, nil
+		},
+		getByteCodesHandler: func(_ peer.ID, hashes []types.Hash) ([][]byte, error) {
+			items, err := service.GetByteCodes(context.Background(), toProtoStateItemsRequest(hashes))
+			if err != nil {
+				return nil, err
+			}
+
+			return items.Items, nil
+		},
+	}
+}
+
+func Test_snapSyncWithPeer(t *testing.T) {
+	t.Parallel()
+
+	sourceStorage, root, objs := newTestState(t, 50)
+	genesis := &types.Header{Number: 0, StateRoot: types.EmptyRootHash}
+
+	blocks := createMockBlocks(100)
+	pivot := uint64(len(blocks) - pivotDistance)
+	blocks[pivot-1].Header.StateRoot = root
+
+	bestPeer := &NoForkPeer{ID: peer.ID("A"), Number: uint64(len(blocks))}
+
+	assertStateSynced := func(t *testing.T, storage itrie.Storage) {
+		t.Helper()
+
+		nodes, codes, err := itrie.FindMissingNodes(root, storage, 1)
+		require.NoError(t, err)
+		assert.Empty(t, nodes)
+		assert.Empty(t, codes)
+
+		snapshot, err := itrie.NewState(storage).NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		account, err := snapshot.GetAccount(objs[3].Address)
+		require.NoError(t, err)
+		assert.Equal(t, objs[3].Balance, account.Balance)
+	}
+
+	t.Run("should write the blocks to the pivot and sync its state", func(t *testing.T) {
+		t.Parallel()
+
+		stateStorage := itrie.NewMemoryStorage()
+		client := newStateServingClient(blocks, sourceStorage)
+
+		syncer, chain := newChainTestSyncer(genesis, client)
+		syncer.stateStorage = stateStorage
+		syncer.snapSync = true
+
+		require.NoError(t, syncer.snapSyncWithPeer(bestPeer))
+
+		assert.Equal(t, pivot, chain.latest.Number)
+		assert.True(t, syncer.hasState(root))
+		assertStateSynced(t, stateStorage)
+		assert.Empty(t, client.reportedPeers)
+	})
+
+	t.Run("should heal a partially synced state", func(t *testing.T) {
+		t.Parallel()
+
+		// the storage trie of the account was partially synced by an interrupted sync
+		snapshot, err := itrie.NewState(sourceStorage).NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		account, err := snapshot.GetAccount(objs[0].Address)
+		require.NoError(t, err)
+
+		storageRootNode, ok := sourceStorage.Get(account.Root.Bytes())
+		require.True(t, ok)
+
+		stateStorage := itrie.NewMemoryStorage()
+		stateStorage.Put(account.Root.Bytes(), storageRootNode)
+
+		client := newStateServingClient(blocks, sourceStorage)
+		trieNodesRequested := false
+		getTrieNodes := client.getTrieNodesHandler
+		client.getTrieNodesHandler = func(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+			trieNodesRequested = true
+
+			return getTrieNodes(id, hashes)
+		}
+
+		syncer, _ := newChainTestSyncer(genesis, client)
+		syncer.stateStorage = stateStorage
+		syncer.snapSync = true
+
+		require.NoError(t, syncer.snapSyncWithPeer(bestPeer))
+
+		assert.True(t, trieNodesRequested)
+		assertStateSynced(t, stateStorage)
+	})
+
+	t.Run("should report the peer serving an invalid state range", func(t *testing.T) {
+		t.Parallel()
+
+		stateStorage := itrie.NewMemoryStorage()
+		client := newStateServingClient(blocks, sourceStorage)
+		getStateRange := client.getStateRangeHandler
+		client.getStateRangeHandler = func(id peer.ID, root types.Hash, origin []byte) (*StateRange, error) {
+			stateRange, err := getStateRange(id, root, origin)
+			if err != nil {
+				return nil, err
+			}
+
+			stateRange.Values[0] = (&state.Account{Balance: big.NewInt(1)}).MarshalWith(&fastrlp.Arena{}).MarshalTo(nil)
+
+			return stateRange, nil
+		}
+
+		syncer, _ := newChainTestSyncer(genesis, client)
+		syncer.stateStorage = stateStorage
+		syncer.snapSync = true
+
+		assert.ErrorIs(t, syncer.snapSyncWithPeer(bestPeer), itrie.ErrRangeRootMismatch)
+		assert.Equal(t, []peer.ID{bestPeer.ID}, client.reportedPeers)
+		assert.False(t, syncer.hasState(root))
+	})
+
+	t.Run("should not sync the state of a near peer", func(t *testing.T) {
+		t.Parallel()
+
+		stateStorage := itrie.NewMemoryStorage()
+		syncer, chain := newChainTestSyncer(genesis, &mockSyncPeerClient{})
+		syncer.stateStorage = stateStorage
+		syncer.snapSync = true
+
+		require.NoError(t, syncer.snapSyncWithPeer(&NoForkPeer{ID: peer.ID("A"), Number: pivotDistance}))
+
+		assert.Equal(t, uint64(0), chain.latest.Number)
+	})
+}
+
+func Test_getStateItems(t *testing.T) {
+	t.Parallel()
+
+	// the codes don't fit in a single response
+	storage := itrie.NewMemoryStorage()
+	hashes := make([]types.Hash, 3)
+	codes := make([][]byte, len(hashes))
+
+	for i := range hashes {
+		codes[i] = bytes.Repeat([]byte{byte(i)}, maxStateItemsBytes/2+1)
+		hashes[i] = types.BytesToHash(crypto.Keccak256(codes[i]))
+
+		storage.SetCode(hashes[i], codes[i])
+	}
+
+	client := newStateServingClient(nil, storage)
+	requests := 0
+	getByteCodes := client.getByteCodesHandler
+	client.getByteCodesHandler = func(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+		requests++
+
+		return getByteCodes(id, hashes)
+	}
+
+	syncer, _ := newChainTestSyncer(&types.Header{}, client)
+	syncer.stateStorage = itrie.NewMemoryStorage()
+	syncer.snapSync = true
+
+	items, err := syncer.getStateItems(peer.ID("A"), hashes, client.GetByteCodes)
+	require.NoError(t, err)
+
+	assert.Equal(t, codes, items)
+	assert.Equal(t, 2, requests)
+	assert.Empty(t, client.reportedPeers)
+}
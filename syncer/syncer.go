@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
@@ -20,6 +23,23 @@ const (
 
 var (
 	errTimeout = errors.New("timeout awaiting block from peer")
+
+	// invalidHeaderErrors are the block verification errors caused by the header
+	// served by a peer, so the peer serving the header is penalized
+	invalidHeaderErrors = []error{
+		blockchain.ErrInvalidHeader,
+		blockchain.ErrInvalidBlockSequence,
+		blockchain.ErrInvalidStateRoot,
+		blockchain.ErrInvalidGasUsed,
+		blockchain.ErrInvalidReceiptsRoot,
+	}
+
+	// invalidBodyErrors are the block verification errors caused by the body
+	// served by a peer, so the peer serving the body is penalized
+	invalidBodyErrors = []error{
+		blockchain.ErrInvalidTxRoot,
+		blockchain.ErrInvalidSha3Uncles,
+	}
 )
 
 // XXX: Don't use this syncer for the consensus that may cause fork.
@@ -36,6 +56,16 @@ type syncer struct {
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
+	// State storage, synced from the peers on snap sync
+	stateStorage itrie.Storage
+	// Flag for bootstrapping the node from the state of a recent block
+	snapSync bool
+	// Trusted block the node is synced from, instead of validating all the blocks before it
+	checkpoint *Checkpoint
+
+	// Download throughput of the peers, to download the blocks from the fastest ones
+	throughput *peerThroughput
+
 	// Channel to notify Sync that a new status arrived
 	newStatusCh chan struct{}
 }
@@ -44,15 +74,22 @@ func NewSyncer(
 	logger hclog.Logger,
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 	blockTimeout time.Duration,
+	snapSync bool,
+	checkpoint *Checkpoint,
 ) Syncer {
 	return &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
+		syncPeerService: NewSyncPeerService(network, blockchain, stateStorage),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
 		blockTimeout:    blockTimeout,
+		stateStorage:    stateStorage,
+		snapSync:        snapSync,
+		checkpoint:      checkpoint,
+		throughput:      newPeerThroughput(),
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
 	}
@@ -135,6 +172,7 @@ func (s *syncer) putToPeerMap(status *NoForkPeer) {
 // removeFromPeerMap removes the peer from peer map
 func (s *syncer) removeFromPeerMap(peerID peer.ID) {
 	s.peerMap.Remove(peerID)
+	s.throughput.remove(peerID)
 }
 
 // notifyNewStatusEvent emits signal to newStatusCh
@@ -159,7 +197,11 @@ func (s *syncer) HasSyncPeer() bool {
 	return bestPeer != nil && bestPeer.Number > header.Number
 }
 
-// Sync syncs block with the best peer until callback returns true
+// Sync syncs block with the best peer until callback returns true.
+// When far behind the best peer, the blocks are downloaded from multiple peers in parallel.
+// On checkpoint sync, the node is first bootstrapped from the checkpoint block and the state at its root.
+// On snap sync, the node is first bootstrapped from the state of a recent block of the best peer,
+// and the callback is not called for the blocks up to that block
 func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 	localLatest := s.blockchain.Header().Number
 	skipList := make(map[peer.ID]bool)
@@ -182,11 +224,56 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			continue
 		}
 
+		if s.checkpoint != nil {
+			shouldTerminate, err := s.checkpointSyncWithPeer(bestPeer, callback)
+			if err != nil {
+				s.logger.Warn("failed to complete checkpoint sync with peer, try to next one",
+					"peer ID", bestPeer.ID, "error", err)
+
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+
+			if shouldTerminate {
+				break
+			}
+
+			localLatest = s.blockchain.Header().Number
+		}
+
+		if s.snapSync {
+			if err := s.snapSyncWithPeer(bestPeer); err != nil {
+				s.logger.Warn("failed to complete snap sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+
+			localLatest = s.blockchain.Header().Number
+		}
+
 		// if the bestPeer does not have a new block continue
 		if bestPeer.Number <= localLatest {
 			continue
 		}
 
+		// far behind the best peer, the blocks are downloaded from multiple peers in parallel first,
+		// the remaining ones are synced from the best peer below
+		if bestPeer.Number > localLatest+parallelSyncThreshold {
+			lastNumber, shouldTerminate, err := s.downloadBlocks(bestPeer, callback)
+			if err != nil {
+				s.logger.Warn("failed to download blocks in parallel, sync with peer only", "peer ID", bestPeer.ID, "error", err)
+			}
+
+			if shouldTerminate {
+				break
+			}
+
+			localLatest = lastNumber
+		}
+
 		// fetch block from the peer
 		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, callback)
 		if err != nil {
@@ -241,7 +328,7 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.F
 
 			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
 			if err != nil {
-				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+				s.reportInvalidBlock(err, peerID, peerID)
 
 				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
 			}
@@ -262,6 +349,29 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.F
 	}
 }
 
+// reportInvalidBlock penalizes the peer which served the invalid part of a block.
+// The errors not caused by the served data, such as the failures of the consensus
+// or of the local state, are not charged to the peers
+func (s *syncer) reportInvalidBlock(err error, headerPeer, bodyPeer peer.ID) {
+	metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+	for _, headerErr := range invalidHeaderErrors {
+		if errors.Is(err, headerErr) {
+			s.syncPeerClient.ReportPeer(headerPeer, common.PenaltyInvalidBlock, "invalid block header while syncing")
+
+			return
+		}
+	}
+
+	for _, bodyErr := range invalidBodyErrors {
+		if errors.Is(err, bodyErr) {
+			s.syncPeerClient.ReportPeer(bodyPeer, common.PenaltyInvalidBlock, "invalid block body while syncing")
+
+			return
+		}
+	}
+}
+
 func updateMetrics(fullBlock *types.FullBlock) {
 	metrics.SetGauge([]string{syncerMetrics, "tx_num"}, float32(len(fullBlock.Block.Transactions)))
 	metrics.SetGauge([]string{syncerMetrics, "receipts_num"}, float32(len(fullBlock.Receipts)))
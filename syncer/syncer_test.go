@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -42,6 +44,12 @@ type mockBlockchain struct {
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+	getBodyByHashHandler        func(types.Hash) (*types.Body, bool)
+
+	verifyFinalizedBlockWithoutExecutionHandler func(*types.Block) (*types.FullBlock, error)
+	verifyCheckpointBlockHandler                func(*types.Block) error
+	writeCheckpointAncestorsHandler             func([]*types.Header) error
+	writeCheckpointBlockHandler                 func(*types.Block) error
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -56,10 +64,30 @@ func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Bloc
 	return m.getBlockByNumberHandler(number, full)
 }
 
+func (m *mockBlockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
+	return m.getBodyByHashHandler(hash)
+}
+
 func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock, error) {
 	return m.verifyFinalizedBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyFinalizedBlockWithoutExecution(b *types.Block) (*types.FullBlock, error) {
+	return m.verifyFinalizedBlockWithoutExecutionHandler(b)
+}
+
+func (m *mockBlockchain) VerifyCheckpointBlock(b *types.Block) error {
+	return m.verifyCheckpointBlockHandler(b)
+}
+
+func (m *mockBlockchain) WriteCheckpointAncestors(headers []*types.Header) error {
+	return m.writeCheckpointAncestorsHandler(headers)
+}
+
+func (m *mockBlockchain) WriteCheckpointBlock(b *types.Block, s string) error {
+	return m.writeCheckpointBlockHandler(b)
+}
+
 func (m *mockBlockchain) WriteBlock(b *types.Block, s string) error {
 	return m.writeBlockHandler(b)
 }
@@ -92,6 +120,15 @@ type mockSyncPeerClient struct {
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
+	getStateRangeHandler                  func(peer.ID, types.Hash, []byte) (*StateRange, error)
+	getTrieNodesHandler                   func(peer.ID, []types.Hash) ([][]byte, error)
+	getByteCodesHandler                   func(peer.ID, []types.Hash) ([][]byte, error)
+	getHeadersHandler                     func(peer.ID, uint64, uint64) ([]*types.Header, error)
+	getBodiesHandler                      func(peer.ID, []types.Hash) ([]*types.Body, error)
+
+	// the peers may be reported by the concurrent body downloads
+	reportLock    sync.Mutex
+	reportedPeers []peer.ID
 }
 
 func (m *mockSyncPeerClient) DisablePublishingPeerStatus() {}
@@ -120,6 +157,26 @@ func (m *mockSyncPeerClient) GetBlocks(
 	return m.getBlocksHandler(id, start, timeoutPerBlock)
 }
 
+func (m *mockSyncPeerClient) GetStateRange(id peer.ID, root types.Hash, origin []byte) (*StateRange, error) {
+	return m.getStateRangeHandler(id, root, origin)
+}
+
+func (m *mockSyncPeerClient) GetTrieNodes(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+	return m.getTrieNodesHandler(id, hashes)
+}
+
+func (m *mockSyncPeerClient) GetByteCodes(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+	return m.getByteCodesHandler(id, hashes)
+}
+
+func (m *mockSyncPeerClient) GetHeaders(id peer.ID, from, count uint64) ([]*types.Header, error) {
+	return m.getHeadersHandler(id, from, count)
+}
+
+func (m *mockSyncPeerClient) GetBodies(id peer.ID, hashes []types.Hash) ([]*types.Body, error) {
+	return m.getBodiesHandler(id, hashes)
+}
+
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
 	return m.getPeerStatusUpdateChHandler()
 }
@@ -132,6 +189,13 @@ func (m *mockSyncPeerClient) CloseStream(peerID peer.ID) error {
 	return nil
 }
 
+func (m *mockSyncPeerClient) ReportPeer(peerID peer.ID, _ common.Penalty, _ string) {
+	m.reportLock.Lock()
+	defer m.reportLock.Unlock()
+
+	m.reportedPeers = append(m.reportedPeers, peerID)
+}
+
 func GetAllElementsFromPeerMap(t *testing.T, p *PeerMap) []*NoForkPeer {
 	t.Helper()
 
@@ -171,11 +235,74 @@ func NewTestSyncer(
 		syncPeerService: &mockSyncPeerService{},
 		syncPeerClient:  mockSyncPeerClient,
 		blockTimeout:    blockTimeout,
+		throughput:      newPeerThroughput(),
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
 	}
 }
 
+// testChain is the in-memory chain to which a test syncer writes the synced blocks
+type testChain struct {
+	latest    *types.Header
+	written   []uint64
+	ancestors map[uint64]*types.Header
+}
+
+// newChainTestSyncer returns a syncer writing to an in-memory chain starting at the given genesis.
+// The executed blocks must be children of the latest block, and the checkpoint block
+// a child of its written ancestors
+func newChainTestSyncer(genesis *types.Header, client *mockSyncPeerClient) (*syncer, *testChain) {
+	chain := &testChain{
+		latest:    genesis,
+		written:   make([]uint64, 0),
+		ancestors: make(map[uint64]*types.Header),
+	}
+
+	mockChain := &mockBlockchain{
+		headerHandler: func() *types.Header {
+			return chain.latest
+		},
+		verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+			if b.ParentHash() != chain.latest.Hash {
+				return nil, errors.New("block is not the child of the latest block")
+			}
+
+			return &types.FullBlock{Block: b}, nil
+		},
+		verifyFinalizedBlockWithoutExecutionHandler: func(b *types.Block) (*types.FullBlock, error) {
+			return &types.FullBlock{Block: b}, nil
+		},
+		writeFullBlockHandler: func(b *types.FullBlock) error {
+			chain.latest = b.Block.Header
+			chain.written = append(chain.written, b.Block.Number())
+
+			return nil
+		},
+		writeCheckpointAncestorsHandler: func(headers []*types.Header) error {
+			for _, header := range headers {
+				chain.ancestors[header.Number] = header
+			}
+
+			return nil
+		},
+		// the consensus verifies the checkpoint block against its ancestors
+		verifyCheckpointBlockHandler: func(b *types.Block) error {
+			if parent, ok := chain.ancestors[b.Number()-1]; !ok || parent.Hash != b.ParentHash() {
+				return errors.New("parent not found")
+			}
+
+			return nil
+		},
+		writeCheckpointBlockHandler: func(b *types.Block) error {
+			chain.latest = b.Header
+
+			return nil
+		},
+	}
+
+	return NewTestSyncer(nil, mockChain, time.Second, client, &mockProgression{}), chain
+}
+
 var (
 	peerStatuses = []*NoForkPeer{
 		{
@@ -702,6 +829,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		lastSyncedBlockNumber uint64
 		shouldTerminate       bool
 		err                   error
+		penalized             bool
 	}{
 		{
 			name:            "should sync blocks to the latest successfully",
@@ -755,6 +883,32 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
 				return blocksToCh(blocks[:10], 0), nil
 			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				if b.Number() > 5 {
+					return nil, blockchain.ErrInvalidStateRoot
+				}
+
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				return nil
+			},
+			blocks:                blocks[:5],
+			lastSyncedBlockNumber: 5,
+			shouldTerminate:       false,
+			err:                   blockchain.ErrInvalidStateRoot,
+			penalized:             true,
+		},
+		{
+			name:            "should not penalize the peer if verification fails locally",
+			beginningHeight: 0,
+			blockTimeout:    time.Second,
+			blockCallback: func(b *types.FullBlock) bool {
+				return false
+			},
+			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+				return blocksToCh(blocks[:10], 0), nil
+			},
 			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
 				if b.Number() > 5 {
 					return nil, errInvalidBlock
@@ -827,6 +981,10 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			var (
 				syncedBlocks = make([]*types.Block, 0, len(test.blocks))
 
+				syncPeerClient = &mockSyncPeerClient{
+					getBlocksHandler: test.getBlocksHandler,
+				}
+
 				syncer = NewTestSyncer(
 					nil,
 					&mockBlockchain{
@@ -843,9 +1001,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 						},
 					},
 					test.blockTimeout,
-					&mockSyncPeerClient{
-						getBlocksHandler: test.getBlocksHandler,
-					},
+					syncPeerClient,
 					&mockProgression{},
 				)
 			)
@@ -856,6 +1012,12 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			assert.Equal(t, test.shouldTerminate, shouldTerminate)
 			assert.ErrorIs(t, err, test.err)
 			assert.Equal(t, test.blocks, syncedBlocks)
+
+			if test.penalized {
+				assert.Equal(t, []peer.ID{"X"}, syncPeerClient.reportedPeers)
+			} else {
+				assert.Empty(t, syncPeerClient.reportedPeers)
+			}
 		})
 	}
 }
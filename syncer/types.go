@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -25,12 +26,22 @@ type Blockchain interface {
 	Header() *types.Header
 	// GetBlockByNumber returns block by number
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	// GetBodyByHash returns the body of the block by its hash
+	GetBodyByHash(types.Hash) (*types.Body, bool)
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error)
+	// VerifyFinalizedBlockWithoutExecution verifies finalized block without executing its transactions
+	VerifyFinalizedBlockWithoutExecution(block *types.Block) (*types.FullBlock, error)
+	// VerifyCheckpointBlock verifies the checkpoint block against the headers of its ancestors
+	VerifyCheckpointBlock(block *types.Block) error
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// WriteCheckpointAncestors writes the headers of the ancestors of the checkpoint block
+	WriteCheckpointAncestors([]*types.Header) error
+	// WriteCheckpointBlock writes the checkpoint block as the head, without its parent block
+	WriteCheckpointBlock(*types.Block, string) error
 }
 
 type Network interface {
@@ -48,13 +59,15 @@ type Network interface {
 	// and returns a reference to the connection
 	NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.ClientConn, error)
 	// NewTopic Creates New Topic for gossip
-	NewTopic(protoID string, obj proto.Message) (*network.Topic, error)
+	NewTopic(protoID string, obj proto.Message, validator network.TopicValidator) (*network.Topic, error)
 	// IsConnected returns the node is connecting to the peer associated with the given ID
 	IsConnected(peerID peer.ID) bool
 	// SaveProtocolStream saves stream
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// ReportPeer penalizes the peer for a misbehavior
+	ReportPeer(peerID peer.ID, penalty common.Penalty, reason string)
 }
 
 type Syncer interface {
@@ -99,12 +112,24 @@ type SyncPeerClient interface {
 	GetConnectedPeerStatuses() []*NoForkPeer
 	// GetBlocks returns a stream of blocks from given height to peer's latest
 	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetHeaders returns up to count headers of the consecutive blocks from the given height
+	GetHeaders(peerID peer.ID, from, count uint64) ([]*types.Header, error)
+	// GetBodies returns the bodies of the blocks of the given hashes, up to the first block the peer doesn't have
+	GetBodies(peerID peer.ID, hashes []types.Hash) ([]*types.Body, error)
+	// GetStateRange returns the entries of a state or storage trie from the origin, along with their proof
+	GetStateRange(peerID peer.ID, root types.Hash, origin []byte) (*StateRange, error)
+	// GetTrieNodes returns the trie nodes of the given hashes, empty if the peer doesn't have them
+	GetTrieNodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error)
+	// GetByteCodes returns the contract codes of the given hashes, empty if the peer doesn't have them
+	GetByteCodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
 	GetPeerConnectionUpdateEventCh() <-chan *event.PeerEvent
 	// CloseStream close a stream
 	CloseStream(peerID peer.ID) error
+	// ReportPeer penalizes the peer for a misbehavior
+	ReportPeer(peerID peer.ID, penalty common.Penalty, reason string)
 	// DisablePublishingPeerStatus disables publishing status in syncer topic
 	DisablePublishingPeerStatus()
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
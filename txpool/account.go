@@ -3,6 +3,7 @@ package txpool
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -20,10 +21,11 @@ type accountsMap struct {
 // Intializes an account for the given address.
 func (m *accountsMap) initOnce(addr types.Address, nonce uint64) *account {
 	a, loaded := m.LoadOrStore(addr, &account{
-		enqueued:    newAccountQueue(),
-		promoted:    newAccountQueue(),
-		maxEnqueued: m.maxEnqueuedLimit,
-		nextNonce:   nonce,
+		enqueued:     newAccountQueue(),
+		promoted:     newAccountQueue(),
+		maxEnqueued:  m.maxEnqueuedLimit,
+		nextNonce:    nonce,
+		lastActivity: time.Now().UnixNano(),
 	})
 	newAccount := a.(*account) //nolint:forcetypeassert
 
@@ -136,6 +138,47 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 	return
 }
 
+// txsFrom returns the promoted and enqueued transactions
+// of the given account, sorted by nonce.
+func (m *accountsMap) txsFrom(addr types.Address) (promoted, enqueued []*types.Transaction) {
+	account := m.get(addr)
+	if account == nil {
+		return nil, nil
+	}
+
+	account.promoted.lock(false)
+	promoted = account.promoted.sorted()
+	account.promoted.unlock()
+
+	account.enqueued.lock(false)
+	enqueued = account.enqueued.sorted()
+	account.enqueued.unlock()
+
+	return
+}
+
+// promotedSorted returns a copy of the promoted transactions
+// of all the accounts, sorted by nonce.
+func (m *accountsMap) promotedSorted() map[types.Address][]*types.Transaction {
+	promoted := make(map[types.Address][]*types.Transaction)
+
+	m.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account := m.get(addr)
+
+		account.promoted.lock(false)
+		defer account.promoted.unlock()
+
+		if account.promoted.length() != 0 {
+			promoted[addr] = account.promoted.sorted()
+		}
+
+		return true
+	})
+
+	return promoted
+}
+
 // An account is the core structure for processing
 // transactions from a specific address. The nextNonce
 // field is what separates the enqueued from promoted transactions:
@@ -156,6 +199,32 @@ type account struct {
 
 	//	maximum number of enqueued transactions
 	maxEnqueued uint64
+
+	// unix timestamp (in nanoseconds) of the last time
+	// the account had a transaction enqueued, promoted or executed
+	lastActivity int64
+}
+
+// lookup returns the status of the given transaction
+// and its position in the corresponding queue.
+func (a *account) lookup(hash types.Hash) (TxStatus, uint64, bool) {
+	a.promoted.lock(false)
+	position, ok := a.promoted.position(hash)
+	a.promoted.unlock()
+
+	if ok {
+		return TxStatusPending, position, true
+	}
+
+	a.enqueued.lock(false)
+	position, ok = a.enqueued.position(hash)
+	a.enqueued.unlock()
+
+	if ok {
+		return TxStatusQueued, position, true
+	}
+
+	return 0, 0, false
 }
 
 // getNonce returns the next expected nonce for this account.
@@ -168,6 +237,16 @@ func (a *account) setNonce(nonce uint64) {
 	atomic.StoreUint64(&a.nextNonce, nonce)
 }
 
+// updateLastActivity marks the account as active at the current time.
+func (a *account) updateLastActivity() {
+	atomic.StoreInt64(&a.lastActivity, time.Now().UnixNano())
+}
+
+// isStale checks if the account has been inactive for longer than the given lifetime.
+func (a *account) isStale(lifetime time.Duration) bool {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&a.lastActivity))) > lifetime
+}
+
 // Demotions returns the current value of demotions
 func (a *account) Demotions() uint64 {
 	return a.demotions
@@ -210,6 +289,7 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 
 	// update nonce expected for this account
 	a.setNonce(nonce)
+	a.updateLastActivity()
 
 	// it is important to signal promotion while
 	// the locks are held to ensure no other
@@ -238,6 +318,7 @@ func (a *account) enqueue(tx *types.Transaction) error {
 
 	// enqueue tx
 	a.enqueued.push(tx)
+	a.updateLastActivity()
 
 	return nil
 }
@@ -295,6 +376,69 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 		a.setNonce(nextNonce)
 	}
 
+	if len(promoted) > 0 {
+		a.updateLastActivity()
+	}
+
+	return
+}
+
+// removeFrom removes the transactions with nonce greater than or equal to given
+// from both queues, as they can no longer be executed in order.
+// The next expected nonce is rolled back if needed.
+func (a *account) removeFrom(nonce uint64) (
+	removedPromoted,
+	removedEnqueued []*types.Transaction,
+) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	removedPromoted = a.promoted.removeFrom(nonce)
+	removedEnqueued = a.enqueued.removeFrom(nonce)
+
+	if nonce < a.getNonce() {
+		a.setNonce(nonce)
+	}
+
+	return
+}
+
+// clearStale removes all transactions of the account from both queues, if the account
+// has been inactive for longer than the given lifetime, and rolls back the next expected
+// nonce to the lowest removed promoted transaction. The staleness is checked under the
+// locks of the queues, so a transaction added meanwhile keeps the account alive
+func (a *account) clearStale(lifetime time.Duration) (
+	prunedPromoted,
+	prunedEnqueued []*types.Transaction,
+) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	if !a.isStale(lifetime) {
+		return nil, nil
+	}
+
+	if first := a.promoted.peek(); first != nil {
+		// rollback nonce
+		a.setNonce(first.Nonce)
+	}
+
+	prunedPromoted = a.promoted.clear()
+	prunedEnqueued = a.enqueued.clear()
+
+	a.resetDemotions()
+	a.resetSkips()
+
 	return
 }
 
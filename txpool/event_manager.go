@@ -16,6 +16,9 @@ type eventManager struct {
 	subscriptionsLock sync.RWMutex
 	numSubscriptions  int64
 	logger            hclog.Logger
+
+	// isPrivate reports the transactions whose events are not signaled, if set
+	isPrivate func(types.Hash) bool
 }
 
 func newEventManager(logger hclog.Logger) *eventManager {
@@ -78,14 +81,18 @@ func (em *eventManager) Close() {
 	em.subscriptionsLock.Lock()
 	defer em.subscriptionsLock.Unlock()
 
-	for _, subscription := range em.subscriptions {
+	// the canceled subscriptions are removed, for the later cancellations to be no-ops
+	for id, subscription := range em.subscriptions {
 		subscription.close()
+		delete(em.subscriptions, id)
 	}
 
 	atomic.StoreInt64(&em.numSubscriptions, 0)
 }
 
-// signalEvent is a helper method for alerting listeners of a new TxPool event
+// signalEvent is a helper method for alerting listeners of a new TxPool event.
+// The private transactions are left out, so their events must be signaled
+// before the transactions are removed from the pool
 func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types.Hash) {
 	if atomic.LoadInt64(&em.numSubscriptions) < 1 {
 		// No reason to lock the subscriptions map
@@ -97,6 +104,10 @@ func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types
 	defer em.subscriptionsLock.RUnlock()
 
 	for _, txHash := range txHashes {
+		if em.isPrivate != nil && em.isPrivate(txHash) {
+			continue
+		}
+
 		for _, subscription := range em.subscriptions {
 			subscription.pushEvent(&proto.TxPoolEvent{
 				Type:   eventType,
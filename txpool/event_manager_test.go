@@ -74,6 +74,15 @@ func TestEventManager_SubscribeClose(t *testing.T) {
 			t.Fatalf("Subscription channel not closed for index %d", indx)
 		}
 	}
+
+	// Check that the subscriptions can still be canceled
+	for _, subscription := range subscriptions {
+		assert.NotPanics(t, func() {
+			em.cancelSubscription(subscription.subscriptionID)
+		})
+	}
+
+	assert.Equal(t, int64(0), em.numSubscriptions)
 }
 
 func TestEventManager_SignalEvent(t *testing.T) {
@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"math"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/types"
@@ -10,11 +11,18 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// arrival order of the transactions present in the map
+	arrivals    map[types.Hash]uint64
+	nextArrival uint64
+
+	// transactions present in the map which were submitted privately
+	private map[types.Hash]struct{}
 }
 
-// add inserts the given transaction into the map. Returns false
-// if it already exists. [thread-safe]
-func (m *lookupMap) add(tx *types.Transaction) bool {
+// add inserts the given transaction into the map, marked as private or not.
+// Returns false if it already exists. [thread-safe]
+func (m *lookupMap) add(tx *types.Transaction, private bool) bool {
 	m.Lock()
 	defer m.Unlock()
 
@@ -23,6 +31,12 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 	}
 
 	m.all[tx.Hash] = tx
+	m.arrivals[tx.Hash] = m.nextArrival
+	m.nextArrival++
+
+	if private {
+		m.private[tx.Hash] = struct{}{}
+	}
 
 	return true
 }
@@ -34,6 +48,8 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.arrivals, tx.Hash)
+		delete(m.private, tx.Hash)
 	}
 }
 
@@ -49,3 +65,28 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// arrival returns the arrival order of the transaction associated with the given hash.
+// Transactions that are not present in the map go last. [thread-safe]
+func (m *lookupMap) arrival(hash types.Hash) uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	order, ok := m.arrivals[hash]
+	if !ok {
+		return math.MaxUint64
+	}
+
+	return order
+}
+
+// isPrivate returns true if the transaction associated
+// with the given hash was submitted privately. [thread-safe]
+func (m *lookupMap) isPrivate(hash types.Hash) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	_, ok := m.private[hash]
+
+	return ok
+}
@@ -1,10 +1,16 @@
 package txpool
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rawGrpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var mockHeader = &types.Header{
@@ -66,3 +72,55 @@ type mockSigner struct {
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+type mockSubscribeServer struct {
+	rawGrpc.ServerStream
+
+	ctx    context.Context
+	events chan *proto.TxPoolEvent
+}
+
+func (m *mockSubscribeServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockSubscribeServer) Send(event *proto.TxPoolEvent) error {
+	m.events <- event
+
+	return nil
+}
+
+// mockAnnouncerClient calls the endpoints of the announcer of another node directly,
+// as if the requests were sent by the given peer
+type mockAnnouncerClient struct {
+	from peer.ID
+	to   *txAnnouncer
+}
+
+func (m *mockAnnouncerClient) context(ctx context.Context) context.Context {
+	return &grpc.Context{Context: ctx, PeerID: m.from}
+}
+
+func (m *mockAnnouncerClient) AnnounceTxs(
+	ctx context.Context,
+	in *proto.TxAnnouncements,
+	_ ...rawGrpc.CallOption,
+) (*emptypb.Empty, error) {
+	return m.to.AnnounceTxs(m.context(ctx), in)
+}
+
+func (m *mockAnnouncerClient) SendTxs(
+	ctx context.Context,
+	in *proto.Txns,
+	_ ...rawGrpc.CallOption,
+) (*emptypb.Empty, error) {
+	return m.to.SendTxs(m.context(ctx), in)
+}
+
+func (m *mockAnnouncerClient) GetTxs(
+	ctx context.Context,
+	in *proto.TxHashes,
+	_ ...rawGrpc.CallOption,
+) (*proto.Txns, error) {
+	return m.to.GetTxs(m.context(ctx), in)
+}
@@ -3,9 +3,12 @@ package txpool
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/grpc/peer"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -20,6 +23,97 @@ func (p *TxPool) Status(ctx context.Context, req *empty.Empty) (*proto.TxnPoolSt
 
 // AddTxn adds a local transaction to the pool
 func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTxnResp, error) {
+	txn, err := decodeAddTxnReq(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.AddTxFromClient(txn, clientAddr(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &proto.AddTxnResp{
+		TxHash: txn.Hash.String(),
+	}, nil
+}
+
+// AddPrivateTxn adds a local transaction to the pool without gossiping it
+func (p *TxPool) AddPrivateTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTxnResp, error) {
+	txn, err := decodeAddTxnReq(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.AddPrivateTxFromClient(txn, clientAddr(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &proto.AddTxnResp{
+		TxHash: txn.Hash.String(),
+	}, nil
+}
+
+// ContentFrom returns the pending and queued transactions of the given account
+func (p *TxPool) ContentFrom(ctx context.Context, req *proto.ContentFromReq) (*proto.ContentFromResp, error) {
+	pending, queued := p.GetTxsFrom(types.StringToAddress(req.Address))
+
+	return &proto.ContentFromResp{
+		Pending: toRawTxs(pending),
+		Queued:  toRawTxs(queued),
+	}, nil
+}
+
+// GetTxn returns the transaction by hash along with its status in the pool
+func (p *TxPool) GetTxn(ctx context.Context, req *proto.GetTxnReq) (*proto.GetTxnResp, error) {
+	lookup, ok := p.LookupTx(types.StringToHash(req.TxHash))
+	if !ok {
+		return nil, ErrTxNotFound
+	}
+
+	status := proto.TxnStatus_PENDING
+	if lookup.Status == TxStatusQueued {
+		status = proto.TxnStatus_QUEUED
+	}
+
+	return &proto.GetTxnResp{
+		Raw: &any.Any{
+			Value: lookup.Tx.MarshalRLP(),
+		},
+		From:     lookup.Tx.From.String(),
+		Status:   status,
+		Position: lookup.Position,
+	}, nil
+}
+
+// toRawTxs encodes the given transactions
+func toRawTxs(txs []*types.Transaction) []*any.Any {
+	raw := make([]*any.Any, len(txs))
+	for i, tx := range txs {
+		raw[i] = &any.Any{
+			Value: tx.MarshalRLP(),
+		}
+	}
+
+	return raw
+}
+
+// clientAddr returns the IP address of the gRPC client, if known
+func clientAddr(ctx context.Context) string {
+	client, ok := peer.FromContext(ctx)
+	if !ok || client.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(client.Addr.String())
+	if err != nil {
+		return client.Addr.String()
+	}
+
+	return host
+}
+
+// decodeAddTxnReq decodes the transaction from the add txn request
+func decodeAddTxnReq(raw *proto.AddTxnReq) (*types.Transaction, error) {
 	if raw.Raw == nil {
 		return nil, fmt.Errorf("transaction's field raw is empty")
 	}
@@ -38,13 +132,7 @@ func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTx
 		txn.From = from
 	}
 
-	if err := p.AddTx(txn); err != nil {
-		return nil, err
-	}
-
-	return &proto.AddTxnResp{
-		TxHash: txn.Hash.String(),
-	}, nil
+	return txn, nil
 }
 
 // Subscribe implements the operator endpoint. It subscribes to new events in the tx pool
@@ -0,0 +1,281 @@
+package txpool
+
+import (
+	"container/heap"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// OrderingPolicyType is the name of the policy used
+// for ordering the executable transactions of the pool
+type OrderingPolicyType string
+
+const (
+	// PriceOrdering orders transactions by their effective tip (default)
+	PriceOrdering OrderingPolicyType = "price"
+
+	// FIFOOrdering orders transactions by the time they arrived to the pool
+	FIFOOrdering OrderingPolicyType = "fifo"
+
+	// SenderCapOrdering orders transactions by price, but limits the number
+	// of transactions a single sender can have in a block
+	SenderCapOrdering OrderingPolicyType = "sender-cap"
+
+	// PriorityLanesOrdering orders transactions sent to the priority addresses first,
+	// followed by the rest of the transactions, both ordered by price
+	PriorityLanesOrdering OrderingPolicyType = "priority-lanes"
+)
+
+// TxOrderingPolicy defines the order in which the executable transactions
+// (the lowest nonce promoted transaction of each account) are handed over
+// to the block builders through Peek and Pop.
+type TxOrderingPolicy interface {
+	// Reset clears the policy state before building a block with the given base fee
+	Reset(baseFee uint64)
+
+	// Push adds an executable transaction
+	Push(tx *types.Transaction)
+
+	// Pop removes and returns the next transaction to be executed,
+	// or nil if there are no more transactions
+	Pop() *types.Transaction
+
+	// Included marks a popped transaction as included in the block,
+	// right before the next transaction of its sender is pushed
+	Included(tx *types.Transaction)
+
+	// Length returns the number of executable transactions
+	Length() uint64
+}
+
+// newOrderingPolicy creates the ordering policy set in the pool config
+func newOrderingPolicy(config *Config, arrivalOf func(types.Hash) uint64) (TxOrderingPolicy, error) {
+	switch config.OrderingPolicy {
+	case "", PriceOrdering:
+		return newPriceOrderingPolicy(), nil
+	case FIFOOrdering:
+		return newFIFOOrderingPolicy(arrivalOf), nil
+	case SenderCapOrdering:
+		if config.MaxTxsPerSender == 0 {
+			return nil, fmt.Errorf("ordering policy %s requires a non-zero sender cap", config.OrderingPolicy)
+		}
+
+		return newSenderCapOrderingPolicy(newPriceOrderingPolicy(), config.MaxTxsPerSender), nil
+	case PriorityLanesOrdering:
+		if len(config.PriorityAddresses) == 0 {
+			return nil, fmt.Errorf("ordering policy %s requires priority addresses", config.OrderingPolicy)
+		}
+
+		return newPriorityLanesOrderingPolicy(config.PriorityAddresses), nil
+	default:
+		return nil, fmt.Errorf("unknown ordering policy %s", config.OrderingPolicy)
+	}
+}
+
+// priceOrderingPolicy orders transactions by their effective tip
+type priceOrderingPolicy struct {
+	queue *pricedQueue
+}
+
+func newPriceOrderingPolicy() *priceOrderingPolicy {
+	return &priceOrderingPolicy{
+		queue: newPricedQueue(),
+	}
+}
+
+func (p *priceOrderingPolicy) Reset(baseFee uint64) {
+	p.queue.clear()
+	atomic.StoreUint64(&p.queue.queue.baseFee, baseFee)
+}
+
+func (p *priceOrderingPolicy) Push(tx *types.Transaction) {
+	p.queue.push(tx)
+}
+
+func (p *priceOrderingPolicy) Pop() *types.Transaction {
+	return p.queue.pop()
+}
+
+func (p *priceOrderingPolicy) Included(_ *types.Transaction) {}
+
+func (p *priceOrderingPolicy) Length() uint64 {
+	return p.queue.length()
+}
+
+// fifoOrderingPolicy orders transactions by the time they arrived to the pool
+type fifoOrderingPolicy struct {
+	queue *arrivalQueue
+}
+
+func newFIFOOrderingPolicy(arrivalOf func(types.Hash) uint64) *fifoOrderingPolicy {
+	return &fifoOrderingPolicy{
+		queue: &arrivalQueue{arrivalOf: arrivalOf},
+	}
+}
+
+func (p *fifoOrderingPolicy) Reset(_ uint64) {
+	p.queue.txs = p.queue.txs[:0]
+}
+
+func (p *fifoOrderingPolicy) Push(tx *types.Transaction) {
+	// the arrival order is fixed when pushing, so the heap stays
+	// consistent even if the transaction leaves the pool meanwhile
+	heap.Push(p.queue, &arrivalTx{
+		tx:      tx,
+		arrival: p.queue.arrivalOf(tx.Hash),
+	})
+}
+
+func (p *fifoOrderingPolicy) Pop() *types.Transaction {
+	if p.queue.Len() == 0 {
+		return nil
+	}
+
+	entry, _ := heap.Pop(p.queue).(*arrivalTx)
+
+	return entry.tx
+}
+
+func (p *fifoOrderingPolicy) Included(_ *types.Transaction) {}
+
+func (p *fifoOrderingPolicy) Length() uint64 {
+	return uint64(p.queue.Len())
+}
+
+// arrivalTx is a transaction along with its arrival order
+type arrivalTx struct {
+	tx      *types.Transaction
+	arrival uint64
+}
+
+// transactions sorted by arrival order (ascending)
+type arrivalQueue struct {
+	arrivalOf func(types.Hash) uint64
+	txs       []*arrivalTx
+}
+
+/* Queue methods required by the heap interface */
+
+func (q *arrivalQueue) Len() int {
+	return len(q.txs)
+}
+
+func (q *arrivalQueue) Swap(i, j int) {
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
+}
+
+func (q *arrivalQueue) Less(i, j int) bool {
+	return q.txs[i].arrival < q.txs[j].arrival
+}
+
+func (q *arrivalQueue) Push(x interface{}) {
+	entry, ok := x.(*arrivalTx)
+	if !ok {
+		return
+	}
+
+	q.txs = append(q.txs, entry)
+}
+
+func (q *arrivalQueue) Pop() interface{} {
+	old := q.txs
+	n := len(old)
+	x := old[n-1]
+	q.txs = old[0 : n-1]
+
+	return x
+}
+
+// senderCapOrderingPolicy limits the number of transactions
+// included for a single sender while building a block
+type senderCapOrderingPolicy struct {
+	TxOrderingPolicy
+
+	maxTxsPerSender uint64
+	included        map[types.Address]uint64
+}
+
+func newSenderCapOrderingPolicy(inner TxOrderingPolicy, maxTxsPerSender uint64) *senderCapOrderingPolicy {
+	return &senderCapOrderingPolicy{
+		TxOrderingPolicy: inner,
+		maxTxsPerSender:  maxTxsPerSender,
+		included:         make(map[types.Address]uint64),
+	}
+}
+
+func (p *senderCapOrderingPolicy) Reset(baseFee uint64) {
+	p.TxOrderingPolicy.Reset(baseFee)
+	p.included = make(map[types.Address]uint64)
+}
+
+func (p *senderCapOrderingPolicy) Push(tx *types.Transaction) {
+	// the sender has reached its cap for the current block,
+	// the rest of its transactions wait for the next one
+	if p.included[tx.From] >= p.maxTxsPerSender {
+		return
+	}
+
+	p.TxOrderingPolicy.Push(tx)
+}
+
+// Included counts the transaction against the cap of its sender.
+// The transactions dropped or demoted by the block builder are not counted
+func (p *senderCapOrderingPolicy) Included(tx *types.Transaction) {
+	p.TxOrderingPolicy.Included(tx)
+	p.included[tx.From]++
+}
+
+// priorityLanesOrderingPolicy hands over the transactions sent to
+// the priority addresses before any other transaction
+type priorityLanesOrderingPolicy struct {
+	priorityAddresses map[types.Address]struct{}
+
+	priority *priceOrderingPolicy
+	regular  *priceOrderingPolicy
+}
+
+func newPriorityLanesOrderingPolicy(addresses []types.Address) *priorityLanesOrderingPolicy {
+	priorityAddresses := make(map[types.Address]struct{}, len(addresses))
+	for _, addr := range addresses {
+		priorityAddresses[addr] = struct{}{}
+	}
+
+	return &priorityLanesOrderingPolicy{
+		priorityAddresses: priorityAddresses,
+		priority:          newPriceOrderingPolicy(),
+		regular:           newPriceOrderingPolicy(),
+	}
+}
+
+func (p *priorityLanesOrderingPolicy) Reset(baseFee uint64) {
+	p.priority.Reset(baseFee)
+	p.regular.Reset(baseFee)
+}
+
+func (p *priorityLanesOrderingPolicy) Push(tx *types.Transaction) {
+	if tx.To != nil {
+		if _, ok := p.priorityAddresses[*tx.To]; ok {
+			p.priority.Push(tx)
+
+			return
+		}
+	}
+
+	p.regular.Push(tx)
+}
+
+func (p *priorityLanesOrderingPolicy) Pop() *types.Transaction {
+	if tx := p.priority.Pop(); tx != nil {
+		return tx
+	}
+
+	return p.regular.Pop()
+}
+
+func (p *priorityLanesOrderingPolicy) Included(_ *types.Transaction) {}
+
+func (p *priorityLanesOrderingPolicy) Length() uint64 {
+	return p.priority.Length() + p.regular.Length()
+}
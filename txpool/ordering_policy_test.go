@@ -0,0 +1,171 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newPricedTx(from types.Address, to *types.Address, gasPrice int64) *types.Transaction {
+	tx := &types.Transaction{
+		Type:     types.LegacyTx,
+		From:     from,
+		To:       to,
+		GasPrice: big.NewInt(gasPrice),
+	}
+	tx.ComputeHash()
+
+	return tx
+}
+
+func popAll(policy TxOrderingPolicy) (txs []*types.Transaction) {
+	for tx := policy.Pop(); tx != nil; tx = policy.Pop() {
+		txs = append(txs, tx)
+	}
+
+	return
+}
+
+func Test_newOrderingPolicy(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name        string
+		config      *Config
+		expectedErr bool
+	}{
+		{"default", &Config{}, false},
+		{"price", &Config{OrderingPolicy: PriceOrdering}, false},
+		{"fifo", &Config{OrderingPolicy: FIFOOrdering}, false},
+		{"sender cap", &Config{OrderingPolicy: SenderCapOrdering, MaxTxsPerSender: 1}, false},
+		{"sender cap without cap", &Config{OrderingPolicy: SenderCapOrdering}, true},
+		{"priority lanes", &Config{OrderingPolicy: PriorityLanesOrdering, PriorityAddresses: []types.Address{addr1}}, false},
+		{"priority lanes without addresses", &Config{OrderingPolicy: PriorityLanesOrdering}, true},
+		{"unknown", &Config{OrderingPolicy: "unknown"}, true},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := newOrderingPolicy(tt.config, func(types.Hash) uint64 { return 0 })
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, policy)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, policy)
+			}
+		})
+	}
+}
+
+func Test_fifoOrderingPolicy(t *testing.T) {
+	t.Parallel()
+
+	txs := []*types.Transaction{
+		newPricedTx(addr1, nil, 1),
+		newPricedTx(addr2, nil, 100),
+		newPricedTx(addr3, nil, 10),
+	}
+
+	arrivals := map[types.Hash]uint64{
+		txs[0].Hash: 2,
+		txs[1].Hash: 0,
+		txs[2].Hash: 1,
+	}
+
+	policy := newFIFOOrderingPolicy(func(hash types.Hash) uint64 {
+		return arrivals[hash]
+	})
+	policy.Reset(0)
+
+	for _, tx := range txs {
+		policy.Push(tx)
+	}
+
+	require.Equal(t, uint64(3), policy.Length())
+
+	// the transactions leaving the pool keep their position
+	delete(arrivals, txs[1].Hash)
+	delete(arrivals, txs[2].Hash)
+
+	assert.Equal(t, []*types.Transaction{txs[1], txs[2], txs[0]}, popAll(policy))
+}
+
+func Test_senderCapOrderingPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := newSenderCapOrderingPolicy(newPriceOrderingPolicy(), 2)
+	policy.Reset(0)
+
+	// the next tx of the sender is pushed after each inclusion
+	policy.Push(newPricedTx(addr1, nil, 100))
+
+	for i := 0; i < 2; i++ {
+		tx := policy.Pop()
+		require.NotNil(t, tx)
+		assert.Equal(t, addr1, tx.From)
+
+		policy.Included(tx)
+		policy.Push(newPricedTx(addr1, nil, 100))
+	}
+
+	// cap reached for addr1, other senders are not affected
+	assert.Equal(t, uint64(0), policy.Length())
+
+	policy.Push(newPricedTx(addr2, nil, 1))
+	assert.Equal(t, []types.Address{addr2}, fromAddresses(popAll(policy)))
+
+	// the txs popped but not included (dropped or demoted) are not counted
+	policy.Push(newPricedTx(addr3, nil, 1))
+	require.NotNil(t, policy.Pop())
+	policy.Push(newPricedTx(addr3, nil, 1))
+	require.NotNil(t, policy.Pop())
+	policy.Push(newPricedTx(addr3, nil, 1))
+	assert.Equal(t, uint64(1), policy.Length())
+
+	// cap is cleared for the next block
+	policy.Reset(0)
+	policy.Push(newPricedTx(addr1, nil, 100))
+	assert.Equal(t, uint64(1), policy.Length())
+}
+
+func Test_priorityLanesOrderingPolicy(t *testing.T) {
+	t.Parallel()
+
+	priorityContract := types.StringToAddress("0xabcd")
+	regularContract := types.StringToAddress("0xef01")
+
+	policy := newPriorityLanesOrderingPolicy([]types.Address{priorityContract})
+	policy.Reset(0)
+
+	policy.Push(newPricedTx(addr1, &regularContract, 100))
+	policy.Push(newPricedTx(addr2, &priorityContract, 1))
+	policy.Push(newPricedTx(addr3, nil, 50))
+	policy.Push(newPricedTx(addr4, &priorityContract, 10))
+
+	require.Equal(t, uint64(4), policy.Length())
+
+	popped := fromAddresses(popAll(policy))
+	require.Len(t, popped, 4)
+
+	// priority lane goes first regardless of the price
+	assert.ElementsMatch(t, []types.Address{addr2, addr4}, popped[:2])
+	assert.ElementsMatch(t, []types.Address{addr1, addr3}, popped[2:])
+}
+
+func fromAddresses(txs []*types.Transaction) []types.Address {
+	addresses := make([]types.Address, len(txs))
+	for i, tx := range txs {
+		addresses[i] = tx.From
+	}
+
+	return addresses
+}
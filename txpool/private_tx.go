@@ -0,0 +1,253 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const privateTxProto = "/txpool/private/0.1"
+
+var (
+	ErrUntrustedPeer = errors.New("peer is not trusted for private transactions")
+)
+
+// privateTxs keeps track of the transactions submitted privately
+// (not gossiped) and the block number at which they expire.
+type privateTxs struct {
+	sync.Mutex
+
+	expiries map[types.Hash]uint64
+}
+
+func newPrivateTxs() *privateTxs {
+	return &privateTxs{
+		expiries: make(map[types.Hash]uint64),
+	}
+}
+
+// add marks the transaction as private until the given block number
+func (p *privateTxs) add(hash types.Hash, expiry uint64) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.expiries[hash] = expiry
+}
+
+// popExpired removes and returns all the transactions
+// that expire at (or before) the given block number
+func (p *privateTxs) popExpired(number uint64) (expired []types.Hash) {
+	p.Lock()
+	defer p.Unlock()
+
+	for hash, expiry := range p.expiries {
+		if expiry <= number {
+			expired = append(expired, hash)
+
+			delete(p.expiries, hash)
+		}
+	}
+
+	return
+}
+
+// privateTxRelay hands over private transactions to a set of trusted peers
+// (usually validators) over a direct libp2p protocol, and accepts theirs
+type privateTxRelay struct {
+	proto.UnimplementedTxRelayServer
+
+	logger  hclog.Logger
+	network *network.Server
+	pool    *TxPool
+	stream  *grpc.GrpcStream
+
+	trustedPeers map[peer.ID]struct{}
+}
+
+func newPrivateTxRelay(
+	logger hclog.Logger,
+	network *network.Server,
+	pool *TxPool,
+	trustedPeers []peer.ID,
+) *privateTxRelay {
+	relay := &privateTxRelay{
+		logger:       logger.Named("private-relay"),
+		network:      network,
+		pool:         pool,
+		trustedPeers: make(map[peer.ID]struct{}, len(trustedPeers)),
+	}
+
+	for _, id := range trustedPeers {
+		relay.trustedPeers[id] = struct{}{}
+	}
+
+	relay.stream = grpc.NewGrpcStream()
+	proto.RegisterTxRelayServer(relay.stream.GrpcServer(), relay)
+	relay.stream.Serve()
+	network.RegisterProtocol(privateTxProto, relay.stream)
+
+	return relay
+}
+
+// SendPrivateTxn is a gRPC endpoint receiving private transactions from trusted peers
+func (r *privateTxRelay) SendPrivateTxn(ctx context.Context, raw *proto.Txn) (*emptypb.Empty, error) {
+	grpcContext, ok := ctx.(*grpc.Context)
+	if !ok {
+		return nil, errors.New("invalid type assertion for grpc context")
+	}
+
+	if _, trusted := r.trustedPeers[grpcContext.PeerID]; !trusted {
+		return nil, ErrUntrustedPeer
+	}
+
+	if raw.Raw == nil {
+		return nil, errors.New("transaction's field raw is empty")
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
+		return nil, err
+	}
+
+	if err := r.pool.addPrivateTx(relayed, tx, ""); err != nil && !errors.Is(err, ErrAlreadyKnown) {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// broadcast sends the private transaction to all the connected trusted peers
+func (r *privateTxRelay) broadcast(tx *types.Transaction) {
+	msg := &proto.Txn{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}
+
+	for id := range r.trustedPeers {
+		if !r.network.IsConnected(id) {
+			continue
+		}
+
+		go func(id peer.ID) {
+			conn, err := r.network.NewProtoConnection(privateTxProto, id)
+			if err != nil {
+				r.logger.Error("failed to open private tx stream", "peer", id, "err", err)
+
+				return
+			}
+
+			defer func() {
+				_ = conn.Close()
+			}()
+
+			if _, err := proto.NewTxRelayClient(conn).SendPrivateTxn(context.Background(), msg); err != nil {
+				r.logger.Error("failed to relay private tx", "peer", id, "hash", tx.Hash, "err", err)
+
+				return
+			}
+
+			metrics.IncrCounter([]string{txPoolMetrics, "private_txs_relayed"}, 1)
+		}(id)
+	}
+}
+
+// close stops accepting private transactions from the trusted peers
+func (r *privateTxRelay) close() error {
+	return r.stream.Close()
+}
+
+// AddPrivateTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
+// without broadcasting it to the network. The transaction is only handed over to the
+// configured trusted peers, and is dropped if not included within the private tx expiry.
+func (p *TxPool) AddPrivateTx(tx *types.Transaction) error {
+	return p.AddPrivateTxFromClient(tx, "")
+}
+
+// AddPrivateTxFromClient is AddPrivateTx for the transactions whose submitting
+// RPC client is known, so the admission hooks can take it into account.
+func (p *TxPool) AddPrivateTxFromClient(tx *types.Transaction, clientAddr string) error {
+	if err := p.addPrivateTx(private, tx, clientAddr); err != nil {
+		p.logger.Error("failed to add private tx", "err", err)
+
+		return err
+	}
+
+	if p.privateRelay != nil {
+		p.privateRelay.broadcast(tx)
+	}
+
+	return nil
+}
+
+// addPrivateTx adds the transaction to the pool and marks it as private
+func (p *TxPool) addPrivateTx(origin txOrigin, tx *types.Transaction, clientAddr string) error {
+	if err := p.addTxFromClient(origin, tx, clientAddr); err != nil {
+		return err
+	}
+
+	if p.privateTxExpiry > 0 {
+		p.privateTxs.add(tx.Hash, p.store.Header().Number+p.privateTxExpiry)
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "private_txs"}, 1)
+
+	return nil
+}
+
+// pruneExpiredPrivateTxs removes the private transactions that were not
+// included within the private tx expiry. Transactions of the same account with
+// higher nonce are removed too, as they can no longer be executed.
+func (p *TxPool) pruneExpiredPrivateTxs(number uint64) {
+	if p.privateTxExpiry == 0 {
+		return
+	}
+
+	dropped := 0
+
+	for _, hash := range p.privateTxs.popExpired(number) {
+		tx, ok := p.index.get(hash)
+		if !ok {
+			// already included in a block or removed from the pool
+			continue
+		}
+
+		account := p.accounts.get(tx.From)
+		if account == nil {
+			continue
+		}
+
+		removedPromoted, removedEnqueued := account.removeFrom(tx.Nonce)
+
+		// signaled before the private txs are removed from the index, to leave them out
+		p.eventManager.signalEvent(
+			proto.EventType_DROPPED,
+			append(toHash(removedPromoted...), toHash(removedEnqueued...)...)...,
+		)
+
+		p.index.remove(removedPromoted...)
+		p.index.remove(removedEnqueued...)
+		p.gauge.decrease(slotsRequired(removedPromoted...) + slotsRequired(removedEnqueued...))
+		p.updatePending(int64(-1 * len(removedPromoted)))
+
+		dropped += len(removedPromoted) + len(removedEnqueued)
+	}
+
+	if dropped == 0 {
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "expired_private_txs"}, float32(dropped))
+
+	p.logger.Debug("dropped expired private txs", "num", dropped, "block", number)
+}
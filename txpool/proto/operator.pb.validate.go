@@ -371,6 +371,535 @@ var _ interface {
 	ErrorName() string
 } = TxnPoolStatusRespValidationError{}
 
+// Validate checks the field values on ContentFromReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ContentFromReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ContentFromReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ContentFromReqMultiError, or
+// nil if none found.
+func (m *ContentFromReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ContentFromReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_ContentFromReq_Address_Pattern.MatchString(m.GetAddress()) {
+		err := ContentFromReqValidationError{
+			field:  "Address",
+			reason: "value does not match regex pattern \"^0x[a-fA-F0-9]{40}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ContentFromReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ContentFromReqMultiError is an error wrapping multiple validation errors
+// returned by ContentFromReq.ValidateAll() if the designated constraints aren't
+// met.
+type ContentFromReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ContentFromReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ContentFromReqMultiError) AllErrors() []error { return m }
+
+// ContentFromReqValidationError is the validation error returned by
+// ContentFromReq.Validate if the designated constraints aren't met.
+type ContentFromReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContentFromReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContentFromReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContentFromReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContentFromReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContentFromReqValidationError) ErrorName() string { return "ContentFromReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ContentFromReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContentFromReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContentFromReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContentFromReqValidationError{}
+
+var _ContentFromReq_Address_Pattern = regexp.MustCompile("^0x[a-fA-F0-9]{40}$")
+
+// Validate checks the field values on ContentFromResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ContentFromResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ContentFromResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ContentFromRespMultiError, or
+// nil if none found.
+func (m *ContentFromResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ContentFromResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetPending() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Pending[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Pending[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ContentFromRespValidationError{
+					field:  fmt.Sprintf("Pending[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetQueued() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Queued[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Queued[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ContentFromRespValidationError{
+					field:  fmt.Sprintf("Queued[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ContentFromRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// ContentFromRespMultiError is an error wrapping multiple validation errors
+// returned by ContentFromResp.ValidateAll() if the designated constraints
+// aren't met.
+type ContentFromRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ContentFromRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ContentFromRespMultiError) AllErrors() []error { return m }
+
+// ContentFromRespValidationError is the validation error returned by
+// ContentFromResp.Validate if the designated constraints aren't met.
+type ContentFromRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContentFromRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContentFromRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContentFromRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContentFromRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContentFromRespValidationError) ErrorName() string {
+	return "ContentFromRespValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ContentFromRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContentFromResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContentFromRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContentFromRespValidationError{}
+
+// Validate checks the field values on GetTxnReq with the rules defined in the
+// proto definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *GetTxnReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetTxnReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in GetTxnReqMultiError, or nil if none
+// found.
+func (m *GetTxnReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetTxnReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_GetTxnReq_TxHash_Pattern.MatchString(m.GetTxHash()) {
+		err := GetTxnReqValidationError{
+			field:  "TxHash",
+			reason: "value does not match regex pattern \"^0x[a-fA-F0-9]{64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetTxnReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetTxnReqMultiError is an error wrapping multiple validation errors returned
+// by GetTxnReq.ValidateAll() if the designated constraints aren't met.
+type GetTxnReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetTxnReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetTxnReqMultiError) AllErrors() []error { return m }
+
+// GetTxnReqValidationError is the validation error returned by
+// GetTxnReq.Validate if the designated constraints aren't met.
+type GetTxnReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetTxnReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetTxnReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetTxnReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetTxnReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetTxnReqValidationError) ErrorName() string { return "GetTxnReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetTxnReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetTxnReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetTxnReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetTxnReqValidationError{}
+
+var _GetTxnReq_TxHash_Pattern = regexp.MustCompile("^0x[a-fA-F0-9]{64}$")
+
+// Validate checks the field values on GetTxnResp with the rules defined in the
+// proto definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *GetTxnResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetTxnResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in GetTxnRespMultiError, or nil if none
+// found.
+func (m *GetTxnResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetTxnResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetRaw()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetTxnRespValidationError{
+					field:  "Raw",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetTxnRespValidationError{
+					field:  "Raw",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRaw()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetTxnRespValidationError{
+				field:  "Raw",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for From
+
+	// no validation rules for Status
+
+	// no validation rules for Position
+
+	if len(errors) > 0 {
+		return GetTxnRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetTxnRespMultiError is an error wrapping multiple validation errors returned
+// by GetTxnResp.ValidateAll() if the designated constraints aren't met.
+type GetTxnRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetTxnRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetTxnRespMultiError) AllErrors() []error { return m }
+
+// GetTxnRespValidationError is the validation error returned by
+// GetTxnResp.Validate if the designated constraints aren't met.
+type GetTxnRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetTxnRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetTxnRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetTxnRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetTxnRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetTxnRespValidationError) ErrorName() string { return "GetTxnRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetTxnRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetTxnResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetTxnRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetTxnRespValidationError{}
+
 // Validate checks the field values on SubscribeRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -27,8 +27,14 @@ type TxnPoolOperatorClient interface {
 	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TxnPoolStatusResp, error)
 	// AddTxn adds a local transaction to the pool
 	AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
+	// AddPrivateTxn adds a local transaction to the pool without gossiping it
+	AddPrivateTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error)
+	// ContentFrom returns the pending and queued transactions of an account
+	ContentFrom(ctx context.Context, in *ContentFromReq, opts ...grpc.CallOption) (*ContentFromResp, error)
+	// GetTxn returns a transaction from the pool along with its status
+	GetTxn(ctx context.Context, in *GetTxnReq, opts ...grpc.CallOption) (*GetTxnResp, error)
 }
 
 type txnPoolOperatorClient struct {
@@ -57,6 +63,15 @@ func (c *txnPoolOperatorClient) AddTxn(ctx context.Context, in *AddTxnReq, opts
 	return out, nil
 }
 
+func (c *txnPoolOperatorClient) AddPrivateTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error) {
+	out := new(AddTxnResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/AddPrivateTxn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *txnPoolOperatorClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[0], "/v1.TxnPoolOperator/Subscribe", opts...)
 	if err != nil {
@@ -89,6 +104,24 @@ func (x *txnPoolOperatorSubscribeClient) Recv() (*TxPoolEvent, error) {
 	return m, nil
 }
 
+func (c *txnPoolOperatorClient) ContentFrom(ctx context.Context, in *ContentFromReq, opts ...grpc.CallOption) (*ContentFromResp, error) {
+	out := new(ContentFromResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/ContentFrom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) GetTxn(ctx context.Context, in *GetTxnReq, opts ...grpc.CallOption) (*GetTxnResp, error) {
+	out := new(GetTxnResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/GetTxn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxnPoolOperatorServer is the server API for TxnPoolOperator service.
 // All implementations must embed UnimplementedTxnPoolOperatorServer
 // for forward compatibility
@@ -97,8 +130,14 @@ type TxnPoolOperatorServer interface {
 	Status(context.Context, *emptypb.Empty) (*TxnPoolStatusResp, error)
 	// AddTxn adds a local transaction to the pool
 	AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
+	// AddPrivateTxn adds a local transaction to the pool without gossiping it
+	AddPrivateTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error
+	// ContentFrom returns the pending and queued transactions of an account
+	ContentFrom(context.Context, *ContentFromReq) (*ContentFromResp, error)
+	// GetTxn returns a transaction from the pool along with its status
+	GetTxn(context.Context, *GetTxnReq) (*GetTxnResp, error)
 	mustEmbedUnimplementedTxnPoolOperatorServer()
 }
 
@@ -112,9 +151,18 @@ func (UnimplementedTxnPoolOperatorServer) Status(context.Context, *emptypb.Empty
 func (UnimplementedTxnPoolOperatorServer) AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTxn not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) AddPrivateTxn(context.Context, *AddTxnReq) (*AddTxnResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPrivateTxn not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) ContentFrom(context.Context, *ContentFromReq) (*ContentFromResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContentFrom not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) GetTxn(context.Context, *GetTxnReq) (*GetTxnResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxn not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) mustEmbedUnimplementedTxnPoolOperatorServer() {}
 
 // UnsafeTxnPoolOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -164,6 +212,24 @@ func _TxnPoolOperator_AddTxn_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TxnPoolOperator_AddPrivateTxn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).AddPrivateTxn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/AddPrivateTxn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).AddPrivateTxn(ctx, req.(*AddTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TxnPoolOperator_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -185,6 +251,42 @@ func (x *txnPoolOperatorSubscribeServer) Send(m *TxPoolEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TxnPoolOperator_ContentFrom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentFromReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).ContentFrom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/ContentFrom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).ContentFrom(ctx, req.(*ContentFromReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_GetTxn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).GetTxn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/GetTxn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).GetTxn(ctx, req.(*GetTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TxnPoolOperator_ServiceDesc is the grpc.ServiceDesc for TxnPoolOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -200,6 +302,18 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddTxn",
 			Handler:    _TxnPoolOperator_AddTxn_Handler,
 		},
+		{
+			MethodName: "AddPrivateTxn",
+			Handler:    _TxnPoolOperator_AddPrivateTxn_Handler,
+		},
+		{
+			MethodName: "ContentFrom",
+			Handler:    _TxnPoolOperator_ContentFrom_Handler,
+		},
+		{
+			MethodName: "GetTxn",
+			Handler:    _TxnPoolOperator_GetTxn_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
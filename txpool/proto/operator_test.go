@@ -51,6 +51,36 @@ func TestRequestValidation(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "ContentFromReq: invalid address",
+			req: &ContentFromReq{
+				Address: "1234",
+			},
+			valid:    false,
+			errorMsg: "invalid ContentFromReq.Address: value does not match regex pattern",
+		},
+		{
+			name: "ContentFromReq: valid address",
+			req: &ContentFromReq{
+				Address: "0x9FC184A287e4BB51Eef4ecA81788eA10EF3f202f",
+			},
+			valid: true,
+		},
+		{
+			name: "GetTxnReq: invalid hash",
+			req: &GetTxnReq{
+				TxHash: "0x9FC184A287e4BB51Eef4ecA81788eA10EF3f202f",
+			},
+			valid:    false,
+			errorMsg: "invalid GetTxnReq.TxHash: value does not match regex pattern",
+		},
+		{
+			name: "GetTxnReq: valid hash",
+			req: &GetTxnReq{
+				TxHash: "0x3c7a7f5a2b8a3e1a2f2d9e1b0b5c5e8c1f6b0d6f8d2a1e4b5c6d7e8f9a0b1c2d",
+			},
+			valid: true,
+		},
 		{
 			name: "SubscribeRequest: type not specified",
 			req: &SubscribeRequest{
@@ -3,9 +3,40 @@ package txpool
 import (
 	"sync/atomic"
 
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// TxStatus is the status of a transaction in the pool
+type TxStatus int
+
+const (
+	// TxStatusPending is the status of the promoted transactions, ready for execution
+	TxStatusPending TxStatus = iota
+	// TxStatusQueued is the status of the enqueued transactions, waiting for a nonce gap to be filled
+	TxStatusQueued
+)
+
+func (s TxStatus) String() string {
+	switch s {
+	case TxStatusPending:
+		return "pending"
+	case TxStatusQueued:
+		return "queued"
+	default:
+		return "unknown"
+	}
+}
+
+// TxLookup is a transaction found in the pool along with its status
+type TxLookup struct {
+	Tx     *types.Transaction
+	Status TxStatus
+	// Position is the number of transactions of the same account
+	// ahead of this one in the same queue
+	Position uint64
+}
+
 /* QUERY methods */
 // Used to query the pool for specific state info.
 
@@ -32,23 +63,134 @@ func (p *TxPool) GetCapacity() (uint64, uint64) {
 	return p.gauge.read(), p.gauge.max
 }
 
-// GetPendingTx returns the transaction by hash in the TxPool (pending txn) [Thread-safe]
+// GetPendingTx returns the transaction by hash in the TxPool (pending txn).
+// The private transactions are not returned [Thread-safe]
 func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	tx, ok := p.index.get(txHash)
-	if !ok {
+	if !ok || p.index.isPrivate(txHash) {
 		return nil, false
 	}
 
 	return tx, true
 }
 
-// GetTxs gets pending and queued transactions
+// GetTxs gets pending and queued transactions, without the private ones
 func (p *TxPool) GetTxs(inclQueued bool) (
 	allPromoted, allEnqueued map[types.Address][]*types.Transaction,
 ) {
 	allPromoted, allEnqueued = p.accounts.allTxs(inclQueued)
 
-	return
+	return p.publicTxsByAccount(allPromoted), p.publicTxsByAccount(allEnqueued)
+}
+
+// GetTxsFrom gets the pending and queued transactions of the given account, sorted by nonce.
+// The private transactions are left out
+func (p *TxPool) GetTxsFrom(addr types.Address) (promoted, enqueued []*types.Transaction) {
+	promoted, enqueued = p.accounts.txsFrom(addr)
+
+	return p.publicTxs(promoted), p.publicTxs(enqueued)
+}
+
+// publicTxs returns the given transactions without the private ones
+func (p *TxPool) publicTxs(txs []*types.Transaction) []*types.Transaction {
+	public := make([]*types.Transaction, 0, len(txs))
+
+	for _, tx := range txs {
+		if !p.index.isPrivate(tx.Hash) {
+			public = append(public, tx)
+		}
+	}
+
+	return public
+}
+
+// publicTxsByAccount returns the given transactions of each account without the private ones,
+// leaving out the accounts which only have private transactions
+func (p *TxPool) publicTxsByAccount(
+	txsByAccount map[types.Address][]*types.Transaction,
+) map[types.Address][]*types.Transaction {
+	public := make(map[types.Address][]*types.Transaction, len(txsByAccount))
+
+	for addr, txs := range txsByAccount {
+		if publicTxs := p.publicTxs(txs); len(publicTxs) > 0 {
+			public[addr] = publicTxs
+		}
+	}
+
+	return public
+}
+
+// GetPendingTxs returns a snapshot of the promoted transactions, in the order
+// the block builders execute them: by the configured ordering policy, with the
+// transactions of each account in nonce order. The private transactions, and
+// the following ones of the same account, are left out. Unlike Peek and Pop,
+// it leaves the executables of the pool untouched [Thread-safe]
+func (p *TxPool) GetPendingTxs(baseFee uint64) []*types.Transaction {
+	promoted := p.accounts.promotedSorted()
+
+	policy := p.newOrderingPolicy()
+	policy.Reset(baseFee)
+
+	for addr, txs := range promoted {
+		policy.Push(txs[0])
+		promoted[addr] = txs[1:]
+	}
+
+	sorted := make([]*types.Transaction, 0, len(promoted))
+
+	for tx := policy.Pop(); tx != nil; tx = policy.Pop() {
+		// the next transactions of the account can not be executed without it
+		if p.index.isPrivate(tx.Hash) {
+			continue
+		}
+
+		sorted = append(sorted, tx)
+		policy.Included(tx)
+
+		// the next transaction of the account becomes executable
+		if txs := promoted[tx.From]; len(txs) > 0 {
+			policy.Push(txs[0])
+			promoted[tx.From] = txs[1:]
+		}
+	}
+
+	return sorted
+}
+
+// SubscribePoolEvents registers an in-process listener for the given TxPool events.
+// The returned function cancels the subscription, closing the channel.
+func (p *TxPool) SubscribePoolEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	return subscription.subscriptionChannel, func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
+	}
+}
+
+// LookupTx returns the transaction by hash in the TxPool, along with its status
+// and position in the account queue. The private transactions are not returned [Thread-safe]
+func (p *TxPool) LookupTx(txHash types.Hash) (*TxLookup, bool) {
+	tx, ok := p.index.get(txHash)
+	if !ok || p.index.isPrivate(txHash) {
+		return nil, false
+	}
+
+	account := p.accounts.get(tx.From)
+	if account == nil {
+		return nil, false
+	}
+
+	status, position, ok := account.lookup(txHash)
+	if !ok {
+		// the transaction is being enqueued
+		return nil, false
+	}
+
+	return &TxLookup{
+		Tx:       tx,
+		Status:   status,
+		Position: position,
+	}, true
 }
 
 // GetBaseFee returns current base fee
@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"container/heap"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -60,6 +61,27 @@ func (q *accountQueue) prune(nonce uint64) (
 	return
 }
 
+// removeFrom removes all transactions from the queue
+// with nonce greater than or equal to given.
+func (q *accountQueue) removeFrom(nonce uint64) (
+	removed []*types.Transaction,
+) {
+	kept := make(minNonceQueue, 0, q.length())
+
+	for _, tx := range q.queue {
+		if tx.Nonce >= nonce {
+			removed = append(removed, tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+
+	q.queue = kept
+	heap.Init(&q.queue)
+
+	return
+}
+
 // clear removes all transactions from the queue.
 func (q *accountQueue) clear() (removed []*types.Transaction) {
 	// store txs
@@ -99,6 +121,47 @@ func (q *accountQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// sorted returns a copy of the queued transactions sorted by nonce.
+func (q *accountQueue) sorted() []*types.Transaction {
+	txs := make([]*types.Transaction, len(q.queue))
+	copy(txs, q.queue)
+
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Nonce < txs[j].Nonce
+	})
+
+	return txs
+}
+
+// position returns the number of queued transactions
+// with nonce lower than the one of the given transaction.
+func (q *accountQueue) position(hash types.Hash) (uint64, bool) {
+	var (
+		target *types.Transaction
+		lower  uint64
+	)
+
+	for _, tx := range q.queue {
+		if tx.Hash == hash {
+			target = tx
+
+			break
+		}
+	}
+
+	if target == nil {
+		return 0, false
+	}
+
+	for _, tx := range q.queue {
+		if tx.Nonce < target.Nonce {
+			lower++
+		}
+	}
+
+	return lower, true
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
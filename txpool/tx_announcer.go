@@ -0,0 +1,627 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	txAnnouncerProto = "/txpool/announce/0.1"
+
+	// announceInterval is the interval at which the new transactions are propagated, in batches
+	announceInterval = 100 * time.Millisecond
+
+	// maxAnnounceBatch is the maximum number of transactions announced, sent or fetched at once
+	maxAnnounceBatch = 256
+
+	// announceQueueSize is the number of transactions waiting to be propagated
+	// above which the new ones are dropped
+	announceQueueSize = 4096
+
+	// recentTxsLimit is the number of recently propagated transactions kept,
+	// so the peers can fetch them even once they left the pool
+	recentTxsLimit = 4096
+
+	// fetchTimeout is the timeout of a request fetching announced transactions
+	fetchTimeout = 5 * time.Second
+
+	// maxPeerFetches is the maximum number of requests fetching announced transactions
+	// from a peer at once, above which the announcements of the peer are dropped
+	maxPeerFetches = 4
+
+	// maxFetches is the maximum number of requests fetching announced transactions
+	// from all the peers at once, above which the announcements are dropped
+	maxFetches = 64
+)
+
+var (
+	errTooManyAnnouncements = errors.New("too many transactions announced or requested at once")
+)
+
+// propagatedTx is a new transaction to propagate, along with the peer it was received from
+type propagatedTx struct {
+	tx   *types.Transaction
+	from peer.ID
+}
+
+// txAnnouncer propagates the transactions with an eth/68-style protocol: every new transaction
+// is sent in full to a random sqrt(n) subset of the peers, and only announced (hash, type and size)
+// to the other ones, which fetch it over a request/response stream if they don't know it yet.
+//
+// The peers which don't support the protocol keep receiving the transactions over the gossip topic,
+// on which the local transactions are published as long as such peers are connected
+type txAnnouncer struct {
+	proto.UnimplementedTxAnnouncerServer
+
+	logger  hclog.Logger
+	network *network.Server
+	pool    *TxPool
+	stream  *grpc.GrpcStream
+
+	// dial opens a stream to the peer, and returns its client along with the function closing the stream
+	dial func(peerID peer.ID) (proto.TxAnnouncerClient, func() error, error)
+
+	// queue of the transactions waiting to be propagated
+	queue chan propagatedTx
+
+	// recently propagated transactions, by hash
+	recent *lru.Cache
+
+	// transactions being fetched from a peer, and number of fetch requests in flight
+	fetchingLock sync.Mutex
+	fetching     map[types.Hash]struct{}
+	peerFetches  map[peer.ID]int
+	fetchSlots   chan struct{}
+
+	closeCh chan struct{}
+}
+
+func newTxAnnouncer(logger hclog.Logger, network *network.Server, pool *TxPool) (*txAnnouncer, error) {
+	recent, err := lru.New(recentTxsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	announcer := &txAnnouncer{
+		logger:      logger.Named("announcer"),
+		network:     network,
+		pool:        pool,
+		queue:       make(chan propagatedTx, announceQueueSize),
+		recent:      recent,
+		fetching:    make(map[types.Hash]struct{}),
+		peerFetches: make(map[peer.ID]int),
+		fetchSlots:  make(chan struct{}, maxFetches),
+		closeCh:     make(chan struct{}),
+	}
+
+	announcer.dial = announcer.dialPeer
+
+	announcer.stream = grpc.NewGrpcStream()
+	proto.RegisterTxAnnouncerServer(announcer.stream.GrpcServer(), announcer)
+	announcer.stream.Serve()
+	network.RegisterProtocol(txAnnouncerProto, announcer.stream)
+
+	go announcer.run()
+
+	return announcer, nil
+}
+
+// AnnounceTxs is a gRPC endpoint receiving the announcements of new transactions,
+// which are fetched from the announcing peer if they are unknown
+func (a *txAnnouncer) AnnounceTxs(ctx context.Context, req *proto.TxAnnouncements) (*emptypb.Empty, error) {
+	peerID, err := peerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Txs) > maxAnnounceBatch {
+		a.pool.penalizeGossipPeer(peerID, common.PenaltyMalformedMessage, errTooManyAnnouncements.Error())
+
+		return nil, errTooManyAnnouncements
+	}
+
+	announced := make(map[types.Hash]*proto.TxAnnouncement, len(req.Txs))
+
+	for _, ann := range req.Txs {
+		if len(ann.Hash) != types.HashLength || !isAnnouncedTypeValid(ann.Type) || ann.Size > txMaxSize {
+			a.pool.penalizeGossipPeer(peerID, common.PenaltyInvalidTx, "invalid transaction announcement")
+
+			continue
+		}
+
+		announced[types.BytesToHash(ann.Hash)] = ann
+	}
+
+	toFetch := a.markFetching(announced)
+	if len(toFetch) == 0 {
+		return &emptypb.Empty{}, nil
+	}
+
+	// the announcements are dropped while too many fetches are in flight,
+	// the transactions are received again from the other peers
+	if !a.acquireFetch(peerID) {
+		a.unmarkFetching(toFetch)
+
+		metrics.IncrCounter([]string{txPoolMetrics, "dropped_fetches"}, 1)
+		a.logger.Debug("too many announced txs being fetched, dropping announcement", "peer", peerID)
+
+		return &emptypb.Empty{}, nil
+	}
+
+	// fetch the unknown transactions in the background, without holding the announcing peer
+	go a.fetch(peerID, toFetch)
+
+	return &emptypb.Empty{}, nil
+}
+
+// SendTxs is a gRPC endpoint receiving new transactions in full
+func (a *txAnnouncer) SendTxs(ctx context.Context, req *proto.Txns) (*emptypb.Empty, error) {
+	peerID, err := peerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Txs) > maxAnnounceBatch {
+		a.pool.penalizeGossipPeer(peerID, common.PenaltyMalformedMessage, errTooManyAnnouncements.Error())
+
+		return nil, errTooManyAnnouncements
+	}
+
+	for _, raw := range req.Txs {
+		tx, err := decodeAnnouncedTx(raw)
+		if err != nil {
+			a.logger.Debug("failed to decode sent tx", "peer", peerID, "err", err)
+			a.pool.penalizeGossipPeer(peerID, common.PenaltyMalformedMessage, "undecodable transaction")
+
+			continue
+		}
+
+		a.receive(peerID, tx)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetTxs is a gRPC endpoint returning the requested transactions, if they are known.
+// The private transactions are never served
+func (a *txAnnouncer) GetTxs(_ context.Context, req *proto.TxHashes) (*proto.Txns, error) {
+	if len(req.Hashes) > maxAnnounceBatch {
+		return nil, errTooManyAnnouncements
+	}
+
+	resp := &proto.Txns{}
+
+	for _, rawHash := range req.Hashes {
+		hash := types.BytesToHash(rawHash)
+		if a.pool.index.isPrivate(hash) {
+			continue
+		}
+
+		if tx, ok := a.get(hash); ok {
+			resp.Txs = append(resp.Txs, &proto.Txn{
+				Raw: &any.Any{
+					Value: tx.MarshalRLP(),
+				},
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+// propagate queues the transaction to be sent or announced to the peers,
+// except the one it was received from [Thread safe]
+func (a *txAnnouncer) propagate(tx *types.Transaction, from peer.ID) {
+	a.recent.Add(tx.Hash, tx)
+
+	select {
+	case a.queue <- propagatedTx{tx: tx, from: from}:
+	default:
+		metrics.IncrCounter([]string{txPoolMetrics, "dropped_announcements"}, 1)
+		a.logger.Debug("announcement queue is full, dropping tx", "hash", tx.Hash.String())
+	}
+}
+
+// receive handles a transaction received from a peer, either sent in full or fetched.
+// Sealing nodes add it to the pool, and every node propagates it further once accepted
+func (a *txAnnouncer) receive(from peer.ID, tx *types.Transaction) {
+	if _, known := a.get(tx.Hash); known {
+		return
+	}
+
+	if err := a.pool.validateGossipTxSignature(tx); err != nil {
+		a.logger.Debug("rejecting announced tx", "hash", tx.Hash.String(), "err", err)
+		a.pool.penalizeGossipPeer(from, common.PenaltyInvalidTx, err.Error())
+
+		return
+	}
+
+	if a.pool.sealing.Load() {
+		if err := a.pool.addTx(gossip, tx); err != nil {
+			if !errors.Is(err, ErrAlreadyKnown) {
+				a.logger.Debug("failed to add announced tx", "hash", tx.Hash.String(), "err", err)
+				a.pool.penalizeGossipTxErr(from, err)
+			}
+
+			return
+		}
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "announced_txs_received"}, 1)
+
+	a.propagate(tx, from)
+}
+
+// fetch requests the announced transactions from the peer, and checks they match their announcement.
+// The fetch must be acquired beforehand, it is released once done
+func (a *txAnnouncer) fetch(peerID peer.ID, announced map[types.Hash]*proto.TxAnnouncement) {
+	defer a.releaseFetch(peerID)
+	defer a.unmarkFetching(announced)
+
+	req := &proto.TxHashes{Hashes: make([][]byte, 0, len(announced))}
+	for hash := range announced {
+		req.Hashes = append(req.Hashes, hash.Bytes())
+	}
+
+	var resp *proto.Txns
+
+	err := a.call(peerID, func(ctx context.Context, client proto.TxAnnouncerClient) (err error) {
+		resp, err = client.GetTxs(ctx, req)
+
+		return err
+	})
+	if err != nil {
+		a.logger.Debug("failed to fetch announced txs", "peer", peerID, "err", err)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "fetched_txs"}, float32(len(resp.Txs)))
+
+	for _, raw := range resp.Txs {
+		tx, err := decodeAnnouncedTx(raw)
+		if err != nil {
+			a.pool.penalizeGossipPeer(peerID, common.PenaltyMalformedMessage, "undecodable transaction")
+
+			continue
+		}
+
+		// the peer must serve the transactions exactly as it announced them
+		ann, ok := announced[tx.Hash]
+		if !ok || uint32(tx.Type) != ann.Type || tx.Size() != ann.Size {
+			a.pool.penalizeGossipPeer(peerID, common.PenaltyInvalidTx, "transaction doesn't match its announcement")
+
+			continue
+		}
+
+		a.receive(peerID, tx)
+	}
+}
+
+// markFetching returns the announced transactions which are neither known nor already
+// being fetched from another peer, and marks them as being fetched
+func (a *txAnnouncer) markFetching(
+	announced map[types.Hash]*proto.TxAnnouncement,
+) map[types.Hash]*proto.TxAnnouncement {
+	a.fetchingLock.Lock()
+	defer a.fetchingLock.Unlock()
+
+	toFetch := make(map[types.Hash]*proto.TxAnnouncement, len(announced))
+
+	for hash, ann := range announced {
+		if _, fetching := a.fetching[hash]; fetching {
+			continue
+		}
+
+		if _, known := a.get(hash); known {
+			continue
+		}
+
+		a.fetching[hash] = struct{}{}
+		toFetch[hash] = ann
+	}
+
+	return toFetch
+}
+
+// unmarkFetching marks the transactions as no longer being fetched,
+// so they are fetched from another peer if the fetch failed
+func (a *txAnnouncer) unmarkFetching(fetched map[types.Hash]*proto.TxAnnouncement) {
+	a.fetchingLock.Lock()
+	defer a.fetchingLock.Unlock()
+
+	for hash := range fetched {
+		delete(a.fetching, hash)
+	}
+}
+
+// acquireFetch reserves a fetch request to the peer, if neither the peer
+// nor all the peers have reached their limit of fetches in flight
+func (a *txAnnouncer) acquireFetch(peerID peer.ID) bool {
+	a.fetchingLock.Lock()
+	defer a.fetchingLock.Unlock()
+
+	if a.peerFetches[peerID] >= maxPeerFetches {
+		return false
+	}
+
+	select {
+	case a.fetchSlots <- struct{}{}:
+	default:
+		return false
+	}
+
+	a.peerFetches[peerID]++
+
+	return true
+}
+
+// releaseFetch releases a fetch request to the peer reserved by acquireFetch
+func (a *txAnnouncer) releaseFetch(peerID peer.ID) {
+	a.fetchingLock.Lock()
+	defer a.fetchingLock.Unlock()
+
+	<-a.fetchSlots
+
+	if a.peerFetches[peerID]--; a.peerFetches[peerID] <= 0 {
+		delete(a.peerFetches, peerID)
+	}
+}
+
+// get returns the transaction of the given hash, if it is in the pool or was recently propagated
+func (a *txAnnouncer) get(hash types.Hash) (*types.Transaction, bool) {
+	if tx, ok := a.pool.index.get(hash); ok {
+		return tx, true
+	}
+
+	if tx, ok := a.recent.Get(hash); ok {
+		return tx.(*types.Transaction), true //nolint:forcetypeassert
+	}
+
+	return nil, false
+}
+
+// run propagates the queued transactions in batches
+func (a *txAnnouncer) run() {
+	ticker := time.NewTicker(announceInterval)
+	defer ticker.Stop()
+
+	batch := make([]propagatedTx, 0, maxAnnounceBatch)
+
+	for {
+		select {
+		case <-a.closeCh:
+			return
+		case ptx := <-a.queue:
+			if batch = append(batch, ptx); len(batch) < maxAnnounceBatch {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		a.broadcast(batch)
+
+		batch = make([]propagatedTx, 0, maxAnnounceBatch)
+	}
+}
+
+// broadcast sends every transaction of the batch in full to a random sqrt(n) subset of the
+// peers supporting the protocol, and announces it to the other ones. The local transactions
+// are published over the gossip topic too if some peers don't support the protocol. The ones
+// received from a peer are not: the topic already relays them once published by their origin,
+// while every re-publishing node would flood the mesh with a copy under a new message ID
+func (a *txAnnouncer) broadcast(batch []propagatedTx) {
+	peers, legacyPeers := a.peers()
+
+	sent := make(map[peer.ID][]*types.Transaction)
+	announced := make(map[peer.ID][]*types.Transaction)
+
+	for _, ptx := range batch {
+		sentTo, announcedTo := splitBroadcastPeers(peers, ptx.from)
+
+		for _, peerID := range sentTo {
+			sent[peerID] = append(sent[peerID], ptx.tx)
+		}
+
+		for _, peerID := range announcedTo {
+			announced[peerID] = append(announced[peerID], ptx.tx)
+		}
+
+		if legacyPeers && ptx.from == "" && a.pool.topic != nil {
+			if err := a.pool.topic.Publish(&proto.Txn{Raw: &any.Any{Value: ptx.tx.MarshalRLP()}}); err != nil {
+				a.logger.Error("failed to topic tx", "err", err)
+			}
+		}
+	}
+
+	for peerID, txs := range sent {
+		go a.sendTxs(peerID, txs)
+	}
+
+	for peerID, txs := range announced {
+		go a.announceTxs(peerID, txs)
+	}
+}
+
+// peers returns the connected peers supporting the protocol,
+// and whether some connected peers don't support it
+func (a *txAnnouncer) peers() ([]peer.ID, bool) {
+	var (
+		peers       []peer.ID
+		legacyPeers bool
+	)
+
+	for _, p := range a.network.Peers() {
+		protocols, err := a.network.GetProtocols(p.Info.ID)
+		if err != nil {
+			continue
+		}
+
+		if supportsProtocol(protocols, txAnnouncerProto) {
+			peers = append(peers, p.Info.ID)
+		} else {
+			legacyPeers = true
+		}
+	}
+
+	return peers, legacyPeers
+}
+
+// splitBroadcastPeers splits the peers, except the one the transaction was received from, into
+// a random sqrt(n) subset the transaction is sent to in full, and the peers it is announced to
+func splitBroadcastPeers(peers []peer.ID, from peer.ID) ([]peer.ID, []peer.ID) {
+	targets := make([]peer.ID, 0, len(peers))
+
+	for _, peerID := range peers {
+		if peerID != from {
+			targets = append(targets, peerID)
+		}
+	}
+
+	rand.Shuffle(len(targets), func(i, j int) {
+		targets[i], targets[j] = targets[j], targets[i]
+	})
+
+	numSent := int(math.Ceil(math.Sqrt(float64(len(targets)))))
+
+	return targets[:numSent], targets[numSent:]
+}
+
+// sendTxs sends the transactions in full to the peer
+func (a *txAnnouncer) sendTxs(peerID peer.ID, txs []*types.Transaction) {
+	req := &proto.Txns{Txs: make([]*proto.Txn, 0, len(txs))}
+	for _, tx := range txs {
+		req.Txs = append(req.Txs, &proto.Txn{Raw: &any.Any{Value: tx.MarshalRLP()}})
+	}
+
+	err := a.call(peerID, func(ctx context.Context, client proto.TxAnnouncerClient) error {
+		_, err := client.SendTxs(ctx, req)
+
+		return err
+	})
+	if err != nil {
+		a.logger.Debug("failed to propagate txs", "peer", peerID, "err", err)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "sent_txs"}, float32(len(txs)))
+}
+
+// announceTxs announces the transactions to the peer
+func (a *txAnnouncer) announceTxs(peerID peer.ID, txs []*types.Transaction) {
+	req := &proto.TxAnnouncements{Txs: make([]*proto.TxAnnouncement, 0, len(txs))}
+	for _, tx := range txs {
+		req.Txs = append(req.Txs, &proto.TxAnnouncement{
+			Hash: tx.Hash.Bytes(),
+			Type: uint32(tx.Type),
+			Size: tx.Size(),
+		})
+	}
+
+	err := a.call(peerID, func(ctx context.Context, client proto.TxAnnouncerClient) error {
+		_, err := client.AnnounceTxs(ctx, req)
+
+		return err
+	})
+	if err != nil {
+		a.logger.Debug("failed to propagate txs", "peer", peerID, "err", err)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "announced_txs"}, float32(len(txs)))
+}
+
+// call opens a stream to the peer, and runs the request over it
+func (a *txAnnouncer) call(peerID peer.ID, request func(context.Context, proto.TxAnnouncerClient) error) error {
+	client, closeFn, err := a.dial(peerID)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = closeFn()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	return request(ctx, client)
+}
+
+// dialPeer opens a stream to the peer over the network
+func (a *txAnnouncer) dialPeer(peerID peer.ID) (proto.TxAnnouncerClient, func() error, error) {
+	conn, err := a.network.NewProtoConnection(txAnnouncerProto, peerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return proto.NewTxAnnouncerClient(conn), conn.Close, nil
+}
+
+// close stops propagating the transactions, and serving the peers
+func (a *txAnnouncer) close() error {
+	close(a.closeCh)
+
+	return a.stream.Close()
+}
+
+// peerFromContext returns the peer which sent the gRPC request
+func peerFromContext(ctx context.Context) (peer.ID, error) {
+	grpcContext, ok := ctx.(*grpc.Context)
+	if !ok {
+		return "", errors.New("invalid type assertion for grpc context")
+	}
+
+	return grpcContext.PeerID, nil
+}
+
+// decodeAnnouncedTx decodes a transaction sent by a peer
+func decodeAnnouncedTx(raw *proto.Txn) (*types.Transaction, error) {
+	if raw == nil || raw.Raw == nil {
+		return nil, errors.New("transaction's field raw is empty")
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
+		return nil, err
+	}
+
+	return tx.ComputeHash(), nil
+}
+
+// isAnnouncedTypeValid checks if the transactions of the announced type can be propagated
+func isAnnouncedTypeValid(typ uint32) bool {
+	return typ == uint32(types.LegacyTx) || typ == uint32(types.DynamicFeeTx)
+}
+
+// supportsProtocol checks if the protocol is in the list of the protocols supported by a peer
+func supportsProtocol(protocols []string, protocol string) bool {
+	for _, p := range protocols {
+		if p == protocol {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,250 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBroadcastPeers(t *testing.T) {
+	t.Parallel()
+
+	peers := []peer.ID{"A", "B", "C", "D", "E", "F", "G", "H", "I"}
+
+	// the transaction is not sent back to the peer it was received from
+	sent, announced := splitBroadcastPeers(peers, "A")
+	assert.Len(t, sent, 3)
+	assert.Len(t, announced, 5)
+	assert.NotContains(t, append(sent, announced...), peer.ID("A"))
+	assert.ElementsMatch(t, peers[1:], append(sent, announced...))
+
+	sent, announced = splitBroadcastPeers(peers[:1], "")
+	assert.Equal(t, peers[:1], sent)
+	assert.Empty(t, announced)
+
+	sent, announced = splitBroadcastPeers(nil, "")
+	assert.Empty(t, sent)
+	assert.Empty(t, announced)
+}
+
+func TestTxAnnouncer_Endpoints(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	announcer, err := newTestTxAnnouncer(pool)
+	require.NoError(t, err)
+
+	pooledTx := newTx(addr1, 0, 1).ComputeHash()
+	recentTx := newTx(addr1, 1, 1).ComputeHash()
+	// the sender is not part of the hash, so the txs of the same nonce must differ
+	privateTx := newTx(addr2, 0, 1)
+	privateTx.Value = big.NewInt(2)
+	privateTx.ComputeHash()
+
+	pool.index.add(pooledTx, false)
+	pool.index.add(privateTx, true)
+	announcer.recent.Add(recentTx.Hash, recentTx)
+
+	ctx := &grpc.Context{Context: context.Background(), PeerID: "A"}
+
+	// the transactions of the pool, and the recently propagated ones are served, but not the private ones
+	resp, err := announcer.GetTxs(ctx, &proto.TxHashes{
+		Hashes: [][]byte{
+			pooledTx.Hash.Bytes(),
+			recentTx.Hash.Bytes(),
+			privateTx.Hash.Bytes(),
+			types.StringToHash("unknown").Bytes(),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Txs, 2)
+
+	for i, expected := range []*types.Transaction{pooledTx, recentTx} {
+		tx, err := decodeAnnouncedTx(resp.Txs[i])
+		require.NoError(t, err)
+		assert.Equal(t, expected.Hash, tx.Hash)
+	}
+
+	// only the unknown transactions are fetched, once
+	unknown := types.StringToHash("unknown")
+	announced := map[types.Hash]*proto.TxAnnouncement{
+		pooledTx.Hash: {Hash: pooledTx.Hash.Bytes()},
+		recentTx.Hash: {Hash: recentTx.Hash.Bytes()},
+		unknown:       {Hash: unknown.Bytes()},
+	}
+
+	toFetch := announcer.markFetching(announced)
+	assert.Len(t, toFetch, 1)
+	assert.Contains(t, toFetch, unknown)
+
+	assert.Empty(t, announcer.markFetching(announced))
+
+	announcer.unmarkFetching(toFetch)
+	assert.Len(t, announcer.markFetching(announced), 1)
+
+	// too many announcements at once are refused
+	_, err = announcer.AnnounceTxs(ctx, &proto.TxAnnouncements{Txs: make([]*proto.TxAnnouncement, maxAnnounceBatch+1)})
+	assert.ErrorIs(t, err, errTooManyAnnouncements)
+}
+
+func TestTxAnnouncer_FetchLimits(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	announcer, err := newTestTxAnnouncer(pool)
+	require.NoError(t, err)
+
+	// the fetches are limited per peer
+	for i := 0; i < maxPeerFetches; i++ {
+		assert.True(t, announcer.acquireFetch("A"))
+	}
+
+	assert.False(t, announcer.acquireFetch("A"))
+
+	// the announcements of the peer are dropped, without marking the transactions as being fetched
+	hash := types.StringToHash("unknown")
+	ctx := &grpc.Context{Context: context.Background(), PeerID: "A"}
+
+	_, err = announcer.AnnounceTxs(ctx, &proto.TxAnnouncements{
+		Txs: []*proto.TxAnnouncement{{Hash: hash.Bytes(), Type: uint32(types.LegacyTx)}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, announcer.fetching)
+
+	announcer.releaseFetch("A")
+	assert.True(t, announcer.acquireFetch("A"))
+
+	// the fetches are limited for all the peers
+	for i := maxPeerFetches; i < maxFetches; i++ {
+		assert.True(t, announcer.acquireFetch(peer.ID(fmt.Sprintf("peer-%d", i))))
+	}
+
+	assert.False(t, announcer.acquireFetch("B"))
+
+	for i := 0; i < maxPeerFetches; i++ {
+		announcer.releaseFetch("A")
+	}
+
+	assert.True(t, announcer.acquireFetch("B"))
+	assert.NotContains(t, announcer.peerFetches, peer.ID("A"))
+}
+
+func TestTxAnnouncer_Propagation(t *testing.T) {
+	t.Parallel()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(100, true)
+
+	peerIDs := []peer.ID{"A", "B"}
+	pools := make([]*TxPool, 2)
+	announcers := make([]*txAnnouncer, 2)
+
+	for i := range pools {
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		pool.SetSigner(signer)
+		pool.SetSealing(true)
+		pool.Start()
+
+		t.Cleanup(pool.Close)
+
+		announcer, err := newTestTxAnnouncer(pool)
+		require.NoError(t, err)
+
+		pools[i], announcers[i] = pool, announcer
+	}
+
+	// the announcers are connected to each other
+	for i, announcer := range announcers {
+		from, to := peerIDs[i], announcers[1-i]
+
+		announcer.dial = func(peer.ID) (proto.TxAnnouncerClient, func() error, error) {
+			return &mockAnnouncerClient{from: from, to: to}, func() error { return nil }, nil
+		}
+	}
+
+	signTx := func(nonce uint64) *types.Transaction {
+		t.Helper()
+
+		tx, err := signer.SignTx(newTx(types.ZeroAddress, nonce, 1), key)
+		require.NoError(t, err)
+
+		return tx.ComputeHash()
+	}
+
+	// the transactions sent in full are added to the pool of the peer, and propagated further
+	sentTx := signTx(0)
+	announcers[0].sendTxs(peerIDs[1], []*types.Transaction{sentTx})
+
+	assert.Eventually(t, func() bool {
+		_, ok := pools[1].index.get(sentTx.Hash)
+
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Len(t, announcers[1].queue, 1)
+
+	queued := <-announcers[1].queue
+	assert.Equal(t, sentTx.Hash, queued.tx.Hash)
+	assert.Equal(t, peerIDs[0], queued.from)
+
+	// the announced transactions are fetched from the announcing peer
+	announcedTx := signTx(1)
+	announcers[0].recent.Add(announcedTx.Hash, announcedTx)
+	announcers[0].announceTxs(peerIDs[1], []*types.Transaction{announcedTx})
+
+	assert.Eventually(t, func() bool {
+		_, ok := pools[1].index.get(announcedTx.Hash)
+
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the fetch is released once done
+	assert.Eventually(t, func() bool {
+		announcers[1].fetchingLock.Lock()
+		defer announcers[1].fetchingLock.Unlock()
+
+		return len(announcers[1].peerFetches) == 0 && len(announcers[1].fetching) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// newTestTxAnnouncer creates an announcer of the pool which is not connected to the network
+func newTestTxAnnouncer(pool *TxPool) (*txAnnouncer, error) {
+	recent, err := lru.New(recentTxsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txAnnouncer{
+		logger: hclog.NewNullLogger(),
+		pool:   pool,
+		dial: func(peer.ID) (proto.TxAnnouncerClient, func() error, error) {
+			return nil, nil, errors.New("not connected to the network")
+		},
+		queue:       make(chan propagatedTx, announceQueueSize),
+		recent:      recent,
+		fetching:    make(map[types.Hash]struct{}),
+		peerFetches: make(map[peer.ID]int),
+		fetchSlots:  make(chan struct{}, maxFetches),
+		closeCh:     make(chan struct{}),
+	}, nil
+}
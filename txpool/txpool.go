@@ -10,12 +10,12 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
-	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
@@ -35,6 +35,10 @@ const (
 
 	pruningCooldown = 5000 * time.Millisecond
 
+	// interval at which the pool looks for accounts
+	// that have been inactive for longer than the configured tx lifetime
+	staleAccountsCheckInterval = time.Minute
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -59,14 +63,30 @@ var (
 	ErrTipAboveFeeCap          = errors.New("max priority fee per gas higher than max fee per gas")
 	ErrTipVeryHigh             = errors.New("max priority fee per gas higher than 2^256-1")
 	ErrFeeCapVeryHigh          = errors.New("max fee per gas higher than 2^256-1")
+	ErrTxNotFound              = errors.New("transaction not found in the pool")
+	ErrInvalidChainID          = errors.New("invalid chain id")
+
+	// penalizedGossipErrors are the errors of the transactions which can never become valid,
+	// so the peers gossiping them are penalized
+	penalizedGossipErrors = []error{
+		ErrNegativeValue,
+		ErrExtractSignature,
+		ErrInvalidSender,
+		ErrOversizedData,
+		ErrTipAboveFeeCap,
+		ErrTipVeryHigh,
+		ErrFeeCapVeryHigh,
+	}
 )
 
 // indicates origin of a transaction
 type txOrigin int
 
 const (
-	local  txOrigin = iota // json-RPC/gRPC endpoints
-	gossip                 // gossip protocol
+	local   txOrigin = iota // json-RPC/gRPC endpoints
+	gossip                  // gossip protocol
+	private                 // private json-RPC/gRPC endpoints
+	relayed                 // private transactions relayed by trusted peers
 )
 
 func (o txOrigin) String() (s string) {
@@ -75,11 +95,20 @@ func (o txOrigin) String() (s string) {
 		s = "local"
 	case gossip:
 		s = "gossip"
+	case private:
+		s = "private"
+	case relayed:
+		s = "relayed"
 	}
 
 	return
 }
 
+// isPrivate returns true if the transactions of this origin are not gossiped
+func (o txOrigin) isPrivate() bool {
+	return o == private || o == relayed
+}
+
 // store interface defines State helper methods the TxPool should have access to
 type store interface {
 	Header() *types.Header
@@ -96,6 +125,29 @@ type Config struct {
 	PriceLimit         uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
+	// TxLifetime is the maximum amount of time an inactive account's
+	// transactions are kept in the pool (0 disables the check)
+	TxLifetime time.Duration
+	// OrderingPolicy is the policy used for ordering executable transactions
+	// during block building (price ordering if not set)
+	OrderingPolicy OrderingPolicyType
+	// MaxTxsPerSender is the maximum number of transactions
+	// of a single sender per block (sender-cap ordering only)
+	MaxTxsPerSender uint64
+	// PriorityAddresses are the addresses whose transactions go first
+	// during block building (priority-lanes ordering only)
+	PriorityAddresses []types.Address
+	// PrivateTxExpiry is the number of blocks after which a private
+	// transaction that was not included is dropped (0 disables the expiry)
+	PrivateTxExpiry uint64
+	// PrivateTxPeers are the trusted peers private transactions are handed over to
+	PrivateTxPeers []peer.ID
+	// Validators are the admission hooks invoked, in order,
+	// for every incoming transaction
+	Validators []TxValidator
+	// ChainID is the chain ID the gossiped replay protected transactions
+	// have to be signed for (0 disables the check)
+	ChainID uint64
 }
 
 /* All requests are passed to the main loop
@@ -141,18 +193,38 @@ type TxPool struct {
 	forks  chain.ForksInTime
 	store  store
 
+	// chainID is the chain ID the gossiped transactions have to be signed for
+	chainID uint64
+
 	// map of all accounts registered by the pool
 	accounts accountsMap
 
-	// all the primaries sorted by max gas price
-	executables *pricedQueue
+	// all the primaries sorted by the configured ordering policy
+	executables TxOrderingPolicy
+
+	// newOrderingPolicy creates a new instance of the configured ordering policy
+	newOrderingPolicy func() TxOrderingPolicy
 
 	// lookup map keeping track of all
 	// transactions present in the pool
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic     *network.Topic
+	announcer *txAnnouncer
+
+	// reportPeer penalizes the peers gossiping invalid transactions, nil without networking
+	reportPeer func(peerID peer.ID, penalty common.Penalty, reason string)
+
+	// private (not gossiped) transactions and their expiry
+	privateTxs      *privateTxs
+	privateTxExpiry uint64
+
+	// relay of private transactions to trusted peers
+	privateRelay *privateTxRelay
+
+	// custom admission hooks
+	validators []TxValidator
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
@@ -160,6 +232,10 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// txLifetime is the maximum amount of time
+	// an inactive account's transactions are kept in the pool
+	txLifetime time.Duration
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -198,14 +274,24 @@ func NewTxPool(
 	config *Config,
 ) (*TxPool, error) {
 	pool := &TxPool{
-		logger:      logger.Named("txpool"),
-		forks:       forks,
-		store:       store,
-		executables: newPricedQueue(),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
+		logger:   logger.Named("txpool"),
+		forks:    forks,
+		store:    store,
+		chainID:  config.ChainID,
+		accounts: accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
+		index: lookupMap{
+			all:      make(map[types.Hash]*types.Transaction),
+			arrivals: make(map[types.Hash]uint64),
+			private:  make(map[types.Hash]struct{}),
+		},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit: config.PriceLimit,
+		txLifetime: config.TxLifetime,
+
+		privateTxs:      newPrivateTxs(),
+		privateTxExpiry: config.PrivateTxExpiry,
+
+		validators: config.Validators,
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
@@ -214,12 +300,27 @@ func NewTxPool(
 		shutdownCh:   make(chan struct{}),
 	}
 
+	executables, err := newOrderingPolicy(config, pool.index.arrival)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.executables = executables
+	pool.newOrderingPolicy = func() TxOrderingPolicy {
+		// the config is already checked by the creation of the executables
+		policy, _ := newOrderingPolicy(config, pool.index.arrival)
+
+		return policy
+	}
+
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
+	pool.eventManager.isPrivate = pool.index.isPrivate
 
 	if network != nil {
-		// subscribe to the gossip protocol
-		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
+		// subscribe to the gossip protocol, still used by the peers
+		// which don't support the transaction announcements
+		topic, err := network.NewTopic(topicNameV1, &proto.Txn{}, pool.validateGossipTx)
 		if err != nil {
 			return nil, err
 		}
@@ -229,6 +330,16 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+		pool.reportPeer = network.ReportPeer
+
+		// propagate the transactions with announcements
+		if pool.announcer, err = newTxAnnouncer(pool.logger, network, pool); err != nil {
+			return nil, err
+		}
+
+		if len(config.PrivateTxPeers) > 0 {
+			pool.privateRelay = newPrivateTxRelay(pool.logger, network, pool, config.PrivateTxPeers)
+		}
 	}
 
 	if grpcServer != nil {
@@ -266,6 +377,23 @@ func (p *TxPool) Start() {
 		}
 	}()
 
+	//	run the handler for stale accounts pruning
+	if p.txLifetime > 0 {
+		go func() {
+			ticker := time.NewTicker(staleAccountsCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-p.shutdownCh:
+					return
+				case <-ticker.C:
+					p.pruneStaleAccounts()
+				}
+			}
+		}()
+	}
+
 	//	run the handler for the tx pipeline
 	go func() {
 		for {
@@ -284,7 +412,20 @@ func (p *TxPool) Start() {
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
-	p.shutdownCh <- struct{}{}
+
+	if p.privateRelay != nil {
+		if err := p.privateRelay.close(); err != nil {
+			p.logger.Error("failed to close private tx relay", "err", err)
+		}
+	}
+
+	if p.announcer != nil {
+		if err := p.announcer.close(); err != nil {
+			p.logger.Error("failed to close tx announcer", "err", err)
+		}
+	}
+
+	close(p.shutdownCh)
 }
 
 // SetSigner sets the signer the pool will use
@@ -301,24 +442,21 @@ func (p *TxPool) SetSealing(sealing bool) {
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
-	if err := p.addTx(local, tx); err != nil {
+	return p.AddTxFromClient(tx, "")
+}
+
+// AddTxFromClient is AddTx for the transactions whose submitting
+// RPC client is known, so the admission hooks can take it into account.
+func (p *TxPool) AddTxFromClient(tx *types.Transaction, clientAddr string) error {
+	if err := p.addTxFromClient(local, tx, clientAddr); err != nil {
 		p.logger.Error("failed to add tx", "err", err)
 
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
-
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
-		}
+	// broadcast the transaction only if the networking is enabled
+	if p.announcer != nil {
+		p.announcer.propagate(tx, "")
 	}
 
 	return nil
@@ -327,33 +465,31 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 // Prepare generates all the transactions
 // ready for execution. (primaries)
 func (p *TxPool) Prepare(baseFee uint64) {
-	// clear from previous round
-	if p.executables.length() != 0 {
-		p.executables.clear()
-	}
-
 	// set base fee
 	p.updateBaseFee(baseFee)
 
+	// clear from previous round
+	p.executables.Reset(baseFee)
+
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
 	// push primaries to the executables queue
 	for _, tx := range primaries {
-		p.executables.push(tx)
+		p.executables.Push(tx)
 	}
 }
 
-// Peek returns the best-price selected
-// transaction ready for execution.
+// Peek returns the next transaction ready for execution,
+// as selected by the configured ordering policy.
 func (p *TxPool) Peek() *types.Transaction {
 	// Popping the executables queue
 	// does not remove the actual tx
 	// from the pool.
 	// The executables queue just provides
-	// insight into which account has the
-	// highest priced tx (head of promoted queue)
-	return p.executables.pop()
+	// insight into which account's tx
+	// (head of promoted queue) goes next
+	return p.executables.Pop()
 }
 
 // Pop removes the given transaction from the
@@ -367,11 +503,18 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	account.promoted.lock(true)
 	defer account.promoted.unlock()
 
+	// the tx may have been pruned since it was taken from the executables,
+	// in which case its slots and pending count are already released
+	if first := account.promoted.peek(); first == nil || first.Hash != tx.Hash {
+		return
+	}
+
 	// pop the top most promoted tx
 	account.promoted.pop()
 
 	// successfully popping an account resets its demotions count to 0
 	account.resetDemotions()
+	account.updateLastActivity()
 
 	// update state
 	p.gauge.decrease(slotsRequired(tx))
@@ -380,8 +523,10 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	p.updatePending(-1)
 
 	// update executables
+	p.executables.Included(tx)
+
 	if tx := account.promoted.peek(); tx != nil {
-		p.executables.push(tx)
+		p.executables.Push(tx)
 	}
 }
 
@@ -411,6 +556,9 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 		account.promoted.unlock()
 	}()
 
+	// signaled while the tx is still in the pool, for a private one to be left out
+	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+
 	// rollback nonce
 	nextNonce := tx.Nonce
 	account.setNonce(nextNonce)
@@ -426,7 +574,6 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	dropped = account.enqueued.clear()
 	clearAccountQueue(dropped)
 
-	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
 	p.logger.Debug("dropped account txs",
 		"num", droppedCount,
 		"next_nonce", nextNonce,
@@ -519,6 +666,9 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
+	// drop the private txs which were not included in time
+	p.pruneExpiredPrivateTxs(p.store.Header().Number)
+
 	if !p.sealing.Load() {
 		// only non-validator cleanup inactive accounts
 		p.updateAccountSkipsCounts(stateNonces)
@@ -527,7 +677,7 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 
 // validateTx ensures the transaction conforms to specific
 // constraints before entering the pool.
-func (p *TxPool) validateTx(tx *types.Transaction) error {
+func (p *TxPool) validateTx(tx *types.Transaction, origin txOrigin, clientAddr string) error {
 	// Check the transaction type. State transactions are not expected to be added to the pool
 	if tx.Type == types.StateTx {
 		metrics.IncrCounter([]string{txPoolMetrics, "invalid_tx_type"}, 1)
@@ -675,6 +825,26 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrBlockLimitExceeded
 	}
 
+	if len(p.validators) == 0 {
+		return nil
+	}
+
+	// Run the custom admission hooks
+	validationCtx := &TxValidationContext{
+		Origin:     origin.String(),
+		ClientAddr: clientAddr,
+		Header:     p.store.Header(),
+		State:      &headState{store: p.store, root: stateRoot},
+	}
+
+	for _, validator := range p.validators {
+		if err := validator.ValidateTx(tx, validationCtx); err != nil {
+			metrics.IncrCounter([]string{txPoolMetrics, "rejected_by_validator_tx"}, 1)
+
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -713,18 +883,90 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 	)
 }
 
+// pruneStaleAccounts removes all transactions of the accounts
+// that have been inactive for longer than the configured tx lifetime.
+func (p *TxPool) pruneStaleAccounts() {
+	var (
+		prunedPromoted int
+		prunedEnqueued int
+		prunedAccounts int
+	)
+
+	p.accounts.Range(
+		func(_, value interface{}) bool {
+			account, _ := value.(*account)
+
+			promoted, enqueued := account.clearStale(p.txLifetime)
+			if len(promoted) == 0 && len(enqueued) == 0 {
+				return true
+			}
+
+			// the events are signaled before the private txs are removed from the index
+			if len(promoted) > 0 {
+				p.eventManager.signalEvent(proto.EventType_PRUNED_PROMOTED, toHash(promoted...)...)
+			}
+
+			if len(enqueued) > 0 {
+				p.eventManager.signalEvent(proto.EventType_PRUNED_ENQUEUED, toHash(enqueued...)...)
+			}
+
+			p.index.remove(promoted...)
+			p.index.remove(enqueued...)
+			p.gauge.decrease(slotsRequired(promoted...) + slotsRequired(enqueued...))
+			p.updatePending(int64(-1 * len(promoted)))
+
+			prunedPromoted += len(promoted)
+			prunedEnqueued += len(enqueued)
+			prunedAccounts++
+
+			return true
+		},
+	)
+
+	if prunedAccounts == 0 {
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "stale_accounts_pruned"}, float32(prunedAccounts))
+	metrics.IncrCounter(
+		[]string{txPoolMetrics, "stale_txs_pruned"},
+		float32(prunedPromoted+prunedEnqueued),
+	)
+
+	p.logger.Debug("pruned stale accounts",
+		"accounts", prunedAccounts,
+		"promoted", prunedPromoted,
+		"enqueued", prunedEnqueued,
+	)
+}
+
 // addTx is the main entry point to the pool
 // for all new transactions. If the call is
 // successful, an account is created for this address
 // (only once) and an enqueueRequest is signaled.
 func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
+	return p.addTxFromClient(origin, tx, "")
+}
+
+// addTxFromClient is addTx for the transactions submitted by a known RPC client
+func (p *TxPool) addTxFromClient(origin txOrigin, tx *types.Transaction, clientAddr string) error {
 	p.logger.Debug("add tx",
 		"origin", origin.String(),
 		"hash", tx.Hash.String(),
 	)
 
+	tx.ComputeHash()
+
+	// reject the known txs before running the admission hooks,
+	// so resubmitting a tx does not use up the quota of its sender
+	if _, known := p.index.get(tx.Hash); known {
+		metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
+
+		return ErrAlreadyKnown
+	}
+
 	// validate incoming tx
-	if err := p.validateTx(tx); err != nil {
+	if err := p.validateTx(tx, origin, clientAddr); err != nil {
 		return err
 	}
 
@@ -745,10 +987,8 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		return ErrTxPoolOverflow
 	}
 
-	tx.ComputeHash()
-
 	// add to index
-	if ok := p.index.add(tx); !ok {
+	if ok := p.index.add(tx, origin.isPrivate()); !ok {
 		metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
 
 		return ErrAlreadyKnown
@@ -823,7 +1063,7 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
+func (p *TxPool) addGossipTx(obj interface{}, from peer.ID) {
 	if !p.sealing.Load() {
 		return
 	}
@@ -838,6 +1078,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// Verify that the gossiped transaction message is not empty
 	if raw == nil || raw.Raw == nil {
 		p.logger.Error("malformed gossip transaction message received")
+		p.penalizeGossipPeer(from, common.PenaltyMalformedMessage, "malformed gossip transaction")
 
 		return
 	}
@@ -847,6 +1088,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.penalizeGossipPeer(from, common.PenaltyMalformedMessage, "undecodable gossip transaction")
 
 		return
 	}
@@ -860,6 +1102,71 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+		p.penalizeGossipTxErr(from, err)
+	}
+}
+
+// penalizeGossipTxErr penalizes the peer which gossiped a transaction
+// rejected by the pool, if the transaction can never become valid
+func (p *TxPool) penalizeGossipTxErr(from peer.ID, err error) {
+	for _, penalizedErr := range penalizedGossipErrors {
+		if errors.Is(err, penalizedErr) {
+			p.penalizeGossipPeer(from, common.PenaltyInvalidTx, err.Error())
+
+			return
+		}
+	}
+}
+
+// validateGossipTx is the validator of the gossip topic, which drops the transactions
+// that can never be valid before they are relayed to the other peers
+func (p *TxPool) validateGossipTx(obj interface{}, _ peer.ID) network.ValidationResult {
+	raw, ok := obj.(*proto.Txn)
+	if !ok || raw.Raw == nil || len(raw.Raw.Value) > txMaxSize {
+		return network.ValidationReject
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
+		return network.ValidationReject
+	}
+
+	tx.ComputeHash()
+
+	if err := p.validateGossipTxSignature(tx); err != nil {
+		p.logger.Debug("rejecting gossiped tx", "hash", tx.Hash.String(), "err", err)
+
+		return network.ValidationReject
+	}
+
+	return network.ValidationAccept
+}
+
+// validateGossipTxSignature checks that the gossiped transaction is properly signed for this chain
+func (p *TxPool) validateGossipTxSignature(tx *types.Transaction) error {
+	// the signature can't be checked until the signer is set
+	if p.signer == nil {
+		return nil
+	}
+
+	if p.chainID != 0 && tx.Type == types.LegacyTx && tx.V != nil {
+		// replay protected transactions have v = CHAIN_ID * 2 + 35 + {0, 1}
+		if v := tx.V.Uint64(); tx.V.IsUint64() && v != 27 && v != 28 && (v < 35 || (v-35)/2 != p.chainID) {
+			return ErrInvalidChainID
+		}
+	}
+
+	if _, err := p.signer.Sender(tx); err != nil {
+		return ErrExtractSignature
+	}
+
+	return nil
+}
+
+// penalizeGossipPeer penalizes the peer which gossiped an invalid transaction
+func (p *TxPool) penalizeGossipPeer(peerID peer.ID, penalty common.Penalty, reason string) {
+	if p.reportPeer != nil {
+		p.reportPeer(peerID, penalty, reason)
 	}
 }
 
@@ -900,24 +1207,25 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	}
 
 	// prune pool state
+	// the events are signaled before the private txs are removed from the index
 	if len(allPrunedPromoted) > 0 {
-		cleanup(allPrunedPromoted)
-
 		p.eventManager.signalEvent(
 			proto.EventType_PRUNED_PROMOTED,
 			toHash(allPrunedPromoted...)...,
 		)
 
+		cleanup(allPrunedPromoted)
+
 		p.updatePending(int64(-1 * len(allPrunedPromoted)))
 	}
 
 	if len(allPrunedEnqueued) > 0 {
-		cleanup(allPrunedEnqueued)
-
 		p.eventManager.signalEvent(
 			proto.EventType_PRUNED_ENQUEUED,
 			toHash(allPrunedEnqueued...)...,
 		)
+
+		cleanup(allPrunedEnqueued)
 	}
 }
 
@@ -978,10 +1286,9 @@ func (p *TxPool) Length() uint64 {
 	return p.accounts.promoted()
 }
 
-// updateBaseFee updates base fee in the tx pool and priced queue
+// updateBaseFee updates base fee in the tx pool
 func (p *TxPool) updateBaseFee(baseFee uint64) {
 	atomic.StoreUint64(&p.baseFee, baseFee)
-	atomic.StoreUint64(&p.executables.queue.baseFee, baseFee)
 }
 
 // toHash returns the hash(es) of given transaction(s)
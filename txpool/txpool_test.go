@@ -7,18 +7,22 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -457,6 +461,255 @@ func TestPruneAccountsWithNonceHoles(t *testing.T) {
 	)
 }
 
+func TestPruneStaleAccounts(t *testing.T) {
+	t.Parallel()
+
+	const lifetime = time.Hour
+
+	t.Run(
+		"skip active account",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+			pool.txLifetime = lifetime
+
+			//	enqueue tx
+			go func() {
+				assert.NoError(t,
+					pool.addTx(local, newTx(addr1, 5, 1)),
+				)
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			pool.pruneStaleAccounts()
+
+			assert.Equal(t, uint64(1), pool.gauge.read())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		},
+	)
+
+	t.Run(
+		"prune inactive account",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+			pool.txLifetime = lifetime
+
+			subscription := pool.eventManager.subscribe(
+				[]proto.EventType{
+					proto.EventType_PRUNED_PROMOTED,
+					proto.EventType_PRUNED_ENQUEUED,
+				},
+			)
+			defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+			//	promote tx with nonce 0 and enqueue tx with nonce 2
+			go func() {
+				assert.NoError(t,
+					pool.addTx(local, newTx(addr1, 0, 1)),
+				)
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			go func() {
+				assert.NoError(t,
+					pool.addTx(local, newTx(addr1, 2, 1)),
+				)
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			acc := pool.accounts.get(addr1)
+
+			assert.Equal(t, uint64(2), pool.gauge.read())
+			assert.Equal(t, uint64(1), acc.promoted.length())
+			assert.Equal(t, uint64(1), acc.enqueued.length())
+			assert.Equal(t, uint64(1), acc.getNonce())
+			assert.Equal(t, int64(1), pool.pending)
+
+			//	mark account as inactive
+			acc.lastActivity = time.Now().Add(-2 * lifetime).UnixNano()
+
+			pool.pruneStaleAccounts()
+
+			assert.Equal(t, uint64(0), pool.gauge.read())
+			assert.Equal(t, uint64(0), acc.promoted.length())
+			assert.Equal(t, uint64(0), acc.enqueued.length())
+			assert.Equal(t, uint64(0), acc.getNonce())
+			assert.Equal(t, int64(0), pool.pending)
+			assert.Len(t, pool.index.all, 0)
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), time.Second)
+			defer cancelFn()
+
+			events := waitForEvents(ctx, subscription, 2)
+			require.Len(t, events, 2)
+
+			eventTypes := []proto.EventType{events[0].Type, events[1].Type}
+			assert.ElementsMatch(t,
+				[]proto.EventType{proto.EventType_PRUNED_PROMOTED, proto.EventType_PRUNED_ENQUEUED},
+				eventTypes,
+			)
+		},
+	)
+
+	t.Run(
+		"pop tx pruned after it was taken from the executables",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+			pool.txLifetime = lifetime
+
+			go func() {
+				assert.NoError(t,
+					pool.addTx(local, newTx(addr1, 0, 1)),
+				)
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			pool.Prepare(0)
+			tx := pool.Peek()
+			require.NotNil(t, tx)
+
+			acc := pool.accounts.get(addr1)
+			acc.lastActivity = time.Now().Add(-2 * lifetime).UnixNano()
+
+			pool.pruneStaleAccounts()
+
+			assert.Equal(t, uint64(0), pool.gauge.read())
+			assert.Equal(t, int64(0), pool.pending)
+
+			// the slots and the pending count are not released twice
+			pool.Pop(tx)
+
+			assert.Equal(t, uint64(0), pool.gauge.read())
+			assert.Equal(t, int64(0), pool.pending)
+		},
+	)
+}
+
+func TestPruneExpiredPrivateTxs(t *testing.T) {
+	t.Parallel()
+
+	const expiry = 2
+
+	t.Run(
+		"skip included tx",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+			pool.privateTxExpiry = expiry
+
+			tx := newTx(addr1, 0, 1)
+
+			go func() {
+				assert.NoError(t,
+					pool.addPrivateTx(private, tx, ""),
+				)
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			//	tx is included in a block
+			pool.index.remove(tx)
+
+			pool.pruneExpiredPrivateTxs(mockHeader.Number + expiry)
+
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+			assert.Len(t, pool.privateTxs.expiries, 0)
+		},
+	)
+
+	t.Run(
+		"drop expired tx and its successors",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+			pool.privateTxExpiry = expiry
+
+			subscription := pool.eventManager.subscribe(
+				[]proto.EventType{
+					proto.EventType_DROPPED,
+				},
+			)
+			defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+			//	promote local tx with nonce 0 and private tx with nonce 1
+			go func() {
+				assert.NoError(t,
+					pool.addTx(local, newTx(addr1, 0, 1)),
+				)
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			privateTx := newTx(addr1, 1, 1)
+
+			go func() {
+				assert.NoError(t,
+					pool.addPrivateTx(private, privateTx, ""),
+				)
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			//	enqueue local tx with nonce 3
+			enqueuedTx := newTx(addr1, 3, 1)
+
+			go func() {
+				assert.NoError(t,
+					pool.addTx(local, enqueuedTx),
+				)
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			acc := pool.accounts.get(addr1)
+
+			assert.Equal(t, uint64(3), pool.gauge.read())
+			assert.Equal(t, uint64(2), acc.promoted.length())
+			assert.Equal(t, uint64(1), acc.enqueued.length())
+			assert.Equal(t, uint64(2), acc.getNonce())
+
+			//	not expired yet
+			pool.pruneExpiredPrivateTxs(mockHeader.Number + expiry - 1)
+
+			assert.Equal(t, uint64(3), pool.gauge.read())
+
+			pool.pruneExpiredPrivateTxs(mockHeader.Number + expiry)
+
+			assert.Equal(t, uint64(1), pool.gauge.read())
+			assert.Equal(t, uint64(1), acc.promoted.length())
+			assert.Equal(t, uint64(0), acc.enqueued.length())
+			assert.Equal(t, uint64(1), acc.getNonce())
+			assert.Len(t, pool.index.all, 1)
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), time.Second)
+			defer cancelFn()
+
+			//	the expired private tx is not exposed to the subscribers
+			events := waitForEvents(ctx, subscription, 2)
+			require.Len(t, events, 1)
+			assert.Equal(t, enqueuedTx.Hash.String(), events[0].TxHash)
+		},
+	)
+}
+
 func TestAddTxHighPressure(t *testing.T) {
 	t.Parallel()
 
@@ -603,6 +856,113 @@ func TestAddGossipTx(t *testing.T) {
 
 		assert.Equal(t, uint64(0), pool.accounts.get(sender).enqueued.length())
 	})
+
+	t.Run("invalid transactions penalize the peer", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(signer)
+
+		pool.SetSealing(true)
+
+		penalties := map[peer.ID]common.Penalty{}
+		pool.reportPeer = func(peerID peer.ID, penalty common.Penalty, _ string) {
+			penalties[peerID] += penalty
+		}
+
+		// unsigned transaction
+		pool.addGossipTx(&proto.Txn{
+			Raw: &any.Any{
+				Value: tx.MarshalRLP(),
+			},
+		}, "A")
+
+		// undecodable transaction
+		pool.addGossipTx(&proto.Txn{
+			Raw: &any.Any{
+				Value: []byte{0x1, 0x2},
+			},
+		}, "B")
+
+		assert.Equal(t, map[peer.ID]common.Penalty{
+			"A": common.PenaltyInvalidTx,
+			"B": common.PenaltyMalformedMessage,
+		}, penalties)
+	})
+}
+
+func TestValidateGossipTx(t *testing.T) {
+	t.Parallel()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(100, true)
+
+	signTx := func(signer crypto.TxSigner) []byte {
+		t.Helper()
+
+		signedTx, err := signer.SignTx(newTx(types.ZeroAddress, 1, 1), key)
+		require.NoError(t, err)
+
+		return signedTx.MarshalRLP()
+	}
+
+	testCases := []struct {
+		name     string
+		obj      interface{}
+		expected network.ValidationResult
+	}{
+		{
+			name:     "valid transaction",
+			obj:      &proto.Txn{Raw: &any.Any{Value: signTx(signer)}},
+			expected: network.ValidationAccept,
+		},
+		{
+			name:     "unexpected message",
+			obj:      &proto.SubscribeRequest{},
+			expected: network.ValidationReject,
+		},
+		{
+			name:     "empty transaction",
+			obj:      &proto.Txn{},
+			expected: network.ValidationReject,
+		},
+		{
+			name:     "oversized transaction",
+			obj:      &proto.Txn{Raw: &any.Any{Value: make([]byte, txMaxSize+1)}},
+			expected: network.ValidationReject,
+		},
+		{
+			name:     "undecodable transaction",
+			obj:      &proto.Txn{Raw: &any.Any{Value: []byte{0x1, 0x2}}},
+			expected: network.ValidationReject,
+		},
+		{
+			name:     "unsigned transaction",
+			obj:      &proto.Txn{Raw: &any.Any{Value: newTx(types.ZeroAddress, 1, 1).MarshalRLP()}},
+			expected: network.ValidationReject,
+		},
+		{
+			name:     "transaction of another chain",
+			obj:      &proto.Txn{Raw: &any.Any{Value: signTx(crypto.NewEIP155Signer(101, true))}},
+			expected: network.ValidationReject,
+		},
+	}
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(signer)
+
+	pool.chainID = 100
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, pool.validateGossipTx(tc.obj, "A"))
+		})
+	}
 }
 
 func TestDropKnownGossipTx(t *testing.T) {
@@ -1725,7 +2085,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.Input = input
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(signTx(tx), local, ""),
 			runtime.ErrMaxCodeSizeExceeded,
 		)
 	})
@@ -1744,7 +2104,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.Input = input
 
 		assert.NoError(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(signTx(tx), local, ""),
 			runtime.ErrMaxCodeSizeExceeded,
 		)
 	})
@@ -1760,7 +2120,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasFeeCap = big.NewInt(1100)
 		tx.GasTipCap = big.NewInt(10)
 
-		assert.NoError(t, pool.validateTx(signTx(tx)))
+		assert.NoError(t, pool.validateTx(signTx(tx), local, ""))
 	})
 
 	t.Run("eip-1559 tx (gas fee cap less than base fee)", func(t *testing.T) {
@@ -1775,7 +2135,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = big.NewInt(10)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(signTx(tx), local, ""),
 			ErrUnderpriced,
 		)
 	})
@@ -1792,7 +2152,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = big.NewInt(100000)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(signTx(tx), local, ""),
 			ErrTipAboveFeeCap,
 		)
 	})
@@ -1812,7 +2172,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		signedTx.GasTipCap = nil
 
 		assert.ErrorIs(t,
-			pool.validateTx(signedTx),
+			pool.validateTx(signedTx, local, ""),
 			ErrUnderpriced,
 		)
 
@@ -1824,7 +2184,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		signedTx.GasFeeCap = nil
 
 		assert.ErrorIs(t,
-			pool.validateTx(signedTx),
+			pool.validateTx(signedTx, local, ""),
 			ErrUnderpriced,
 		)
 	})
@@ -1843,7 +2203,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasFeeCap = new(big.Int).SetBit(new(big.Int), bitLength, 1)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(signTx(tx), local, ""),
 			ErrFeeCapVeryHigh,
 		)
 
@@ -1853,7 +2213,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = new(big.Int).SetBit(new(big.Int), bitLength, 1)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(signTx(tx), local, ""),
 			ErrTipVeryHigh,
 		)
 	})
@@ -1869,7 +2229,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = big.NewInt(100000)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(signTx(tx), local, ""),
 			ErrInvalidTxType,
 		)
 	})
@@ -2830,6 +3190,293 @@ func TestGetTxs(t *testing.T) {
 	}
 }
 
+func TestGetTxsFromAndLookupTx(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	//	promote txs with nonce 0 and 1
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx := newTx(addr1, nonce, 1)
+
+		go func() {
+			assert.NoError(t,
+				pool.addTx(local, tx),
+			)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	//	enqueue txs with nonce 5 and 3
+	for _, nonce := range []uint64{5, 3} {
+		tx := newTx(addr1, nonce, 1)
+
+		go func() {
+			assert.NoError(t,
+				pool.addTx(local, tx),
+			)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	promoted, enqueued := pool.GetTxsFrom(addr1)
+	require.Len(t, promoted, 2)
+	require.Len(t, enqueued, 2)
+
+	assert.Equal(t, uint64(0), promoted[0].Nonce)
+	assert.Equal(t, uint64(1), promoted[1].Nonce)
+	assert.Equal(t, uint64(3), enqueued[0].Nonce)
+	assert.Equal(t, uint64(5), enqueued[1].Nonce)
+
+	lookup, ok := pool.LookupTx(promoted[1].Hash)
+	require.True(t, ok)
+	assert.Equal(t, TxStatusPending, lookup.Status)
+	assert.Equal(t, uint64(1), lookup.Position)
+
+	lookup, ok = pool.LookupTx(enqueued[1].Hash)
+	require.True(t, ok)
+	assert.Equal(t, TxStatusQueued, lookup.Status)
+	assert.Equal(t, uint64(1), lookup.Position)
+
+	_, ok = pool.LookupTx(types.Hash{0x1})
+	assert.False(t, ok)
+
+	promoted, enqueued = pool.GetTxsFrom(addr2)
+	assert.Empty(t, promoted)
+	assert.Empty(t, enqueued)
+}
+
+func TestGetPendingTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// addr1 has two cheap txs, addr2 has one expensive tx,
+	// addr3 has an expensive private tx, followed by a regular one
+	txs := []*types.Transaction{
+		newTx(addr1, 0, 1),
+		newTx(addr1, 1, 1),
+		newTx(addr2, 0, 1),
+		newTx(addr3, 0, 1),
+		newTx(addr3, 1, 1),
+	}
+	txs[2].GasPrice = big.NewInt(0).SetUint64(defaultPriceLimit * 10)
+	txs[3].GasPrice = big.NewInt(0).SetUint64(defaultPriceLimit * 20)
+
+	for _, tx := range txs {
+		tx := tx
+
+		go func() {
+			if tx.From == addr3 && tx.Nonce == 0 {
+				assert.NoError(t, pool.addPrivateTx(private, tx, ""))
+			} else {
+				assert.NoError(t, pool.addTx(local, tx))
+			}
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	// the private tx is left out, along with the next one of the same account
+	pending := pool.GetPendingTxs(0)
+	require.Len(t, pending, 3)
+
+	for _, tx := range pending {
+		assert.NotEqual(t, addr3, tx.From)
+	}
+
+	// the executables are left untouched
+	assert.Equal(t, uint64(5), pool.Length())
+
+	// same order as the block builders, which execute the private txs too
+	pool.Prepare(0)
+
+	executed := make([]types.Hash, 0, len(pending))
+
+	for tx := pool.Peek(); tx != nil; tx = pool.Peek() {
+		if tx.From != addr3 {
+			executed = append(executed, tx.Hash)
+		}
+
+		pool.Pop(tx)
+	}
+
+	require.Len(t, executed, len(pending))
+
+	for i, tx := range pending {
+		assert.Equal(t, tx.Hash, executed[i])
+	}
+}
+
+func TestPrivateTxsAreHidden(t *testing.T) {
+	t.Parallel()
+
+	// addr1 has a regular tx followed by a private one and an enqueued private one,
+	// addr2 only has a private tx
+	newPoolWithPrivateTxs := func(t *testing.T) (*TxPool, []*types.Transaction) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		txs := []*types.Transaction{
+			newTx(addr1, 0, 1),
+			newTx(addr1, 1, 1),
+			newTx(addr2, 0, 1),
+		}
+
+		// the sender is not part of the hash, so the txs of the same nonce must differ
+		txs[2].Value = big.NewInt(2)
+
+		for i, tx := range txs {
+			tx := tx
+			isPrivate := i > 0
+
+			go func() {
+				if isPrivate {
+					assert.NoError(t, pool.addPrivateTx(private, tx, ""))
+				} else {
+					assert.NoError(t, pool.addTx(local, tx))
+				}
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+		}
+
+		enqueuedTx := newTx(addr1, 5, 1)
+
+		go func() {
+			assert.NoError(t, pool.addPrivateTx(private, enqueuedTx, ""))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		return pool, append(txs, enqueuedTx)
+	}
+
+	t.Run("txpool_content and txpool_inspect", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := newPoolWithPrivateTxs(t)
+
+		promoted, enqueued := pool.GetTxs(true)
+		require.Len(t, promoted, 1)
+		assert.Equal(t, []*types.Transaction{txs[0]}, promoted[addr1])
+		assert.Empty(t, enqueued)
+	})
+
+	t.Run("txpool_contentFrom", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := newPoolWithPrivateTxs(t)
+
+		promoted, enqueued := pool.GetTxsFrom(addr1)
+		assert.Equal(t, []*types.Transaction{txs[0]}, promoted)
+		assert.Empty(t, enqueued)
+
+		promoted, enqueued = pool.GetTxsFrom(addr2)
+		assert.Empty(t, promoted)
+		assert.Empty(t, enqueued)
+	})
+
+	t.Run("txpool_transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := newPoolWithPrivateTxs(t)
+
+		_, ok := pool.LookupTx(txs[0].Hash)
+		assert.True(t, ok)
+
+		for _, tx := range txs[1:] {
+			_, ok := pool.LookupTx(tx.Hash)
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("eth_getTransactionByHash", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := newPoolWithPrivateTxs(t)
+
+		_, ok := pool.GetPendingTx(txs[0].Hash)
+		assert.True(t, ok)
+
+		for _, tx := range txs[1:] {
+			_, ok := pool.GetPendingTx(tx.Hash)
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("eth_getTransactionCount", func(t *testing.T) {
+		t.Parallel()
+
+		pool, _ := newPoolWithPrivateTxs(t)
+
+		// the private txs are counted, so the sender doesn't reuse their nonces
+		assert.Equal(t, uint64(2), pool.GetNonce(addr1))
+		assert.Equal(t, uint64(1), pool.GetNonce(addr2))
+	})
+
+	t.Run("gRPC Subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		defer cancelFn()
+
+		stream := &mockSubscribeServer{
+			ctx:    ctx,
+			events: make(chan *proto.TxPoolEvent, 10),
+		}
+
+		go func() {
+			assert.NoError(t, pool.Subscribe(
+				&proto.SubscribeRequest{Types: []proto.EventType{proto.EventType_ADDED}},
+				stream,
+			))
+		}()
+
+		// wait for the subscription to be registered
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt64(&pool.eventManager.numSubscriptions) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		privateTx, publicTx := newTx(addr1, 0, 1), newTx(addr2, 0, 1)
+		publicTx.Value = big.NewInt(2)
+
+		go func() {
+			assert.NoError(t, pool.addPrivateTx(private, privateTx, ""))
+		}()
+		<-pool.enqueueReqCh
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, publicTx))
+		}()
+		<-pool.enqueueReqCh
+
+		select {
+		case event := <-stream.events:
+			assert.Equal(t, publicTx.Hash.String(), event.TxHash)
+		case <-time.After(time.Second):
+			t.Fatal("public tx event not received")
+		}
+
+		select {
+		case event := <-stream.events:
+			t.Fatalf("unexpected event for tx %s", event.TxHash)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
 func TestSetSealing(t *testing.T) {
 	t.Parallel()
 
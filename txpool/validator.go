@@ -0,0 +1,179 @@
+package txpool
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// idle rate limiter buckets are removed after this interval
+	rateLimiterIdleTimeout = 10 * time.Minute
+)
+
+var (
+	ErrRateLimitExceeded = errors.New("transaction rate limit exceeded")
+)
+
+// TxValidator is an admission hook invoked for every incoming transaction,
+// after the built-in checks of the pool have passed. Returning an error
+// rejects the transaction.
+type TxValidator interface {
+	ValidateTx(tx *types.Transaction, ctx *TxValidationContext) error
+}
+
+// TxValidatorFunc is an adapter allowing ordinary functions to be used as TxValidator
+type TxValidatorFunc func(tx *types.Transaction, ctx *TxValidationContext) error
+
+func (f TxValidatorFunc) ValidateTx(tx *types.Transaction, ctx *TxValidationContext) error {
+	return f(tx, ctx)
+}
+
+// TxValidatorState gives the admission hooks read access to the state of the head block
+type TxValidatorState interface {
+	GetNonce(addr types.Address) uint64
+	GetBalance(addr types.Address) (*big.Int, error)
+}
+
+// TxValidationContext holds the data an incoming transaction is validated against
+type TxValidationContext struct {
+	// Origin is where the transaction came from (local, gossip, private or relayed)
+	Origin string
+	// ClientAddr is the address of the RPC client which submitted
+	// the transaction (empty if unknown or not submitted through RPC)
+	ClientAddr string
+	// Header is the current head of the chain
+	Header *types.Header
+	// State is the state of the current head
+	State TxValidatorState
+}
+
+// IsRPC returns true if the transaction was submitted through the json-RPC/gRPC endpoints
+// of this node. The private transactions relayed by the trusted peers are not
+func (c *TxValidationContext) IsRPC() bool {
+	return c.Origin == local.String() || c.Origin == private.String()
+}
+
+// headState implements TxValidatorState on top of the pool store
+type headState struct {
+	store store
+	root  types.Hash
+}
+
+func (s *headState) GetNonce(addr types.Address) uint64 {
+	return s.store.GetNonce(s.root, addr)
+}
+
+func (s *headState) GetBalance(addr types.Address) (*big.Int, error) {
+	return s.store.GetBalance(s.root, addr)
+}
+
+// RateLimitValidator limits the number of transactions submitted through
+// the RPC endpoints per sender and per client address
+type RateLimitValidator struct {
+	sync.Mutex
+
+	txsPerSecond rate.Limit
+	burst        int
+
+	buckets   map[string]*rateBucket
+	lastSweep time.Time
+}
+
+// rateBucket is the limiter of a sender or a client address,
+// refilled with txsPerSecond tokens every second, up to burst tokens
+type rateBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimitValidator creates a validator allowing txsPerSecond transactions
+// per sender and per client address, with bursts of up to burst transactions
+func NewRateLimitValidator(txsPerSecond float64, burst uint64) *RateLimitValidator {
+	if burst == 0 {
+		burst = 1
+	}
+
+	return &RateLimitValidator{
+		txsPerSecond: rate.Limit(txsPerSecond),
+		burst:        int(burst),
+		buckets:      make(map[string]*rateBucket),
+		lastSweep:    time.Now(),
+	}
+}
+
+// ValidateTx implements the TxValidator interface
+func (v *RateLimitValidator) ValidateTx(tx *types.Transaction, ctx *TxValidationContext) error {
+	return v.validateAt(tx, ctx, time.Now())
+}
+
+// validateAt checks the limits of the sender and of the client of the transaction at the given time
+func (v *RateLimitValidator) validateAt(tx *types.Transaction, ctx *TxValidationContext, now time.Time) error {
+	// gossiped transactions are limited by the networking layer
+	if !ctx.IsRPC() {
+		return nil
+	}
+
+	v.Lock()
+	defer v.Unlock()
+
+	v.sweep(now)
+
+	keys := []string{"sender:" + tx.From.String()}
+	if ctx.ClientAddr != "" {
+		keys = append(keys, "client:"+ctx.ClientAddr)
+	}
+
+	// none of the limits is consumed unless all of them allow the transaction
+	reservations := make([]*rate.Reservation, 0, len(keys))
+
+	for _, key := range keys {
+		reservation := v.bucket(key, now).limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+
+		if !reservation.OK() || reservation.DelayFrom(now) > 0 {
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+
+			metrics.IncrCounter([]string{txPoolMetrics, "rate_limited_tx"}, 1)
+
+			return ErrRateLimitExceeded
+		}
+	}
+
+	return nil
+}
+
+// bucket returns the bucket of the given key, creating it if needed
+func (v *RateLimitValidator) bucket(key string, now time.Time) *rateBucket {
+	bucket, ok := v.buckets[key]
+	if !ok {
+		bucket = &rateBucket{limiter: rate.NewLimiter(v.txsPerSecond, v.burst)}
+		v.buckets[key] = bucket
+	}
+
+	bucket.lastSeen = now
+
+	return bucket
+}
+
+// sweep removes the buckets which were not used for a while
+func (v *RateLimitValidator) sweep(now time.Time) {
+	if now.Sub(v.lastSweep) < rateLimiterIdleTimeout {
+		return
+	}
+
+	for key, bucket := range v.buckets {
+		if now.Sub(bucket.lastSeen) >= rateLimiterIdleTimeout {
+			delete(v.buckets, key)
+		}
+	}
+
+	v.lastSweep = now
+}
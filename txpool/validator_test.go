@@ -0,0 +1,144 @@
+package txpool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestTxPool_Validators(t *testing.T) {
+	t.Parallel()
+
+	errBlacklisted := errors.New("blacklisted")
+	blacklisted := types.StringToAddress("0xdead")
+
+	var validationCtx *TxValidationContext
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.validators = []TxValidator{
+		TxValidatorFunc(func(tx *types.Transaction, ctx *TxValidationContext) error {
+			validationCtx = ctx
+
+			if tx.To != nil && *tx.To == blacklisted {
+				return errBlacklisted
+			}
+
+			return nil
+		}),
+	}
+
+	tx := newTx(addr1, 0, 1)
+	tx.To = &blacklisted
+
+	assert.ErrorIs(t, pool.validateTx(tx, local, "127.0.0.1"), errBlacklisted)
+
+	require.NotNil(t, validationCtx)
+	assert.Equal(t, local.String(), validationCtx.Origin)
+	assert.Equal(t, "127.0.0.1", validationCtx.ClientAddr)
+	assert.Equal(t, mockHeader, validationCtx.Header)
+	assert.Equal(t, uint64(0), validationCtx.State.GetNonce(addr1))
+
+	tx = newTx(addr1, 0, 1)
+	assert.NoError(t, pool.validateTx(tx, gossip, ""))
+	assert.False(t, validationCtx.IsRPC())
+}
+
+func TestRateLimitValidator(t *testing.T) {
+	t.Parallel()
+
+	rpcCtx := func(clientAddr string) *TxValidationContext {
+		return &TxValidationContext{Origin: local.String(), ClientAddr: clientAddr}
+	}
+
+	t.Run("limit per sender", func(t *testing.T) {
+		t.Parallel()
+
+		validator := NewRateLimitValidator(1, 2)
+
+		assert.NoError(t, validator.ValidateTx(newTx(addr1, 0, 1), rpcCtx("")))
+		assert.NoError(t, validator.ValidateTx(newTx(addr1, 1, 1), rpcCtx("")))
+		assert.ErrorIs(t, validator.ValidateTx(newTx(addr1, 2, 1), rpcCtx("")), ErrRateLimitExceeded)
+
+		// other senders are not affected
+		assert.NoError(t, validator.ValidateTx(newTx(addr2, 0, 1), rpcCtx("")))
+	})
+
+	t.Run("limit per client", func(t *testing.T) {
+		t.Parallel()
+
+		validator := NewRateLimitValidator(1, 1)
+
+		assert.NoError(t, validator.ValidateTx(newTx(addr1, 0, 1), rpcCtx("10.0.0.1")))
+		assert.ErrorIs(t, validator.ValidateTx(newTx(addr2, 0, 1), rpcCtx("10.0.0.1")), ErrRateLimitExceeded)
+		assert.NoError(t, validator.ValidateTx(newTx(addr3, 0, 1), rpcCtx("10.0.0.2")))
+
+		// the rejected tx didn't use up the quota of its sender
+		assert.NoError(t, validator.ValidateTx(newTx(addr2, 1, 1), rpcCtx("10.0.0.3")))
+	})
+
+	t.Run("gossiped and relayed txs are not limited", func(t *testing.T) {
+		t.Parallel()
+
+		validator := NewRateLimitValidator(1, 1)
+
+		for i := uint64(0); i < 3; i++ {
+			assert.NoError(t, validator.ValidateTx(newTx(addr1, i, 1), &TxValidationContext{Origin: gossip.String()}))
+			assert.NoError(t, validator.ValidateTx(newTx(addr2, i, 1), &TxValidationContext{Origin: relayed.String()}))
+		}
+	})
+
+	t.Run("known txs do not use up the quota", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.validators = []TxValidator{NewRateLimitValidator(1, 1)}
+
+		tx := newTx(addr1, 0, 1)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+		<-pool.enqueueReqCh
+
+		// the resubmitted tx is rejected before reaching the rate limiter
+		for i := 0; i < 3; i++ {
+			assert.ErrorIs(t, pool.addTx(local, tx.Copy()), ErrAlreadyKnown)
+		}
+	})
+
+	t.Run("tokens are refilled", func(t *testing.T) {
+		t.Parallel()
+
+		validator := NewRateLimitValidator(1, 1)
+		now := time.Now()
+
+		assert.NoError(t, validator.validateAt(newTx(addr1, 0, 1), rpcCtx(""), now))
+		assert.ErrorIs(t, validator.validateAt(newTx(addr1, 1, 1), rpcCtx(""), now), ErrRateLimitExceeded)
+
+		assert.NoError(t, validator.validateAt(newTx(addr1, 1, 1), rpcCtx(""), now.Add(time.Second)))
+	})
+
+	t.Run("idle buckets are removed", func(t *testing.T) {
+		t.Parallel()
+
+		validator := NewRateLimitValidator(1, 1)
+
+		assert.NoError(t, validator.ValidateTx(newTx(addr1, 0, 1), rpcCtx("")))
+
+		validator.buckets["sender:"+addr1.String()].lastSeen = time.Now().Add(-rateLimiterIdleTimeout)
+		validator.lastSweep = time.Now().Add(-rateLimiterIdleTimeout)
+
+		assert.NoError(t, validator.ValidateTx(newTx(addr2, 0, 1), rpcCtx("")))
+		assert.Len(t, validator.buckets, 1)
+	})
+}
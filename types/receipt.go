@@ -149,3 +149,27 @@ func (b *Bloom) isByteArrPresent(hasher *keccak.Keccak, data []byte) bool {
 
 	return true
 }
+
+// BloomBits returns the global locations of the three bits
+// the given data sets in a bloom filter
+func BloomBits(data []byte) [3]uint {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	hasher.Reset()
+	hasher.Write(data) //nolint:errcheck
+	buf := hasher.Read()
+
+	var bits [3]uint
+
+	for i := 0; i < 6; i += 2 {
+		bits[i/2] = (uint(buf[i+1]) + (uint(buf[i]) << 8)) & (BloomByteLength*8 - 1)
+	}
+
+	return bits
+}
+
+// IsBitSet checks if the bit at the given global location is set in the bloom filter
+func (b *Bloom) IsBitSet(bit uint) bool {
+	return b[BloomByteLength-1-bit/8]&(1<<(bit%8)) != 0
+}
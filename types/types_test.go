@@ -112,3 +112,28 @@ func TestIsValidAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestBloomBits(t *testing.T) {
+	t.Parallel()
+
+	address := StringToAddress("1")
+
+	bloom := CreateBloom([]*Receipt{
+		{Logs: []*Log{{Address: address}}},
+	})
+
+	set := 0
+
+	for bit := uint(0); bit < BloomByteLength*8; bit++ {
+		if bloom.IsBitSet(bit) {
+			set++
+		}
+	}
+
+	for _, bit := range BloomBits(address.Bytes()) {
+		assert.True(t, bloom.IsBitSet(bit))
+	}
+
+	assert.LessOrEqual(t, set, 3)
+	assert.True(t, bloom.IsLogInBloom(&Log{Address: address}))
+}